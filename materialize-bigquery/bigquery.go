@@ -25,6 +25,7 @@ type config struct {
 	Bucket           string `json:"bucket" jsonschema:"title=Bucket,description=Google Cloud Storage bucket that is going to be used to store specfications & temporary data before merging into BigQuery." jsonschema_extras:"order=4"`
 	BucketPath       string `json:"bucket_path,omitempty" jsonschema:"title=Bucket Path,description=A prefix that will be used to store objects to Google Cloud Storage's bucket." jsonschema_extras:"order=5"`
 	BillingProjectID string `json:"billing_project_id,omitempty" jsonschema:"title=Billing Project ID,description=Billing Project ID connected to the BigQuery dataset. Defaults to Project ID if not specified." jsonschema_extras:"order=6"`
+	HardDelete       bool   `json:"hardDelete,omitempty" jsonschema:"title=Hard Delete,description=Delete rows from standard-updates tables when their source documents are deleted (such as CDC deletions having a '_meta/op' of 'd'). By default deletions are stored as regular rows." jsonschema_extras:"order=7"`
 
 	Advanced advancedConfig `json:"advanced,omitempty" jsonschema:"title=Advanced Options,description=Options for advanced users. You should not typically need to modify these." jsonschema_extras:"advanced=true"`
 }
@@ -172,16 +173,18 @@ func newBigQueryDriver() *sql.Driver {
 			var metaSpecs, metaCheckpoints = sql.MetaTables(metaBase)
 
 			return &sql.Endpoint{
-				Config:              cfg,
-				Dialect:             bqDialect,
-				MetaSpecs:           &metaSpecs,
-				MetaCheckpoints:     &metaCheckpoints,
-				NewClient:           newClient,
-				CreateTableTemplate: tplCreateTargetTable,
-				NewResource:         newTableConfig,
-				NewTransactor:       newTransactor,
-				Tenant:              tenant,
-				ConcurrentApply:     true,
+				Config:                     cfg,
+				Dialect:                    bqDialect,
+				MetaSpecs:                  &metaSpecs,
+				MetaCheckpoints:            &metaCheckpoints,
+				NewClient:                  newClient,
+				CreateTableTemplate:        tplCreateTargetTable,
+				NewResource:                newTableConfig,
+				NewTransactor:              newTransactor,
+				Tenant:                     tenant,
+				ConcurrentApply:            true,
+				HardDelete:                 cfg.HardDelete,
+				HardDeleteRequiresDocument: true,
 			}, nil
 		},
 	}
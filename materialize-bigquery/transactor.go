@@ -245,9 +245,21 @@ func (t *transactor) Store(it *m.StoreIterator) (m.StartCommitFunc, error) {
 	log.Info("store: starting encoding and uploading of files")
 	for it.Next() {
 		var b = t.bindings[it.Binding]
+
+		var hardDelete = b.target.IsHardDelete(it)
+		if hardDelete && !it.Exists {
+			continue // There's nothing to delete if the key was never stored.
+		}
 		b.storeFile.start()
 
-		converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
+		var converted []interface{}
+		var err error
+		if hardDelete {
+			// The merge deletes matched rows having a null document.
+			converted, err = b.target.ConvertHardDelete(it.Key, it.Values)
+		} else {
+			converted, err = b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("converting store parameters: %w", err)
 		}
@@ -8,6 +8,7 @@ import (
 
 	pf "github.com/estuary/flow/go/protocols/flow"
 	pm "github.com/estuary/flow/go/protocols/materialize"
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -73,10 +74,25 @@ type Applier interface {
 	UpdateResource(ctx context.Context, spec *pf.MaterializationSpec, bindingIndex int, bindingUpdate BindingUpdate) (string, ActionApplyFn, error)
 }
 
+type dryRunApplyKey struct{}
+
+// WithDryRunApply returns a Context which causes ApplyChanges to plan the actions needed to apply a
+// materialization without executing any of them, including persisting its spec. The descriptions
+// of the planned actions are returned in the response so that they can be reviewed before the
+// materialization is applied for real.
+func WithDryRunApply(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunApplyKey{}, true)
+}
+
+func isDryRunApply(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunApplyKey{}).(bool)
+	return dryRun
+}
+
 // ApplyChanges applies changes to an endpoint. It computes these changes from the apply request and
 // the state of the endpoint per the `InfoSchema`. The `Applier` executes the resulting actions,
 // optionally with a concurrent scatter/gather for expedience on endpoints that would benefit from
-// that sort of thing.
+// that sort of thing. If the context is from WithDryRunApply, the actions are only described.
 func ApplyChanges(ctx context.Context, req *pm.Request_Apply, applier Applier, is *InfoSchema, concurrent bool) (*pm.Response_Applied, error) {
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("validating request: %w", err)
@@ -219,6 +235,19 @@ func ApplyChanges(ctx context.Context, req *pm.Request_Apply, applier Applier, i
 		}
 	}
 
+	if isDryRunApply(ctx) {
+		desc, action, err := applier.PutSpec(ctx, req.Materialization, req.Version, storedSpec != nil)
+		if err != nil {
+			return nil, fmt.Errorf("getting PutSpec action: %w", err)
+		}
+		addAction(desc, action)
+
+		log.WithField("actions", len(actionDescriptions)).Info("dry run: planned apply actions will not be executed")
+		return &pm.Response_Applied{
+			ActionDescription: "Dry run: the following actions were planned but not executed.\n" + strings.Join(actionDescriptions, "\n"),
+		}, nil
+	}
+
 	if concurrent {
 		group, groupCtx := errgroup.WithContext(ctx)
 		group.SetLimit(maxConcurrentUpdateActions)
@@ -144,6 +144,22 @@ func TestApply(t *testing.T) {
 	}
 
 	cupaloy.SnapshotT(t, snap.String())
+
+	t.Run("dry run", func(t *testing.T) {
+		original := loadApplySpec(t, "base.flow.proto")
+		app := &testApplier{storedSpec: original}
+		is := testInfoSchemaFromSpec(t, original, simpleTestTransform)
+
+		req := &pm.Request_Apply{Materialization: loadApplySpec(t, "replace-original-binding.flow.proto"), Version: "aVersion"}
+
+		got, err := ApplyChanges(WithDryRunApply(ctx), req, app, is, false)
+		require.NoError(t, err)
+		require.Equal(t, testResults{}, app.getResults())
+
+		want, err := ApplyChanges(ctx, req, app, is, false)
+		require.NoError(t, err)
+		require.Equal(t, "Dry run: the following actions were planned but not executed.\n"+want.ActionDescription, got.ActionDescription)
+	})
 }
 
 type testResults struct {
@@ -11,6 +11,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"net/http"
@@ -36,6 +37,20 @@ type Connector interface {
 // ConnectorServer wraps a Connector to implement the pc.ConnectorServer gRPC service interface.
 type ConnectorServer struct {
 	Connector
+
+	// DryRunApply causes Apply requests to be planned without being executed, so that the actions
+	// which would be taken can be reviewed. Apply requests fail if the Connector isn't a
+	// DryRunApplier. See WithDryRunApply.
+	DryRunApply bool
+}
+
+// DryRunApplier is implemented by Connectors which honor a Context from WithDryRunApply in their
+// Apply, which is the case for those which apply changes using ApplyChanges. Connectors must opt
+// in, so that a dry run never executes the actions of a connector which applies changes itself.
+type DryRunApplier interface {
+	// SupportsDryRunApply returns true if Apply only plans its actions when called with a Context
+	// from WithDryRunApply.
+	SupportsDryRunApply() bool
 }
 
 // RunMain is the boilerplate main function of a materialization connector.
@@ -78,7 +93,12 @@ func RunMain(connector Connector) {
 		}
 	}()
 
-	var server = ConnectorServer{connector}
+	dryRunApply, err := strconv.ParseBool(getEnvDefault("APPLY_DRY_RUN", "false"))
+	if err != nil {
+		log.WithField("value", os.Getenv("APPLY_DRY_RUN")).Fatal("invalid APPLY_DRY_RUN (expected a boolean)")
+	}
+
+	var server = ConnectorServer{Connector: connector, DryRunApply: dryRunApply}
 
 	if err := server.Materialize(stream); err != nil {
 		cerrors.HandleFinalError(err)
@@ -127,7 +147,7 @@ func (s *ConnectorServer) Materialize(stream pm.Connector_MaterializeServer) err
 				return err
 			}
 		case request.Apply != nil:
-			if response, err := s.Connector.Apply(ctx, request.Apply); err != nil {
+			if response, err := s.apply(ctx, request.Apply); err != nil {
 				return err
 			} else if err := stream.Send(&pm.Response{Applied: response}); err != nil {
 				return err
@@ -144,6 +164,17 @@ func (s *ConnectorServer) Materialize(stream pm.Connector_MaterializeServer) err
 	}
 }
 
+// apply runs the Apply request with the Connector, as a dry run if DryRunApply is set. Dry runs are
+// refused for Connectors which don't support them, rather than applying their changes.
+func (s *ConnectorServer) apply(ctx context.Context, req *pm.Request_Apply) (*pm.Response_Applied, error) {
+	if !s.DryRunApply {
+		return s.Connector.Apply(ctx, req)
+	} else if d, ok := s.Connector.(DryRunApplier); !ok || !d.SupportsDryRunApply() {
+		return nil, fmt.Errorf("APPLY_DRY_RUN is set but this connector does not support dry runs of apply")
+	}
+	return s.Connector.Apply(WithDryRunApply(ctx), req)
+}
+
 func newProtoCodec(ctx context.Context) pm.Connector_MaterializeServer {
 	return &protoCodec{
 		ctx: ctx,
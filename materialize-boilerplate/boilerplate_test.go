@@ -0,0 +1,53 @@
+package boilerplate
+
+import (
+	"context"
+	"testing"
+
+	pm "github.com/estuary/flow/go/protocols/materialize"
+	"github.com/stretchr/testify/require"
+)
+
+type testApplyConnector struct {
+	Connector
+	supportsDryRun bool
+	dryRuns        []bool
+}
+
+func (c *testApplyConnector) Apply(ctx context.Context, req *pm.Request_Apply) (*pm.Response_Applied, error) {
+	c.dryRuns = append(c.dryRuns, isDryRunApply(ctx))
+	return &pm.Response_Applied{}, nil
+}
+
+type testDryRunConnector struct{ *testApplyConnector }
+
+func (c testDryRunConnector) SupportsDryRunApply() bool { return c.supportsDryRun }
+
+func TestConnectorServerDryRunApply(t *testing.T) {
+	var ctx = context.Background()
+	var req = &pm.Request_Apply{}
+
+	// Connectors which don't opt in never have their Apply called for a dry run.
+	var conn = &testApplyConnector{}
+	var server = ConnectorServer{Connector: conn, DryRunApply: true}
+	_, err := server.apply(ctx, req)
+	require.ErrorContains(t, err, "does not support dry runs")
+	require.Empty(t, conn.dryRuns)
+
+	server.Connector = testDryRunConnector{conn}
+	_, err = server.apply(ctx, req)
+	require.ErrorContains(t, err, "does not support dry runs")
+	require.Empty(t, conn.dryRuns)
+
+	conn.supportsDryRun = true
+	_, err = server.apply(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []bool{true}, conn.dryRuns)
+
+	// Without a dry run, all connectors apply normally.
+	server.DryRunApply = false
+	server.Connector = conn
+	_, err = server.apply(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false}, conn.dryRuns)
+}
@@ -26,6 +26,15 @@ type Constrainter interface {
 	DescriptionForType(p *pf.Projection, rawFieldConfig json.RawMessage) (string, error)
 }
 
+// Migrator is optionally implemented by a Constrainter for endpoints which can migrate the type of
+// an existing materialized field in place, rather than requiring the binding to be backfilled when
+// its projection is no longer compatible.
+type Migrator interface {
+	// Migratable reports whether an existing materialized field, which is not compatible with a
+	// proposed projection, can be migrated to the type required by the projection.
+	Migratable(existing EndpointField, proposed *pf.Projection, rawFieldConfig json.RawMessage) (bool, error)
+}
+
 // Validator performs validation of a materialization binding.
 type Validator struct {
 	c  Constrainter
@@ -266,15 +275,31 @@ func (v Validator) validateMatchesExistingBinding(
 					return nil, fmt.Errorf("getting description for field %q of bound collection %q: %w", p.Field, boundCollection.Name.String(), err)
 				}
 
-				c = &pm.Response_Validated_Constraint{
-					Type: pm.Response_Validated_Constraint_UNSATISFIABLE,
-					Reason: fmt.Sprintf(
-						"Field '%s' is already being materialized as endpoint type '%s' but endpoint type '%s' is required by its schema '%s'",
-						p.Field,
-						strings.ToUpper(existingField.Type),
-						strings.ToUpper(newDesc),
-						fieldSchema(p),
-					),
+				migratable, err := v.migratable(existingField, &p, rawConfig)
+				if err != nil {
+					return nil, fmt.Errorf("determining migratability for endpoint field %q vs. selected field %q: %w", existingField.Name, p.Field, err)
+				}
+
+				if migratable {
+					c = &pm.Response_Validated_Constraint{
+						Type: pm.Response_Validated_Constraint_LOCATION_RECOMMENDED,
+						Reason: fmt.Sprintf(
+							"This location is part of the current materialization, and its endpoint type '%s' will be migrated to '%s'",
+							strings.ToUpper(existingField.Type),
+							strings.ToUpper(newDesc),
+						),
+					}
+				} else {
+					c = &pm.Response_Validated_Constraint{
+						Type: pm.Response_Validated_Constraint_UNSATISFIABLE,
+						Reason: fmt.Sprintf(
+							"Field '%s' is already being materialized as endpoint type '%s' but endpoint type '%s' is required by its schema '%s'",
+							p.Field,
+							strings.ToUpper(existingField.Type),
+							strings.ToUpper(newDesc),
+							fieldSchema(p),
+						),
+					}
 				}
 			}
 		}
@@ -312,6 +337,16 @@ func (v Validator) validateMatchesExistingBinding(
 	return constraints, nil
 }
 
+// migratable reports whether an incompatible existing field can be migrated in place to the type
+// required by the proposed projection. Collection keys are never migrated.
+func (v Validator) migratable(existing EndpointField, p *pf.Projection, rawFieldConfig json.RawMessage) (bool, error) {
+	m, ok := v.c.(Migrator)
+	if !ok || p.IsPrimaryKey {
+		return false, nil
+	}
+	return m.Migratable(existing, p, rawFieldConfig)
+}
+
 // ambiguousFields determines if the given projection is part of a set of projections that would
 // result in ambiguous field names in the destination system. Fields are "ambiguous" if their
 // destination treats more than one Flow collection field name as the same materialized field name.
@@ -268,6 +268,26 @@ func TestValidate(t *testing.T) {
 		require.ErrorContains(t, err, "backfill count 0 is less than previously applied count of 1")
 	})
 
+	t.Run("migratable incompatible fields are recommended", func(t *testing.T) {
+		existing := loadValidateSpec(t, "base.flow.proto")
+		proposed := loadValidateSpec(t, "incompatible-changes.flow.proto")
+		is := testInfoSchemaFromSpec(t, existing, simpleTestTransform)
+		validator := NewValidator(testMigratingConstrainter{}, is, 0, true)
+
+		cs, err := validator.ValidateBinding(
+			[]string{"key_value"},
+			false,
+			proposed.Bindings[0].Backfill,
+			proposed.Bindings[0].Collection,
+			proposed.Bindings[0].FieldSelection.FieldConfigJsonMap,
+			existing,
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, pm.Response_Validated_Constraint_LOCATION_RECOMMENDED, cs["nonScalarValue"].Type)
+		require.Equal(t, "This location is part of the current materialization, and its endpoint type 'OBJECT' will be migrated to 'STRING'", cs["nonScalarValue"].Reason)
+	})
+
 	t.Run("can't switch from delta to standard updates", func(t *testing.T) {
 		existing := loadValidateSpec(t, "base.flow.proto")
 		proposed := loadValidateSpec(t, "base.flow.proto")
@@ -557,3 +577,12 @@ func (testConstrainter) NewConstraints(p *pf.Projection, deltaUpdates bool) *pm.
 	}
 	return constraint
 }
+
+// testMigratingConstrainter can migrate any incompatible field that is currently an object.
+type testMigratingConstrainter struct {
+	testConstrainter
+}
+
+func (testMigratingConstrainter) Migratable(existing EndpointField, _ *pf.Projection, _ json.RawMessage) (bool, error) {
+	return existing.Type == "object", nil
+}
@@ -16,6 +16,7 @@ type config struct {
 	HTTPPath    string `json:"http_path" jsonschema:"title=HTTP path,description=HTTP path of your SQL warehouse"`
 	CatalogName string `json:"catalog_name" jsonschema:"title=Catalog Name,description=Name of your Unity Catalog."`
 	SchemaName  string `json:"schema_name" jsonschema:"title=Schema Name,description=Default schema to materialize to,default=default"`
+	HardDelete  bool   `json:"hardDelete,omitempty" jsonschema:"title=Hard Delete,description=Delete rows from standard-updates tables when their source documents are deleted (such as CDC deletions having a '_meta/op' of 'd'). By default deletions are stored as regular rows."`
 
 	Credentials credentialConfig `json:"credentials" jsonschema:"title=Authentication"`
 
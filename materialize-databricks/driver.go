@@ -91,16 +91,18 @@ func newDatabricksDriver() *sql.Driver {
 			var metaSpecs, _ = sql.MetaTables(metaBase)
 
 			return &sql.Endpoint{
-				Config:              cfg,
-				Dialect:             databricksDialect,
-				MetaSpecs:           &metaSpecs,
-				MetaCheckpoints:     nil,
-				NewClient:           newClient,
-				CreateTableTemplate: tplCreateTargetTable,
-				NewResource:         newTableConfig,
-				NewTransactor:       newTransactor,
-				Tenant:              tenant,
-				ConcurrentApply:     true,
+				Config:                     cfg,
+				Dialect:                    databricksDialect,
+				MetaSpecs:                  &metaSpecs,
+				MetaCheckpoints:            nil,
+				NewClient:                  newClient,
+				CreateTableTemplate:        tplCreateTargetTable,
+				NewResource:                newTableConfig,
+				NewTransactor:              newTransactor,
+				Tenant:                     tenant,
+				ConcurrentApply:            true,
+				HardDelete:                 cfg.HardDelete,
+				HardDeleteRequiresDocument: true,
 			}, nil
 		},
 	}
@@ -371,10 +373,23 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 	for it.Next() {
 		var b = d.bindings[it.Binding]
 
-		if err := b.storeFile.start(ctx); err != nil {
-			return nil, err
-		} else if converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON); err != nil {
+		var hardDelete = b.target.IsHardDelete(it)
+		if hardDelete && !it.Exists {
+			continue // There's nothing to delete if the key was never stored.
+		}
+
+		var converted []interface{}
+		if hardDelete {
+			// The merge deletes matched rows having a null document.
+			converted, err = b.target.ConvertHardDelete(it.Key, it.Values)
+		} else {
+			converted, err = b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
+		}
+
+		if err != nil {
 			return nil, fmt.Errorf("converting store parameters: %w", err)
+		} else if err := b.storeFile.start(ctx); err != nil {
+			return nil, err
 		} else if err := b.storeFile.encodeRow(converted); err != nil {
 			return nil, fmt.Errorf("encoding row for store: %w", err)
 		}
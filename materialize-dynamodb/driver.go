@@ -272,6 +272,12 @@ func (d driver) Apply(ctx context.Context, req *pm.Request_Apply) (*pm.Response_
 	}, is, true)
 }
 
+// SupportsDryRunApply implements the boilerplate.DryRunApplier interface, since Apply uses
+// boilerplate.ApplyChanges.
+func (d driver) SupportsDryRunApply() bool {
+	return true
+}
+
 func (d driver) NewTransactor(ctx context.Context, open pm.Request_Open) (m.Transactor, *pm.Response_Opened, error) {
 	var cfg, err = resolveEndpointConfig(open.Materialization.ConfigJson)
 	if err != nil {
@@ -450,6 +450,12 @@ func (driver) Apply(ctx context.Context, req *pm.Request_Apply) (*pm.Response_Ap
 	}, is, true)
 }
 
+// SupportsDryRunApply implements the boilerplate.DryRunApplier interface, since Apply uses
+// boilerplate.ApplyChanges.
+func (driver) SupportsDryRunApply() bool {
+	return true
+}
+
 func (d driver) NewTransactor(ctx context.Context, open pm.Request_Open) (m.Transactor, *pm.Response_Opened, error) {
 	var cfg config
 	if err := pf.UnmarshalStrict(open.Materialization.ConfigJson, &cfg); err != nil {
@@ -36,12 +36,13 @@ type tunnelConfig struct {
 
 // config represents the endpoint configuration for mysql.
 type config struct {
-	Address  string         `json:"address" jsonschema:"title=Address,description=Host and port of the database (in the form of host[:port]). Port 3306 is used as the default if no specific port is provided." jsonschema_extras:"order=0"`
-	User     string         `json:"user" jsonschema:"title=User,description=Database user to connect as." jsonschema_extras:"order=1"`
-	Password string         `json:"password" jsonschema:"title=Password,description=Password for the specified database user." jsonschema_extras:"secret=true,order=2"`
-	Database string         `json:"database" jsonschema:"title=Database,description=Name of the logical database to materialize to." jsonschema_extras:"order=3"`
-	Timezone string         `json:"timezone,omitempty" jsonschema:"title=Timezone,description=Timezone to use when materializing datetime columns. Should normally be left blank to use the database's 'time_zone' system variable. Only required if the 'time_zone' system variable cannot be read. Must be a valid IANA time zone name or +HH:MM offset. Takes precedence over the 'time_zone' system variable if both are set." jsonschema_extras:"order=4"`
-	Advanced advancedConfig `json:"advanced,omitempty" jsonschema:"title=Advanced Options,description=Options for advanced users. You should not typically need to modify these." jsonschema_extras:"advanced=true"`
+	Address    string         `json:"address" jsonschema:"title=Address,description=Host and port of the database (in the form of host[:port]). Port 3306 is used as the default if no specific port is provided." jsonschema_extras:"order=0"`
+	User       string         `json:"user" jsonschema:"title=User,description=Database user to connect as." jsonschema_extras:"order=1"`
+	Password   string         `json:"password" jsonschema:"title=Password,description=Password for the specified database user." jsonschema_extras:"secret=true,order=2"`
+	Database   string         `json:"database" jsonschema:"title=Database,description=Name of the logical database to materialize to." jsonschema_extras:"order=3"`
+	Timezone   string         `json:"timezone,omitempty" jsonschema:"title=Timezone,description=Timezone to use when materializing datetime columns. Should normally be left blank to use the database's 'time_zone' system variable. Only required if the 'time_zone' system variable cannot be read. Must be a valid IANA time zone name or +HH:MM offset. Takes precedence over the 'time_zone' system variable if both are set." jsonschema_extras:"order=4"`
+	HardDelete bool           `json:"hardDelete,omitempty" jsonschema:"title=Hard Delete,description=Delete rows from standard-updates tables when their source documents are deleted (such as CDC deletions having a '_meta/op' of 'd'). By default deletions are stored as regular rows." jsonschema_extras:"order=5"`
+	Advanced   advancedConfig `json:"advanced,omitempty" jsonschema:"title=Advanced Options,description=Options for advanced users. You should not typically need to modify these." jsonschema_extras:"advanced=true"`
 
 	NetworkTunnel *tunnelConfig `json:"networkTunnel,omitempty" jsonschema:"title=Network Tunnel,description=Connect to your system through an SSH server that acts as a bastion host for your network."`
 }
@@ -184,8 +185,9 @@ func (c *config) ToURI() string {
 }
 
 type tableConfig struct {
-	Table string `json:"table" jsonschema:"title=Table,description=Name of the database table" jsonschema_extras:"x-collection-name=true"`
-	Delta bool   `json:"delta_updates,omitempty" jsonschema:"default=false,title=Delta Update,description=Should updates to this table be done via delta updates. Default is false."`
+	Table       string `json:"table" jsonschema:"title=Table,description=Name of the database table" jsonschema_extras:"x-collection-name=true"`
+	Delta       bool   `json:"delta_updates,omitempty" jsonschema:"default=false,title=Delta Update,description=Should updates to this table be done via delta updates. Default is false."`
+	HistoryMode bool   `json:"history_mode,omitempty" jsonschema:"default=false,title=History Mode,description=Should this table keep every version of each document as a separate row (a type 2 slowly-changing dimension). Versions are tracked by the valid_from and valid_to and is_current columns. Default is false."`
 }
 
 func newTableConfig(ep *sql.Endpoint) sql.Resource {
@@ -208,6 +210,10 @@ func (c tableConfig) DeltaUpdates() bool {
 	return c.Delta
 }
 
+func (c tableConfig) History() bool {
+	return c.HistoryMode
+}
+
 func newMysqlDriver() *sql.Driver {
 	return &sql.Driver{
 		DocumentationURL: "https://go.estuary.dev/materialize-mysql",
@@ -313,6 +319,7 @@ func newMysqlDriver() *sql.Driver {
 				NewTransactor:       prepareNewTransactor(dialect, templates),
 				Tenant:              tenant,
 				ConcurrentApply:     false,
+				HardDelete:          cfg.HardDelete,
 			}, nil
 		},
 	}
@@ -444,6 +451,9 @@ type binding struct {
 	storeUpdateSQL       string
 	updateReplaceSQL     string
 	updateTruncateSQL    string
+	historyCloseSQL      string
+	historyInsertSQL     string
+	storeDeleteSQL       string
 
 	mustMerge bool
 }
@@ -463,6 +473,9 @@ func (t *transactor) addBinding(ctx context.Context, target sql.Table, is *boile
 		{&b.storeUpdateSQL, t.templates.updateLoad},
 		{&b.updateReplaceSQL, t.templates.updateReplace},
 		{&b.updateTruncateSQL, t.templates.updateTruncate},
+		{&b.historyCloseSQL, t.templates.historyClose},
+		{&b.historyInsertSQL, t.templates.historyInsert},
+		{&b.storeDeleteSQL, t.templates.storeDelete},
 		{&b.tempTableName, t.templates.tempTableName},
 		{&b.tempTruncate, t.templates.tempTruncate},
 	} {
@@ -680,6 +693,20 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 
 		var b = d.bindings[it.Binding]
 
+		if b.target.IsHardDelete(it) {
+			if !it.Exists {
+				continue // There's nothing to delete if the key was never stored.
+			}
+			// Deletions are expected to be comparatively rare, and are applied directly to the
+			// target table rather than being staged through an infile.
+			if converted, err := b.target.ConvertKey(it.Key); err != nil {
+				return nil, fmt.Errorf("converting delete parameters: %w", err)
+			} else if _, err := txn.ExecContext(ctx, b.storeDeleteSQL, converted...); err != nil {
+				return nil, fmt.Errorf("deleting from %q: %w", b.target.Identifier, err)
+			}
+			continue
+		}
+
 		converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
 		if err != nil {
 			return nil, fmt.Errorf("converting store parameters: %w", err)
@@ -739,11 +766,23 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 					continue
 				}
 
-				// Merge data from the temporary staging table into the target table, replacing keys
-				// that already exist.
-				if _, err := txn.ExecContext(ctx, b.updateReplaceSQL); err != nil {
-					return fmt.Errorf("running REPLACE INTO for %q: %w", b.target.Identifier, err)
-				} else if _, err := txn.ExecContext(ctx, b.updateTruncateSQL); err != nil {
+				if b.target.History {
+					// Close out the current versions of keys that already exist, and insert their
+					// new versions.
+					if _, err := txn.ExecContext(ctx, b.historyCloseSQL); err != nil {
+						return fmt.Errorf("closing out current versions for %q: %w", b.target.Identifier, err)
+					} else if _, err := txn.ExecContext(ctx, b.historyInsertSQL); err != nil {
+						return fmt.Errorf("inserting new versions for %q: %w", b.target.Identifier, err)
+					}
+				} else {
+					// Merge data from the temporary staging table into the target table, replacing
+					// keys that already exist.
+					if _, err := txn.ExecContext(ctx, b.updateReplaceSQL); err != nil {
+						return fmt.Errorf("running REPLACE INTO for %q: %w", b.target.Identifier, err)
+					}
+				}
+
+				if _, err := txn.ExecContext(ctx, b.updateTruncateSQL); err != nil {
 					return fmt.Errorf("truncating update table for %q: %w", b.target.Identifier, err)
 				}
 
@@ -163,6 +163,9 @@ type templates struct {
 	updateLoad        *template.Template
 	updateReplace     *template.Template
 	updateTruncate    *template.Template
+	historyClose      *template.Template
+	historyInsert     *template.Template
+	storeDelete       *template.Template
 	insertLoad        *template.Template
 	loadQuery         *template.Template
 	loadLoad          *template.Template
@@ -188,7 +191,18 @@ CREATE TABLE IF NOT EXISTS {{$.Identifier}} (
 		{{- if $ind }},{{ end }}
 		{{$col.Identifier}} {{$col.DDL}} {{- if $col.Comment }} COMMENT {{Literal $col.Comment}}{{- end }}
 	{{- end }}
-	{{- if not $.DeltaUpdates }},
+	{{- if $.HistoryColumns }}
+	{{- range $col := $.HistoryColumns.All }},
+		{{$col.Identifier}} {{$col.DDL}} COMMENT {{Literal $col.Comment}}
+	{{- end }},
+
+		INDEX (
+	{{- range $ind, $key := $.Keys }}
+		{{- if $ind }}, {{end -}}
+		{{$key.Identifier}}
+	{{- end -}}
+		, {{ $.HistoryColumns.IsCurrent.Identifier }})
+	{{- else if not $.DeltaUpdates }},
 
 		PRIMARY KEY (
 	{{- range $ind, $key := $.Keys }}
@@ -271,12 +285,16 @@ SELECT {{ $.Binding }}, r.{{$.Document.Identifier}}
 		{{ if $ind }} AND {{ else }} ON  {{ end -}}
 		l.{{ $key.Identifier }} = r.{{ $key.Identifier }}
 	{{- end }}
+	{{- if $.HistoryColumns }}
+		 AND r.{{ $.HistoryColumns.IsCurrent.Identifier }}
+	{{- end }}
 {{ else -}}
 SELECT * FROM (SELECT -1, CAST(NULL AS JSON) LIMIT 0) as nodoc
 {{ end }}
 {{ end }}
 
--- Template to load data into target table
+-- Template to load data into target table. Rows of history-mode tables are loaded as the current
+-- version of their keys.
 
 {{ define "insertLoad" }}
 LOAD DATA LOCAL INFILE 'Reader::flow_batch_data_insert' INTO TABLE {{ $.Identifier }}
@@ -291,7 +309,13 @@ LOAD DATA LOCAL INFILE 'Reader::flow_batch_data_insert' INTO TABLE {{ $.Identifi
 		{{- if $ind }},{{ end }}
 		{{$col.Identifier}}
 	{{- end }}
-);
+)
+{{- if $.HistoryColumns }}
+SET
+	{{ $.HistoryColumns.ValidFrom.Identifier }} = NOW(6),
+	{{ $.HistoryColumns.IsCurrent.Identifier }} = TRUE
+{{- end -}}
+;
 {{ end }}
 
 {{ define "createUpdateTable" }}
@@ -347,6 +371,60 @@ FROM {{ template "temp_update_name" . }};
 TRUNCATE {{ template "temp_update_name" . }};
 {{ end }}
 
+-- Templated query which closes out the current versions of keys of a history-mode table which
+-- are present in the temporary update table:
+
+{{ define "historyClose" }}
+{{ if $.HistoryColumns -}}
+UPDATE {{ $.Identifier }} AS t
+	JOIN {{ template "temp_update_name" . }} AS u
+	{{- range $ind, $key := $.Keys }}
+		{{ if $ind }} AND {{ else }} ON  {{ end -}}
+		t.{{ $key.Identifier }} = u.{{ $key.Identifier }}
+	{{- end }}
+	SET
+		t.{{ $.HistoryColumns.ValidTo.Identifier }} = NOW(6),
+		t.{{ $.HistoryColumns.IsCurrent.Identifier }} = FALSE
+	WHERE t.{{ $.HistoryColumns.IsCurrent.Identifier }};
+{{- end }}
+{{ end }}
+
+-- Templated query which inserts the rows of the temporary update table as the current versions of
+-- their keys in a history-mode table:
+
+{{ define "historyInsert" }}
+{{ if $.HistoryColumns -}}
+INSERT INTO {{ $.Identifier }}
+(
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }},{{ end }}
+		{{$col.Identifier}}
+	{{- end }},
+		{{ $.HistoryColumns.ValidFrom.Identifier }},
+		{{ $.HistoryColumns.IsCurrent.Identifier }}
+)
+SELECT
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }},{{ end }}
+		{{$col.Identifier}}
+	{{- end }},
+		NOW(6),
+		TRUE
+FROM {{ template "temp_update_name" . }};
+{{- end }}
+{{ end }}
+
+-- Template to delete a single key from the target table
+
+{{ define "storeDelete" }}
+DELETE FROM {{ $.Identifier }}
+	{{- range $ind, $key := $.Keys }}
+	{{ if $ind }} AND   {{ else }} WHERE {{ end -}}
+	{{ $key.Identifier }} = {{ $key.Placeholder }}
+	{{- end -}}
+	;
+{{ end }}
+
 {{ define "installFence" }}
 with
 -- Increment the fence value of _any_ checkpoint which overlaps our key range.
@@ -406,6 +484,9 @@ UPDATE {{ Identifier $.TablePath }}
 		updateLoad:        tplAll.Lookup("updateLoad"),
 		updateReplace:     tplAll.Lookup("updateReplace"),
 		updateTruncate:    tplAll.Lookup("truncateUpdateTable"),
+		historyClose:      tplAll.Lookup("historyClose"),
+		historyInsert:     tplAll.Lookup("historyInsert"),
+		storeDelete:       tplAll.Lookup("storeDelete"),
 		insertLoad:        tplAll.Lookup("insertLoad"),
 		loadQuery:         tplAll.Lookup("loadQuery"),
 		loadLoad:          tplAll.Lookup("loadLoad"),
@@ -34,11 +34,17 @@ func TestSQLGeneration(t *testing.T) {
 		Delta: true,
 	})
 	shape2.Document = nil // TODO(johnny): this is a bit gross.
+	var shape3 = sqlDriver.BuildTableShape(spec, 0, tableConfig{
+		Table:       "history_table",
+		HistoryMode: true,
+	})
 
 	table1, err := sqlDriver.ResolveTable(shape1, testDialect)
 	require.NoError(t, err)
 	table2, err := sqlDriver.ResolveTable(shape2, testDialect)
 	require.NoError(t, err)
+	table3, err := sqlDriver.ResolveTable(shape3, testDialect)
+	require.NoError(t, err)
 
 	var snap strings.Builder
 
@@ -52,8 +58,11 @@ func TestSQLGeneration(t *testing.T) {
 		templates.updateLoad,
 		templates.updateReplace,
 		templates.updateTruncate,
+		templates.historyClose,
+		templates.historyInsert,
+		templates.storeDelete,
 	} {
-		for _, tbl := range []sqlDriver.Table{table1, table2} {
+		for _, tbl := range []sqlDriver.Table{table1, table2, table3} {
 			var testcase = tbl.Identifier + " " + tpl.Name()
 
 			snap.WriteString("--- Begin " + testcase + " ---\n")
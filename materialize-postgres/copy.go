@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
+// copyValue converts a store parameter into a value which pgx encodes in the binary format of a
+// column having the nullable DDL `ddl`, for bulk loading it with COPY. pgx copies strings as-is,
+// which is only correct for textual types, so strings of other types are parsed the same way the
+// server would parse them as the text of a statement parameter. Parameters which aren't strings
+// are encoded by pgx for the type of their column.
+func copyValue(ddl string, param interface{}) (interface{}, error) {
+	str, ok := param.(string)
+	if !ok {
+		return param, nil
+	}
+
+	switch ddl {
+	case "TEXT", "JSON":
+		return str, nil
+	case "TIMESTAMPTZ":
+		return time.Parse(time.RFC3339Nano, str)
+	case "TIME":
+		return parseCopyTime(str)
+	case "DATE":
+		var v pgtype.Date
+		if err := v.DecodeText(nil, []byte(str)); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "NUMERIC", "DECIMAL":
+		return parseCopyNumeric(str)
+	case "INTERVAL":
+		return parseCopyInterval(str)
+	case "CIDR":
+		var v pgtype.CIDR
+		if err := v.Set(str); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "MACADDR":
+		if addr, err := parseCopyMacaddr(str); err != nil {
+			return nil, err
+		} else if len(addr) != 6 {
+			return nil, fmt.Errorf("invalid macaddr %q", str)
+		} else {
+			return pgtype.Macaddr{Addr: addr, Status: pgtype.Present}, nil
+		}
+	case "MACADDR8":
+		return parseCopyMacaddr8(str)
+	case "BYTEA":
+		var v pgtype.Bytea
+		if err := v.DecodeText(nil, []byte(str)); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("copying a string to a column of type %s is not supported", ddl)
+	}
+}
+
+// parseCopyTime parses the time of a "time" formatted string. The server ignores the offset of
+// times cast to a TIME, and so does this.
+func parseCopyTime(str string) (time.Time, error) {
+	if t, err := time.Parse("15:04:05.999999999Z07:00", str); err == nil {
+		return t, nil
+	}
+	return time.Parse("15:04:05.999999999", str)
+}
+
+// parseCopyNumeric parses a "number" or "integer" formatted string, which may have an exponent.
+func parseCopyNumeric(str string) (pgtype.Numeric, error) {
+	var v pgtype.Numeric
+	var mantissa, exponent, hasExponent = str, "", false
+	if idx := strings.IndexAny(str, "eE"); idx != -1 {
+		mantissa, exponent, hasExponent = str[:idx], str[idx+1:], true
+	}
+
+	if err := v.DecodeText(nil, []byte(mantissa)); err != nil {
+		return v, err
+	} else if hasExponent && !v.NaN && v.InfinityModifier == pgtype.None {
+		exp, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil {
+			return v, fmt.Errorf("invalid exponent of %q: %w", str, err)
+		}
+		v.Exp += int32(exp)
+	}
+	return v, nil
+}
+
+var isoDurationRe = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseCopyInterval parses a "duration" formatted string, which is an ISO 8601 duration. Other
+// durations are parsed in the server's own output format.
+func parseCopyInterval(str string) (pgtype.Interval, error) {
+	var v pgtype.Interval
+
+	var m = isoDurationRe.FindStringSubmatch(str)
+	if m == nil || str == "P" || strings.HasSuffix(str, "T") {
+		err := v.DecodeText(nil, []byte(str))
+		return v, err
+	}
+
+	var parts [7]float64
+	for idx, s := range m[1:] {
+		if s == "" {
+			continue
+		} else if f, err := strconv.ParseFloat(s, 64); err != nil {
+			return v, fmt.Errorf("invalid duration %q: %w", str, err)
+		} else {
+			parts[idx] = f
+		}
+	}
+	var years, months, weeks, days, hours, minutes, seconds = parts[0], parts[1], parts[2], parts[3], parts[4], parts[5], parts[6]
+
+	v.Months = int32(years*12 + months)
+	v.Days = int32(weeks*7 + days)
+	v.Microseconds = int64(hours)*int64(time.Hour/time.Microsecond) +
+		int64(minutes)*int64(time.Minute/time.Microsecond) +
+		int64(seconds*float64(time.Second/time.Microsecond)+0.5)
+	v.Status = pgtype.Present
+
+	return v, nil
+}
+
+// parseCopyMacaddr parses a MAC address in any of the formats accepted by the server, which allows
+// the groups of its hex digits to be separated by colons, hyphens, or periods.
+func parseCopyMacaddr(str string) (net.HardwareAddr, error) {
+	var digits = strings.NewReplacer(":", "", "-", "", ".", "").Replace(str)
+	if addr, err := hex.DecodeString(digits); err != nil {
+		return nil, fmt.Errorf("invalid mac address %q: %w", str, err)
+	} else {
+		return addr, nil
+	}
+}
+
+// macaddr8 is an EUI-64 MAC address, which pgtype has no type for.
+type macaddr8 net.HardwareAddr
+
+func (src macaddr8) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return append(buf, src...), nil
+}
+
+// parseCopyMacaddr8 parses an EUI-64 MAC address. Like the server, it converts an EUI-48 address
+// into EUI-64 by inserting FF:FE into its middle.
+func parseCopyMacaddr8(str string) (macaddr8, error) {
+	addr, err := parseCopyMacaddr(str)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(addr) {
+	case 6:
+		return macaddr8{addr[0], addr[1], addr[2], 0xff, 0xfe, addr[3], addr[4], addr[5]}, nil
+	case 8:
+		return macaddr8(addr), nil
+	default:
+		return nil, fmt.Errorf("invalid macaddr8 %q", str)
+	}
+}
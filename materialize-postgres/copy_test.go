@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyValue(t *testing.T) {
+	for _, tt := range []struct {
+		ddl   string
+		param interface{}
+		want  interface{}
+	}{
+		{"TEXT", nil, nil},
+		{"BIGINT", int64(-42), int64(-42)},
+		{"JSON", json.RawMessage(`{"a":1}`), json.RawMessage(`{"a":1}`)},
+		{"TEXT", "a string", "a string"},
+		{"JSON", `{"a":1}`, `{"a":1}`},
+		{"TIMESTAMPTZ", "2023-01-02T03:04:05.123456Z", time.Date(2023, 1, 2, 3, 4, 5, 123456000, time.UTC)},
+		{"DATE", "2023-01-02", pgtype.Date{Time: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), Status: pgtype.Present}},
+		{"TIME", "03:04:05.5", time.Date(0, 1, 1, 3, 4, 5, 500000000, time.UTC)},
+		{"INTERVAL", "P1Y2M3DT4H5M6.5S", pgtype.Interval{Months: 14, Days: 3, Microseconds: 14706500000, Status: pgtype.Present}},
+		{"INTERVAL", "P2W", pgtype.Interval{Days: 14, Status: pgtype.Present}},
+		{"MACADDR8", "08:00:2b:01:02:03", macaddr8{0x08, 0x00, 0x2b, 0xff, 0xfe, 0x01, 0x02, 0x03}},
+		{"MACADDR8", "08-00-2b-01-02-03-04-05", macaddr8{0x08, 0x00, 0x2b, 0x01, 0x02, 0x03, 0x04, 0x05}},
+	} {
+		got, err := copyValue(tt.ddl, tt.param)
+		require.NoError(t, err, tt.param)
+		require.Equal(t, tt.want, got, tt.param)
+	}
+
+	for ddl, str := range map[string]string{
+		"MACADDR":     "0800.2b01.0203",
+		"CIDR":        "10.1.0.0/16",
+		"BYTEA":       `\x0102`,
+		"TIMESTAMPTZ": "2023-01-02T03:04:05+01:00",
+	} {
+		got, err := copyValue(ddl, str)
+		require.NoError(t, err, ddl)
+
+		var text, _ = got.(interface {
+			EncodeText(*pgtype.ConnInfo, []byte) ([]byte, error)
+		})
+		if text == nil {
+			continue
+		}
+		b, err := text.EncodeText(nil, nil)
+		require.NoError(t, err, ddl)
+		require.Equal(t, map[string]string{
+			"MACADDR": "08:00:2b:01:02:03",
+			"CIDR":    "10.1.0.0/16",
+			"BYTEA":   `\x0102`,
+		}[ddl], string(b), ddl)
+	}
+
+	for str, want := range map[string]float64{
+		"1.5e3":  1500,
+		"-12E-2": -0.12,
+		"7":      7,
+	} {
+		got, err := copyValue("NUMERIC", str)
+		require.NoError(t, err, str)
+
+		var num, f = got.(pgtype.Numeric), 0.0
+		require.NoError(t, num.AssignTo(&f))
+		require.InDelta(t, want, f, 1e-9, str)
+	}
+
+	for ddl, str := range map[string]string{
+		"NUMERIC":  "NaN",
+		"DECIMAL":  "-Infinity",
+		"INTERVAL": "1 day 02:00:00",
+	} {
+		_, err := copyValue(ddl, str)
+		require.NoError(t, err, ddl)
+	}
+
+	for ddl, str := range map[string]string{
+		"BIGINT":      "42",
+		"TIMESTAMPTZ": "not a timestamp",
+		"NUMERIC":     "1e",
+		"MACADDR":     "08:00:2b:01:02:03:04:05",
+		"MACADDR8":    "08:00:2b",
+	} {
+		_, err := copyValue(ddl, str)
+		require.Error(t, err, ddl)
+	}
+}
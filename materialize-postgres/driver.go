@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -29,6 +30,11 @@ const (
 	// example, if documents average 2kb then a 10mb batch size will allow for ~5000 documents per
 	// batch.
 	batchBytesLimit = 10 * 1024 * 1024 // Bytes
+
+	// Bindings which store at least this many documents in a transaction bulk load them into a
+	// temporary table using COPY, and then merge them into the target table with a single
+	// statement. Documents of smaller transactions are stored with individual statements.
+	copyThreshold = 1000
 )
 
 type sshForwarding struct {
@@ -42,12 +48,13 @@ type tunnelConfig struct {
 
 // config represents the endpoint configuration for postgres.
 type config struct {
-	Address  string         `json:"address" jsonschema:"title=Address,description=Host and port of the database (in the form of host[:port]). Port 5432 is used as the default if no specific port is provided." jsonschema_extras:"order=0"`
-	User     string         `json:"user" jsonschema:"title=User,description=Database user to connect as." jsonschema_extras:"order=1"`
-	Password string         `json:"password" jsonschema:"title=Password,description=Password for the specified database user." jsonschema_extras:"secret=true,order=2"`
-	Database string         `json:"database,omitempty" jsonschema:"title=Database,description=Name of the logical database to materialize to." jsonschema_extras:"order=3"`
-	Schema   string         `json:"schema,omitempty" jsonschema:"title=Database Schema,default=public,description=Database schema for bound collection tables (unless overridden within the binding resource configuration) as well as associated materialization metadata tables" jsonschema_extras:"order=4"`
-	Advanced advancedConfig `json:"advanced,omitempty" jsonschema:"title=Advanced Options,description=Options for advanced users. You should not typically need to modify these." jsonschema_extras:"advanced=true"`
+	Address    string         `json:"address" jsonschema:"title=Address,description=Host and port of the database (in the form of host[:port]). Port 5432 is used as the default if no specific port is provided." jsonschema_extras:"order=0"`
+	User       string         `json:"user" jsonschema:"title=User,description=Database user to connect as." jsonschema_extras:"order=1"`
+	Password   string         `json:"password" jsonschema:"title=Password,description=Password for the specified database user." jsonschema_extras:"secret=true,order=2"`
+	Database   string         `json:"database,omitempty" jsonschema:"title=Database,description=Name of the logical database to materialize to." jsonschema_extras:"order=3"`
+	Schema     string         `json:"schema,omitempty" jsonschema:"title=Database Schema,default=public,description=Database schema for bound collection tables (unless overridden within the binding resource configuration) as well as associated materialization metadata tables" jsonschema_extras:"order=4"`
+	HardDelete bool           `json:"hardDelete,omitempty" jsonschema:"title=Hard Delete,description=Delete rows from standard-updates tables when their source documents are deleted (such as CDC deletions having a '_meta/op' of 'd'). By default deletions are stored as regular rows." jsonschema_extras:"order=5"`
+	Advanced   advancedConfig `json:"advanced,omitempty" jsonschema:"title=Advanced Options,description=Options for advanced users. You should not typically need to modify these." jsonschema_extras:"advanced=true"`
 
 	NetworkTunnel *tunnelConfig `json:"networkTunnel,omitempty" jsonschema:"title=Network Tunnel,description=Connect to your system through an SSH server that acts as a bastion host for your network."`
 }
@@ -125,6 +132,7 @@ type tableConfig struct {
 	Schema        string `json:"schema,omitempty" jsonschema:"title=Alternative Schema,description=Alternative schema for this table (optional)"`
 	AdditionalSql string `json:"additional_table_create_sql,omitempty" jsonschema:"title=Additional Table Create SQL,description=Additional SQL statement(s) to be run in the same transaction that creates the table." jsonschema_extras:"multiline=true"`
 	Delta         bool   `json:"delta_updates,omitempty" jsonschema:"default=false,title=Delta Update,description=Should updates to this table be done via delta updates. Default is false."`
+	HistoryMode   bool   `json:"history_mode,omitempty" jsonschema:"default=false,title=History Mode,description=Should this table keep every version of each document as a separate row (a type 2 slowly-changing dimension). Versions are tracked by the valid_from and valid_to and is_current columns. Default is false."`
 }
 
 func newTableConfig(ep *sql.Endpoint) sql.Resource {
@@ -154,6 +162,10 @@ func (c tableConfig) DeltaUpdates() bool {
 	return c.Delta
 }
 
+func (c tableConfig) History() bool {
+	return c.HistoryMode
+}
+
 func newPostgresDriver() *sql.Driver {
 	return &sql.Driver{
 		DocumentationURL: "https://go.estuary.dev/materialize-postgresql",
@@ -211,6 +223,7 @@ func newPostgresDriver() *sql.Driver {
 				NewTransactor:       newTransactor,
 				Tenant:              tenant,
 				ConcurrentApply:     false,
+				HardDelete:          cfg.HardDelete,
 			}, nil
 		},
 	}
@@ -226,6 +239,8 @@ type transactor struct {
 	store struct {
 		conn  *pgx.Conn
 		fence sql.Fence
+		// Whether the server supports MERGE, which was added in Postgres 15.
+		merge bool
 	}
 	bindings []*binding
 }
@@ -248,6 +263,11 @@ func newTransactor(
 	if d.store.conn, err = pgx.Connect(ctx, cfg.ToURI()); err != nil {
 		return nil, fmt.Errorf("store pgx.Connect: %w", err)
 	}
+	if version, err := serverMajorVersion(d.store.conn); err != nil {
+		return nil, err
+	} else {
+		d.store.merge = version >= 15
+	}
 
 	for _, binding := range bindings {
 		if err = d.addBinding(ctx, binding); err != nil {
@@ -266,12 +286,32 @@ func newTransactor(
 }
 
 type binding struct {
-	target             sql.Table
-	createLoadTableSQL string
-	loadInsertSQL      string
-	storeUpdateSQL     string
-	storeInsertSQL     string
-	loadQuerySQL       string
+	target              sql.Table
+	createLoadTableSQL  string
+	createStoreTableSQL string
+	loadInsertSQL       string
+	storeUpdateSQL      string
+	storeInsertSQL      string
+	storeDeleteSQL      string
+	storeMergeSQL       string
+	historyCloseSQL     string
+	historyInsertSQL    string
+	loadQuerySQL        string
+
+	// Name, columns, and column types of the temporary table into which stored documents are copied.
+	storeTable   string
+	storeColumns []string
+	storeTypes   []string
+	// Documents of the current transaction which haven't yet been stored.
+	staged []stagedDoc
+	// Whether documents of the current transaction are being bulk loaded with COPY.
+	copying bool
+}
+
+// stagedDoc is a converted document which is waiting to be stored.
+type stagedDoc struct {
+	exists bool
+	params []interface{}
 }
 
 func (t *transactor) addBinding(ctx context.Context, target sql.Table) error {
@@ -282,9 +322,14 @@ func (t *transactor) addBinding(ctx context.Context, target sql.Table) error {
 		tpl *template.Template
 	}{
 		{&b.createLoadTableSQL, tplCreateLoadTable},
+		{&b.createStoreTableSQL, tplCreateStoreTable},
+		{&b.storeTable, tplTempStoreName},
 		{&b.loadInsertSQL, tplLoadInsert},
 		{&b.storeInsertSQL, tplStoreInsert},
 		{&b.storeUpdateSQL, tplStoreUpdate},
+		{&b.storeDeleteSQL, tplStoreDelete},
+		{&b.historyCloseSQL, tplHistoryClose},
+		{&b.historyInsertSQL, tplHistoryInsert},
 		{&b.loadQuerySQL, tplLoadQuery},
 	} {
 		var err error
@@ -293,6 +338,21 @@ func (t *transactor) addBinding(ctx context.Context, target sql.Table) error {
 		}
 	}
 
+	// Delta updates tables are only ever inserted into, and tables of keys which might already
+	// exist are merged into using MERGE if it's available.
+	var mergeTpl = tplStoreUpsert
+	if t.store.merge && !target.DeltaUpdates {
+		mergeTpl = tplStoreMerge
+	}
+	var err error
+	if b.storeMergeSQL, err = sql.RenderTableTemplate(target, mergeTpl); err != nil {
+		return err
+	}
+	for idx, col := range target.Columns() {
+		b.storeColumns = append(b.storeColumns, fmt.Sprintf("c%d", idx))
+		b.storeTypes = append(b.storeTypes, col.NullableDDL)
+	}
+
 	t.bindings = append(t.bindings, b)
 
 	// Create a binding-scoped temporary table for staged keys to load.
@@ -300,6 +360,14 @@ func (t *transactor) addBinding(ctx context.Context, target sql.Table) error {
 		return fmt.Errorf("Exec(%s): %w", b.createLoadTableSQL, err)
 	}
 
+	// Create a binding-scoped temporary table for documents to bulk load. Documents of history
+	// mode tables are always stored with individual statements.
+	if !target.History {
+		if _, err := t.store.conn.Exec(ctx, b.createStoreTableSQL); err != nil {
+			return fmt.Errorf("Exec(%s): %w", b.createStoreTableSQL, err)
+		}
+	}
+
 	return nil
 }
 
@@ -389,6 +457,11 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 		}
 	}()
 
+	for _, b := range d.bindings {
+		b.staged = b.staged[:0]
+		b.copying = false
+	}
+
 	var batch pgx.Batch
 	batchBytes := 0
 	for it.Next() {
@@ -397,22 +470,50 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 		batchBytes += len(it.PackedKey) + len(it.PackedValues) + len(it.RawJSON)
 		var b = d.bindings[it.Binding]
 
-		if converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON); err != nil {
+		if b.target.IsHardDelete(it) {
+			if !it.Exists {
+				continue // There's nothing to delete if the key was never stored.
+			} else if converted, err := b.target.ConvertKey(it.Key); err != nil {
+				return nil, fmt.Errorf("converting delete parameters: %w", err)
+			} else {
+				batch.Queue(b.storeDeleteSQL, converted...)
+			}
+		} else if converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON); err != nil {
 			return nil, fmt.Errorf("converting store parameters: %w", err)
-		} else if it.Exists {
-			batch.Queue(b.storeUpdateSQL, converted...)
+		} else if b.target.History {
+			if it.Exists {
+				// Keys are the leading parameters of the converted document.
+				batch.Queue(b.historyCloseSQL, converted[:len(b.target.Keys)]...)
+			}
+			batch.Queue(b.historyInsertSQL, converted...)
 		} else {
-			batch.Queue(b.storeInsertSQL, converted...)
+			// Documents are staged until it's known whether there are enough of them to bulk load.
+			b.staged = append(b.staged, stagedDoc{exists: it.Exists, params: converted})
+			if len(b.staged) >= copyThreshold {
+				b.copying = true
+			}
 		}
 
 		if batchBytes >= batchBytesLimit {
-			if err := sendBatch(ctx, txn, &batch); err != nil {
+			if err := d.flushStaged(ctx, txn, &batch); err != nil {
+				return nil, err
+			} else if err := sendBatch(ctx, txn, &batch); err != nil {
 				return nil, fmt.Errorf("sending store batch: %w", err)
 			}
 			batchBytes = 0
 		}
 	}
 
+	if err := d.flushStaged(ctx, txn, &batch); err != nil {
+		return nil, err
+	}
+	// Bulk loaded documents are merged into their target tables when the transaction commits.
+	for _, b := range d.bindings {
+		if b.copying {
+			batch.Queue(b.storeMergeSQL)
+		}
+	}
+
 	return func(ctx context.Context, runtimeCheckpoint *protocol.Checkpoint) (*pf.ConnectorState, m.OpFuture) {
 		return nil, m.RunAsyncOperation(func() error {
 			defer txn.Rollback(ctx)
@@ -455,6 +556,40 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 	}, nil
 }
 
+// flushStaged stores the staged documents of each binding, either by bulk loading them into the
+// binding's temporary store table using COPY, or by queuing individual statements to the batch.
+func (d *transactor) flushStaged(ctx context.Context, txn pgx.Tx, batch *pgx.Batch) error {
+	for _, b := range d.bindings {
+		if len(b.staged) == 0 {
+			continue
+		} else if b.copying {
+			var src = pgx.CopyFromSlice(len(b.staged), func(i int) ([]interface{}, error) {
+				var row = make([]interface{}, len(b.staged[i].params))
+				for idx, param := range b.staged[i].params {
+					var err error
+					if row[idx], err = copyValue(b.storeTypes[idx], param); err != nil {
+						return nil, fmt.Errorf("converting column %d: %w", idx, err)
+					}
+				}
+				return row, nil
+			})
+			if _, err := txn.CopyFrom(ctx, pgx.Identifier{b.storeTable}, b.storeColumns, src); err != nil {
+				return fmt.Errorf("copying documents to store table of %s: %w", b.target.Identifier, err)
+			}
+		} else {
+			for _, doc := range b.staged {
+				if doc.exists {
+					batch.Queue(b.storeUpdateSQL, doc.params...)
+				} else {
+					batch.Queue(b.storeInsertSQL, doc.params...)
+				}
+			}
+		}
+		b.staged = b.staged[:0]
+	}
+	return nil
+}
+
 func (d *transactor) Destroy() {
 	d.load.conn.Close(context.Background())
 	d.store.conn.Close(context.Background())
@@ -482,3 +617,23 @@ func sendBatch(ctx context.Context, txn pgx.Tx, batch *pgx.Batch) error {
 
 	return nil
 }
+
+// serverMajorVersion returns the major version of the Postgres server of the connection.
+func serverMajorVersion(conn *pgx.Conn) (int, error) {
+	return parseMajorVersion(conn.PgConn().ParameterStatus("server_version"))
+}
+
+// parseMajorVersion parses the major version from a server version string, which is reported
+// like "15.3 (Debian 15.3-1.pgdg120+1)" or "16beta1".
+func parseMajorVersion(version string) (int, error) {
+	var major = version
+	if idx := strings.IndexFunc(version, func(r rune) bool { return r < '0' || r > '9' }); idx != -1 {
+		major = version[:idx]
+	}
+
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("parsing server version %q: %w", version, err)
+	}
+	return n, nil
+}
@@ -53,13 +53,13 @@ var pgDialect = func() sql.Dialect {
 	}
 
 	columnValidator := sql.NewColumnValidator(
-		sql.ColValidation{Types: []string{"bigint", "integer"}, Validate: sql.IntegerCompatible},
-		sql.ColValidation{Types: []string{"double precision"}, Validate: sql.NumberCompatible},
-		sql.ColValidation{Types: []string{"numeric"}, Validate: sql.NumericCompatible},
-		sql.ColValidation{Types: []string{"boolean"}, Validate: sql.BooleanCompatible},
+		sql.ColValidation{Types: []string{"bigint", "integer"}, Validate: sql.IntegerCompatible, MigrateTo: []string{"DOUBLE PRECISION", "NUMERIC", "DECIMAL", "TEXT", "JSON"}},
+		sql.ColValidation{Types: []string{"double precision"}, Validate: sql.NumberCompatible, MigrateTo: []string{"TEXT", "JSON"}},
+		sql.ColValidation{Types: []string{"numeric"}, Validate: sql.NumericCompatible, MigrateTo: []string{"TEXT", "JSON"}},
+		sql.ColValidation{Types: []string{"boolean"}, Validate: sql.BooleanCompatible, MigrateTo: []string{"TEXT", "JSON"}},
 		sql.ColValidation{Types: []string{"json"}, Validate: sql.JsonCompatible},
-		sql.ColValidation{Types: []string{"text"}, Validate: sql.StringCompatible},
-		sql.ColValidation{Types: []string{"date"}, Validate: sql.DateCompatible},
+		sql.ColValidation{Types: []string{"text"}, Validate: sql.StringCompatible, MigrateTo: []string{"JSON"}},
+		sql.ColValidation{Types: []string{"date"}, Validate: sql.DateCompatible, MigrateTo: []string{"TEXT", "JSON"}},
 		sql.ColValidation{Types: []string{"timestamp with time zone"}, Validate: sql.DateTimeCompatible},
 		sql.ColValidation{Types: []string{"interval"}, Validate: sql.DurationCompatible},
 		sql.ColValidation{Types: []string{"cidr"}, Validate: sql.IPv4or6Compatible},
@@ -104,6 +104,10 @@ var (
 flow_temp_table_{{ $.Binding }}
 {{- end }}
 
+{{ define "temp_store_name" -}}
+flow_temp_store_table_{{ $.Binding }}
+{{- end }}
+
 -- Templated creation of a materialized table definition and comments:
 
 {{ define "createTargetTable" }}
@@ -112,7 +116,12 @@ CREATE TABLE IF NOT EXISTS {{$.Identifier}} (
 		{{- if $ind }},{{ end }}
 		{{$col.Identifier}} {{$col.DDL}}
 	{{- end }}
-	{{- if not $.DeltaUpdates }},
+	{{- if $.HistoryColumns }}
+	{{- range $col := $.HistoryColumns.All }},
+		{{$col.Identifier}} {{$col.DDL}}
+	{{- end }}
+	{{- end }}
+	{{- if not (or $.DeltaUpdates $.History) }},
 
 		PRIMARY KEY (
 	{{- range $ind, $key := $.Keys }}
@@ -127,11 +136,25 @@ COMMENT ON TABLE {{$.Identifier}} IS {{Literal $.Comment}};
 {{- range $col := .Columns }}
 COMMENT ON COLUMN {{$.Identifier}}.{{$col.Identifier}} IS {{Literal $col.Comment}};
 {{- end}}
+{{- if $.HistoryColumns }}
+{{- range $col := $.HistoryColumns.All }}
+COMMENT ON COLUMN {{$.Identifier}}.{{$col.Identifier}} IS {{Literal $col.Comment}};
+{{- end}}
+
+CREATE UNIQUE INDEX IF NOT EXISTS {{ ColumnIdentifier (printf "%s_current_key" (Last $.Path)) }} ON {{$.Identifier}} (
+	{{- range $ind, $key := $.Keys }}
+		{{- if $ind }}, {{end -}}
+		{{$key.Identifier}}
+	{{- end -}}
+) WHERE {{ $.HistoryColumns.IsCurrent.Identifier }};
+{{- end}}
 {{ end }}
 
--- Templated query which performs table alterations by adding columns and/or
--- dropping nullability constraints. All table modifications are done in a 
--- single statement for efficiency.
+-- Templated query which performs table alterations by adding columns, dropping
+-- nullability constraints, and/or migrating the types of columns. All table
+-- modifications are done in a single statement for efficiency. Columns migrated
+-- to JSON are converted with to_json so that their values become JSON scalars
+-- rather than being parsed as JSON text.
 
 {{ define "alterTableColumns" }}
 ALTER TABLE {{$.Identifier}}
@@ -143,9 +166,31 @@ ALTER TABLE {{$.Identifier}}
 {{- range $ind, $col := $.DropNotNulls }}
 	{{- if $ind }},{{ end }}
 	ALTER COLUMN {{ ColumnIdentifier $col.Name }} DROP NOT NULL
+{{- end }}
+{{- if and $.ColumnTypeChanges (or $.AddColumns $.DropNotNulls) }},{{ end }}
+{{- range $ind, $col := $.ColumnTypeChanges }}
+	{{- if $ind }},{{ end }}
+	ALTER COLUMN {{$col.Identifier}} TYPE {{$col.NullableDDL}} USING
+	{{- if eq $col.NullableDDL "JSON" }} to_json({{$col.Identifier}})
+	{{- else }} {{$col.Identifier}}::{{$col.NullableDDL}}
+	{{- end }}
 {{- end }};
 {{ end }}
 
+-- Templated creation of a temporary store table, into which documents are bulk
+-- loaded with COPY. Its columns are named by their position in the target
+-- table's columns so that they can be copied into without quoting, and have the
+-- types of the target table's columns so that values are copied in binary form.
+
+{{ define "createStoreTable" }}
+CREATE TEMPORARY TABLE {{ template "temp_store_name" . }} (
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }},{{ end }}
+		c{{ $ind }} {{ $col.NullableDDL }}
+	{{- end }}
+) ON COMMIT DELETE ROWS;
+{{ end }}
+
 -- Templated creation of a temporary load table:
 
 {{ define "createLoadTable" }}
@@ -186,6 +231,9 @@ SELECT {{ $.Binding }}, r.{{$.Document.Identifier}}
 		{{ if $ind }} AND {{ else }} ON  {{ end -}}
 		l.{{ $key.Identifier }} = r.{{ $key.Identifier }}
 	{{- end }}
+	{{- if $.HistoryColumns }}
+		 AND r.{{ $.HistoryColumns.IsCurrent.Identifier }}
+	{{- end }}
 {{ else -}}
 SELECT * FROM (SELECT -1, CAST(NULL AS JSON) LIMIT 0) as nodoc
 {{ end }}
@@ -226,6 +274,124 @@ UPDATE {{$.Identifier}} SET
 	;
 {{ end }}
 
+{{ define "storeDelete" }}
+DELETE FROM {{$.Identifier}}
+	{{- range $ind, $key := $.Keys }}
+	{{ if $ind }} AND   {{ else }} WHERE {{ end -}}
+	{{ $key.Identifier }} = {{ $key.Placeholder }}
+	{{- end -}}
+	;
+{{ end }}
+
+-- Templated query which inserts the documents of the temporary store table into
+-- the target table. Rows of keys which already exist are updated, which relies
+-- on the primary key of standard updates tables.
+
+{{ define "storeUpsert" }}
+INSERT INTO {{ $.Identifier }} (
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }},{{ end }}
+		{{$col.Identifier}}
+	{{- end }}
+)
+SELECT
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }},{{ end }}
+		r.c{{ $ind }}
+	{{- end }}
+FROM {{ template "temp_store_name" . }} AS r
+{{- if not $.DeltaUpdates }}
+ON CONFLICT (
+	{{- range $ind, $key := $.Keys }}
+		{{- if $ind }}, {{ end -}}
+		{{ $key.Identifier }}
+	{{- end -}}
+) DO
+{{- if or $.Values $.Document }} UPDATE SET
+	{{- range $ind, $val := $.Values }}
+		{{- if $ind }},{{ end }}
+		{{ $val.Identifier }} = EXCLUDED.{{ $val.Identifier }}
+	{{- end }}
+	{{- if $.Document -}}
+		{{ if $.Values }},{{ end }}
+		{{ $.Document.Identifier }} = EXCLUDED.{{ $.Document.Identifier }}
+	{{- end }}
+{{- else }} NOTHING
+{{- end }}
+{{- end }};
+{{ end }}
+
+-- Templated query which merges the documents of the temporary store table into
+-- the target table. MERGE is available as of Postgres 15, and unlike storeUpsert
+-- doesn't require the target table to have a primary key.
+
+{{ define "storeMerge" }}
+MERGE INTO {{ $.Identifier }} AS l
+USING {{ template "temp_store_name" . }} AS r
+	{{- range $ind, $key := $.Keys }}
+	{{ if $ind }} AND {{ else }} ON  {{ end -}}
+	l.{{ $key.Identifier }} = r.c{{ $ind }}
+	{{- end }}
+{{- if or $.Values $.Document }}
+WHEN MATCHED THEN UPDATE SET
+	{{- range $ind, $val := $.Values }}
+		{{- if $ind }},{{ end }}
+		{{ $val.Identifier }} = r.c{{ Add (len $.Keys) $ind }}
+	{{- end }}
+	{{- if $.Document -}}
+		{{ if $.Values }},{{ end }}
+		{{ $.Document.Identifier }} = r.c{{ Add (len $.Keys) (len $.Values) }}
+	{{- end }}
+{{- end }}
+WHEN NOT MATCHED THEN INSERT (
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }},{{ end }}
+		{{$col.Identifier}}
+	{{- end }}
+) VALUES (
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }}, {{ end -}}
+		r.c{{ $ind }}
+	{{- end -}}
+);
+{{ end }}
+
+-- Templated query which closes out the current version of a key in a history-mode table. The
+-- transaction's start time is used for both closing out the version and inserting the next one.
+
+{{ define "storeHistoryClose" }}
+{{ if $.HistoryColumns -}}
+UPDATE {{$.Identifier}} SET
+		{{ $.HistoryColumns.ValidTo.Identifier }} = now(),
+		{{ $.HistoryColumns.IsCurrent.Identifier }} = false
+	{{- range $ind, $key := $.Keys }}
+	{{ if $ind }} AND   {{ else }} WHERE {{ end -}}
+	{{ $key.Identifier }} = {{ $key.Placeholder }}
+	{{- end }}
+	 AND   {{ $.HistoryColumns.IsCurrent.Identifier }};
+{{- end }}
+{{ end }}
+
+-- Templated query which inserts a new current version of a key into a history-mode table:
+
+{{ define "storeHistoryInsert" }}
+{{ if $.HistoryColumns -}}
+INSERT INTO {{ $.Identifier }} (
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind }},{{ end }}
+		{{$col.Identifier}}
+	{{- end }},
+		{{ $.HistoryColumns.ValidFrom.Identifier }},
+		{{ $.HistoryColumns.IsCurrent.Identifier }}
+) VALUES (
+	{{- range $ind, $col := $.Columns }}
+		{{- if $ind}}, {{ end -}}
+		{{ $col.Placeholder }}
+	{{- end -}}
+	, now(), true);
+{{- end }}
+{{ end }}
+
 {{ define "installFence" }}
 with
 -- Increment the fence value of _any_ checkpoint which overlaps our key range.
@@ -281,12 +447,19 @@ BEGIN
 END $$;
 {{ end }}
 `)
+	tplTempStoreName     = tplAll.Lookup("temp_store_name")
 	tplCreateLoadTable   = tplAll.Lookup("createLoadTable")
+	tplCreateStoreTable  = tplAll.Lookup("createStoreTable")
 	tplCreateTargetTable = tplAll.Lookup("createTargetTable")
 	tplAlterTableColumns = tplAll.Lookup("alterTableColumns")
 	tplLoadInsert        = tplAll.Lookup("loadInsert")
 	tplStoreInsert       = tplAll.Lookup("storeInsert")
 	tplStoreUpdate       = tplAll.Lookup("storeUpdate")
+	tplStoreDelete       = tplAll.Lookup("storeDelete")
+	tplStoreUpsert       = tplAll.Lookup("storeUpsert")
+	tplStoreMerge        = tplAll.Lookup("storeMerge")
+	tplHistoryClose      = tplAll.Lookup("storeHistoryClose")
+	tplHistoryInsert     = tplAll.Lookup("storeHistoryInsert")
 	tplLoadQuery         = tplAll.Lookup("loadQuery")
 	tplInstallFence      = tplAll.Lookup("installFence")
 	tplUpdateFence       = tplAll.Lookup("updateFence")
@@ -31,23 +31,36 @@ func TestSQLGeneration(t *testing.T) {
 		Delta:  true,
 	})
 	shape2.Document = nil // TODO(johnny): this is a bit gross.
+	var shape3 = sqlDriver.BuildTableShape(spec, 0, tableConfig{
+		Schema:      "a-schema",
+		Table:       "history_table",
+		HistoryMode: true,
+	})
 
 	table1, err := sqlDriver.ResolveTable(shape1, pgDialect)
 	require.NoError(t, err)
 	table2, err := sqlDriver.ResolveTable(shape2, pgDialect)
 	require.NoError(t, err)
+	table3, err := sqlDriver.ResolveTable(shape3, pgDialect)
+	require.NoError(t, err)
 
 	var snap strings.Builder
 
 	for _, tpl := range []*template.Template{
 		tplCreateTargetTable,
 		tplCreateLoadTable,
+		tplCreateStoreTable,
 		tplLoadInsert,
 		tplLoadQuery,
 		tplStoreInsert,
 		tplStoreUpdate,
+		tplStoreDelete,
+		tplStoreUpsert,
+		tplStoreMerge,
+		tplHistoryClose,
+		tplHistoryInsert,
 	} {
-		for _, tbl := range []sqlDriver.Table{table1, table2} {
+		for _, tbl := range []sqlDriver.Table{table1, table2, table3} {
 			var testcase = tbl.Identifier + " " + tpl.Name()
 
 			snap.WriteString("--- Begin " + testcase + " ---\n")
@@ -74,11 +87,16 @@ func TestSQLGeneration(t *testing.T) {
 			CharacterMaxLength: 0,
 		},
 	}
+	typeChanges := []sqlDriver.Column{
+		{Identifier: "first_migrated_column", MappedType: sqlDriver.MappedType{NullableDDL: "TEXT"}},
+		{Identifier: "second_migrated_column", MappedType: sqlDriver.MappedType{NullableDDL: "JSON"}},
+	}
 
 	for _, testcase := range []struct {
 		name         string
 		addColumns   []sqlDriver.Column
 		dropNotNulls []boilerplate.EndpointField
+		typeChanges  []sqlDriver.Column
 	}{
 		{
 			name:         "alter table add columns and drop not nulls",
@@ -93,12 +111,23 @@ func TestSQLGeneration(t *testing.T) {
 			name:         "alter table drop not nulls",
 			dropNotNulls: dropNotNulls,
 		},
+		{
+			name:        "alter table column types",
+			typeChanges: typeChanges,
+		},
+		{
+			name:         "alter table add columns, drop not nulls, and change column types",
+			addColumns:   addCols,
+			dropNotNulls: dropNotNulls,
+			typeChanges:  typeChanges,
+		},
 	} {
 		snap.WriteString("--- Begin " + testcase.name + " ---\n")
 		require.NoError(t, tplAlterTableColumns.Execute(&snap, sqlDriver.TableAlter{
-			Table:        table1,
-			AddColumns:   testcase.addColumns,
-			DropNotNulls: testcase.dropNotNulls,
+			Table:             table1,
+			AddColumns:        testcase.addColumns,
+			DropNotNulls:      testcase.dropNotNulls,
+			ColumnTypeChanges: testcase.typeChanges,
 		}))
 		snap.WriteString("--- End " + testcase.name + " ---\n\n")
 	}
@@ -186,3 +215,19 @@ func TestTruncatedIdentifier(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMajorVersion(t *testing.T) {
+	for version, want := range map[string]int{
+		"15.3 (Debian 15.3-1.pgdg120+1)": 15,
+		"14.9":                           14,
+		"9.6.24":                         9,
+		"16beta1":                        16,
+	} {
+		got, err := parseMajorVersion(version)
+		require.NoError(t, err)
+		require.Equal(t, want, got, version)
+	}
+
+	_, err := parseMajorVersion("")
+	require.Error(t, err)
+}
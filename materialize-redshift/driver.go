@@ -73,6 +73,7 @@ type config struct {
 	AWSSecretAccessKey string `json:"awsSecretAccessKey" jsonschema:"title=Secret Access Key,description=AWS Secret Access Key for reading and writing data to the S3 staging bucket." jsonschema_extras:"secret=true,order=7"`
 	Region             string `json:"region" jsonschema:"title=Region,description=Region of the S3 staging bucket. For optimal performance this should be in the same region as the Redshift database cluster." jsonschema_extras:"order=8"`
 	BucketPath         string `json:"bucketPath,omitempty" jsonschema:"title=Bucket Path,description=A prefix that will be used to store objects in S3." jsonschema_extras:"order=9"`
+	HardDelete         bool   `json:"hardDelete,omitempty" jsonschema:"title=Hard Delete,description=Delete rows from standard-updates tables when their source documents are deleted (such as CDC deletions having a '_meta/op' of 'd'). By default deletions are stored as regular rows." jsonschema_extras:"order=10"`
 
 	Advanced advancedConfig `json:"advanced,omitempty" jsonschema:"title=Advanced Options,description=Options for advanced users. You should not typically need to modify these." jsonschema_extras:"advanced=true"`
 
@@ -239,16 +240,18 @@ func newRedshiftDriver() *sql.Driver {
 			}
 
 			return &sql.Endpoint{
-				Config:              cfg,
-				Dialect:             rsDialect,
-				MetaSpecs:           &metaSpecs,
-				MetaCheckpoints:     &metaCheckpoints,
-				NewClient:           newClient,
-				CreateTableTemplate: tplCreateTargetTable,
-				NewResource:         newTableConfig,
-				NewTransactor:       newTransactor,
-				Tenant:              tenant,
-				ConcurrentApply:     true,
+				Config:                     cfg,
+				Dialect:                    rsDialect,
+				MetaSpecs:                  &metaSpecs,
+				MetaCheckpoints:            &metaCheckpoints,
+				NewClient:                  newClient,
+				CreateTableTemplate:        tplCreateTargetTable,
+				NewResource:                newTableConfig,
+				NewTransactor:              newTransactor,
+				Tenant:                     tenant,
+				ConcurrentApply:            true,
+				HardDelete:                 cfg.HardDelete,
+				HardDeleteRequiresDocument: true,
 			}, nil
 		},
 	}
@@ -339,6 +342,7 @@ type binding struct {
 	createLoadTableTemplate *template.Template
 	createStoreTableSQL     string
 	mergeIntoSQL            string
+	deleteQuerySQL          string
 	loadQuerySQL            string
 	copyIntoLoadTableSQL    string
 	copyIntoMergeTableSQL   string
@@ -398,6 +402,7 @@ func (t *transactor) addBinding(
 	}{
 		{&b.createStoreTableSQL, tplCreateStoreTable},
 		{&b.mergeIntoSQL, tplMergeInto},
+		{&b.deleteQuerySQL, tplDeleteQuery},
 		{&b.loadQuerySQL, tplLoadQuery},
 	} {
 		var err error
@@ -591,6 +596,10 @@ func (d *transactor) Store(it *m.StoreIterator) (m.StartCommitFunc, error) {
 	// into the target table.
 	hasUpdates := make([]bool, len(d.bindings))
 
+	// hasDeletes is used to track if a given binding includes any hard deletions, which are merged
+	// with a NULL document and then deleted from the target table.
+	hasDeletes := make([]bool, len(d.bindings))
+
 	// varcharColumnUpdates records any VARCHAR columns that need their lengths increased. The keys
 	// are table identifiers, and the values are a list of column identifiers that need altered.
 	// Columns will only ever be to altered it to VARCHAR(MAX).
@@ -598,14 +607,24 @@ func (d *transactor) Store(it *m.StoreIterator) (m.StartCommitFunc, error) {
 
 	log.Info("store: starting encoding and uploading of files")
 	for it.Next() {
-		if it.Exists {
+		var b = d.bindings[it.Binding]
+
+		var hardDelete = b.target.IsHardDelete(it)
+		if hardDelete && !it.Exists {
+			continue // There's nothing to delete if the key was never stored.
+		} else if it.Exists {
 			hasUpdates[it.Binding] = true
 		}
-
-		var b = d.bindings[it.Binding]
 		b.storeFile.start()
 
-		converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
+		var converted []interface{}
+		var err error
+		if hardDelete {
+			hasDeletes[it.Binding] = true
+			converted, err = b.target.ConvertHardDelete(it.Key, it.Values)
+		} else {
+			converted, err = b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("converting store parameters: %w", err)
 		}
@@ -660,11 +679,11 @@ func (d *transactor) Store(it *m.StoreIterator) (m.StartCommitFunc, error) {
 			return nil, m.FinishedOperation(fmt.Errorf("evaluating fence update template: %w", err))
 		}
 
-		return nil, pf.RunAsyncOperation(func() error { return d.commit(ctx, fenceUpdate.String(), hasUpdates, varcharColumnUpdates) })
+		return nil, pf.RunAsyncOperation(func() error { return d.commit(ctx, fenceUpdate.String(), hasUpdates, hasDeletes, varcharColumnUpdates) })
 	}, nil
 }
 
-func (d *transactor) commit(ctx context.Context, fenceUpdate string, hasUpdates []bool, varcharColumnUpdates map[string][]string) error {
+func (d *transactor) commit(ctx context.Context, fenceUpdate string, hasUpdates []bool, hasDeletes []bool, varcharColumnUpdates map[string][]string) error {
 	conn, err := pgx.Connect(ctx, d.cfg.toURI())
 	if err != nil {
 		return fmt.Errorf("store pgx.Connect: %w", err)
@@ -754,6 +773,12 @@ func (d *transactor) commit(ctx context.Context, fenceUpdate string, hasUpdates
 			} else if _, err := txn.Exec(ctx, b.mergeIntoSQL); err != nil {
 				return fmt.Errorf("merging to table '%s': %w", b.target.Identifier, err)
 			}
+
+			if hasDeletes[idx] {
+				if _, err := txn.Exec(ctx, b.deleteQuerySQL); err != nil {
+					return fmt.Errorf("deleting from table '%s': %w", b.target.Identifier, err)
+				}
+			}
 			log.WithField("table", b.target.Identifier).Info("store: finished merging data into table")
 		} else {
 			log.WithField("table", b.target.Identifier).Info("store: starting direct copying data into table")
@@ -204,8 +204,8 @@ CREATE TEMPORARY TABLE {{ template "temp_name" . }} (
 {{ end }}
 
 -- Templated query which updates an existing row in the target table. Redshift does not support
--- "WHEN MATCHED AND", so if/when deletion is implemented using a NULL document, a separate delete
--- statement will be needed in addition to this merge statement.
+-- "WHEN MATCHED AND", so rows which are hard deleted using a NULL document are removed by the
+-- separate deleteQuery statement after this merge statement.
 
 {{ define "mergeInto" }}
 MERGE INTO {{ $.Identifier }}
@@ -237,6 +237,20 @@ WHEN NOT MATCHED THEN
 	);
 {{ end }}
 
+-- Templated query which deletes rows of the target table that were merged with a NULL document.
+
+{{ define "deleteQuery" }}
+{{ if $.Document -}}
+DELETE FROM {{ $.Identifier }}
+USING {{ template "temp_name" . }} AS r
+WHERE {{ range $ind, $key := $.Keys }}
+{{- if $ind }} AND {{end -}}
+	{{$.Identifier}}.{{$key.Identifier}} = r.{{$key.Identifier}}
+{{- end}}
+AND r.{{ $.Document.Identifier }} IS NULL;
+{{- end }}
+{{ end }}
+
 -- Templated query which joins keys from the load table with the target table, and returns values. It
 -- deliberately skips the trailing semi-colon as these queries are composed with a UNION ALL.
 
@@ -289,6 +303,7 @@ TRUNCATECOLUMNS;
 	tplCreateLoadTable   = tplAll.Lookup("createLoadTable")
 	tplCreateStoreTable  = tplAll.Lookup("createStoreTable")
 	tplMergeInto         = tplAll.Lookup("mergeInto")
+	tplDeleteQuery       = tplAll.Lookup("deleteQuery")
 	tplLoadQuery         = tplAll.Lookup("loadQuery")
 	tplUpdateFence       = tplAll.Lookup("updateFence")
 	tplCopyFromS3        = tplAll.Lookup("copyFromS3")
@@ -42,6 +42,7 @@ func TestSQLGeneration(t *testing.T) {
 		tplCreateTargetTable,
 		tplCreateStoreTable,
 		tplMergeInto,
+		tplDeleteQuery,
 		tplLoadQuery,
 	} {
 		for _, tbl := range []sqlDriver.Table{table1, table2} {
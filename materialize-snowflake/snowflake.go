@@ -110,13 +110,15 @@ func newSnowflakeDriver() *sql.Driver {
 				MetaSpecs: &metaSpecs,
 				// Snowflake does not use the checkpoint table, instead we use the recovery log
 				// as the authoritative checkpoint and idempotent apply pattern
-				MetaCheckpoints:     nil,
-				NewClient:           newClient,
-				CreateTableTemplate: templates.createTargetTable,
-				NewResource:         newTableConfig,
-				NewTransactor:       newTransactor,
-				Tenant:              tenant,
-				ConcurrentApply:     true,
+				MetaCheckpoints:            nil,
+				NewClient:                  newClient,
+				CreateTableTemplate:        templates.createTargetTable,
+				NewResource:                newTableConfig,
+				NewTransactor:              newTransactor,
+				Tenant:                     tenant,
+				ConcurrentApply:            true,
+				HardDelete:                 parsed.HardDelete,
+				HardDeleteRequiresDocument: true,
 			}, nil
 		},
 	}
@@ -414,14 +416,27 @@ func (d *transactor) Store(it *m.StoreIterator) (m.StartCommitFunc, error) {
 	for it.Next() {
 		var b = d.bindings[it.Binding]
 
-		if it.Exists {
+		var hardDelete = b.target.IsHardDelete(it)
+		if hardDelete && !it.Exists {
+			continue // There's nothing to delete if the key was never stored.
+		} else if it.Exists {
 			b.store.mustMerge = true
 		}
 
+		var converted []interface{}
+		var err error
+		if hardDelete {
+			// The merge deletes matched rows having a null document.
+			converted, err = b.target.ConvertHardDelete(it.Key, it.Values)
+		} else {
+			converted, err = b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("converting Store: %w", err)
+		}
+
 		if err := b.store.stage.start(ctx, d.db); err != nil {
 			return nil, err
-		} else if converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON); err != nil {
-			return nil, fmt.Errorf("converting Store: %w", err)
 		} else if err = b.store.stage.encodeRow(converted); err != nil {
 			return nil, fmt.Errorf("encoding Store to scratch file: %w", err)
 		}
@@ -71,12 +71,12 @@ var snowflakeDialect = func(configSchema string) sql.Dialect {
 	}
 
 	columnValidator := sql.NewColumnValidator(
-		sql.ColValidation{Types: []string{"text"}, Validate: sql.StringCompatible},
-		sql.ColValidation{Types: []string{"boolean"}, Validate: sql.BooleanCompatible},
-		sql.ColValidation{Types: []string{"float"}, Validate: sql.NumberCompatible},
-		sql.ColValidation{Types: []string{"number"}, Validate: sql.IntegerCompatible}, // "number" is what Snowflake calls INTEGER.
+		sql.ColValidation{Types: []string{"text"}, Validate: sql.StringCompatible, MigrateTo: []string{"VARIANT"}},
+		sql.ColValidation{Types: []string{"boolean"}, Validate: sql.BooleanCompatible, MigrateTo: []string{"STRING", "VARIANT"}},
+		sql.ColValidation{Types: []string{"float"}, Validate: sql.NumberCompatible, MigrateTo: []string{"STRING", "VARIANT"}},
+		sql.ColValidation{Types: []string{"number"}, Validate: sql.IntegerCompatible, MigrateTo: []string{"DOUBLE", "STRING", "VARIANT"}}, // "number" is what Snowflake calls INTEGER.
 		sql.ColValidation{Types: []string{"variant"}, Validate: sql.JsonCompatible},
-		sql.ColValidation{Types: []string{"date"}, Validate: sql.DateCompatible},
+		sql.ColValidation{Types: []string{"date"}, Validate: sql.DateCompatible, MigrateTo: []string{"STRING", "VARIANT"}},
 		sql.ColValidation{Types: []string{"timestamp_ntz"}, Validate: sql.DateTimeCompatible},
 	)
 
@@ -173,6 +173,16 @@ COMMENT ON COLUMN {{$.Identifier}}.{{$col.Identifier}} IS {{Literal $col.Comment
 -- modifying columns together in the same statement, but either one of those
 -- things can be grouped together in separate statements, so this template will
 -- actually generate two separate statements if needed.
+--
+-- Snowflake can't change the type of a column in place except to widen some
+-- types, so columns are migrated to a new type by adding a temporary column of
+-- the new type and copying the existing values into it with a cast. The existing
+-- column is then renamed to a backup, the temporary column is renamed into its
+-- place, and only then is the backup dropped, so that the values of the column
+-- are never only held by a temporary column. The temporary and backup columns
+-- are named by suffixing the column's identifier, inside of its quotes if it
+-- has them. This is only run while the existing column is in place, so leftovers
+-- from an earlier attempt which failed partway are redundant and dropped first.
 
 {{ define "alterTableColumns" }}
 {{ if $.AddColumns -}}
@@ -191,6 +201,24 @@ ALTER TABLE {{$.Identifier}} ALTER COLUMN
 	{{- if $ind }},{{ end }}
 	{{ ColumnIdentifier $col.Name }} DROP NOT NULL
 {{- end }};
+{{- end -}}
+{{- range $ind, $col := $.ColumnTypeChanges }}
+{{- $tmp := print $col.Identifier "_flowtmp" }}
+{{- $bak := print $col.Identifier "_flowbak" }}
+{{- if Contains $col.Identifier "\"" }}
+{{- $tmp = print (slice $col.Identifier 0 (Add (len $col.Identifier) -1)) "_flowtmp\"" }}
+{{- $bak = print (slice $col.Identifier 0 (Add (len $col.Identifier) -1)) "_flowbak\"" }}
+{{- end }}
+{{- if or $ind $.AddColumns $.DropNotNulls }}
+
+{{ end -}}
+ALTER TABLE {{$.Identifier}} DROP COLUMN IF EXISTS {{$tmp}};
+ALTER TABLE {{$.Identifier}} DROP COLUMN IF EXISTS {{$bak}};
+ALTER TABLE {{$.Identifier}} ADD COLUMN {{$tmp}} {{$col.NullableDDL}};
+UPDATE {{$.Identifier}} SET {{$tmp}} = CAST({{$col.Identifier}} AS {{$col.NullableDDL}});
+ALTER TABLE {{$.Identifier}} RENAME COLUMN {{$col.Identifier}} TO {{$bak}};
+ALTER TABLE {{$.Identifier}} RENAME COLUMN {{$tmp}} TO {{$col.Identifier}};
+ALTER TABLE {{$.Identifier}} DROP COLUMN {{$bak}};
 {{- end }}
 {{ end }}
 
@@ -91,11 +91,24 @@ func TestSQLGeneration(t *testing.T) {
 			CharacterMaxLength: 0,
 		},
 	}
+	typeChanges := []sqlDriver.Column{
+		{
+			Projection: sqlDriver.Projection{Projection: pf.Projection{Field: "first_migrated_column"}},
+			Identifier: "first_migrated_column",
+			MappedType: sqlDriver.MappedType{NullableDDL: "STRING"},
+		},
+		{
+			Projection: sqlDriver.Projection{Projection: pf.Projection{Field: "secondMigratedColumn"}},
+			Identifier: `"secondMigratedColumn"`,
+			MappedType: sqlDriver.MappedType{NullableDDL: "VARIANT"},
+		},
+	}
 
 	for _, testcase := range []struct {
 		name         string
 		addColumns   []sqlDriver.Column
 		dropNotNulls []boilerplate.EndpointField
+		typeChanges  []sqlDriver.Column
 	}{
 		{
 			name:         "alter table add columns and drop not nulls",
@@ -110,12 +123,23 @@ func TestSQLGeneration(t *testing.T) {
 			name:         "alter table drop not nulls",
 			dropNotNulls: dropNotNulls,
 		},
+		{
+			name:        "alter table column types",
+			typeChanges: typeChanges,
+		},
+		{
+			name:         "alter table add columns, drop not nulls, and change column types",
+			addColumns:   addCols,
+			dropNotNulls: dropNotNulls,
+			typeChanges:  typeChanges,
+		},
 	} {
 		snap.WriteString("--- Begin " + testcase.name + " ---")
 		require.NoError(t, templates.alterTableColumns.Execute(&snap, sqlDriver.TableAlter{
-			Table:        table1,
-			AddColumns:   testcase.addColumns,
-			DropNotNulls: testcase.dropNotNulls,
+			Table:             table1,
+			AddColumns:        testcase.addColumns,
+			DropNotNulls:      testcase.dropNotNulls,
+			ColumnTypeChanges: testcase.typeChanges,
 		}))
 		snap.WriteString("--- End " + testcase.name + " ---\n\n")
 	}
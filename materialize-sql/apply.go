@@ -22,7 +22,8 @@ type TableCreate struct {
 }
 
 // TableAlter is the alterations for a table that are needed, including new columns that should be
-// added and existing columns that should have their nullability constraints dropped.
+// added, existing columns that should have their nullability constraints dropped, and existing
+// columns that should be migrated to a new type.
 type TableAlter struct {
 	Table
 	AddColumns []Column
@@ -32,6 +33,11 @@ type TableAlter struct {
 	// materialized table and is required but is not included in the field selection for the
 	// materialization.
 	DropNotNulls []boilerplate.EndpointField
+
+	// ColumnTypeChanges is a list of existing columns that are no longer compatible with their
+	// projections, and must be migrated to the new type of the column. The migrated column
+	// is always nullable, and should be created using its NullableDDL.
+	ColumnTypeChanges []Column
 }
 
 // MetaSpecsUpdate is an endpoint-specific parameterized query and parameters needed to persist a
@@ -226,9 +232,28 @@ func (a *sqlApplier) UpdateResource(ctx context.Context, spec *pf.Materializatio
 		alter.AddColumns = append(alter.AddColumns, col)
 	}
 
-	// We only currently handle adding columns or dropping nullability constraints for SQL
-	// materializations.
-	if len(alter.AddColumns) == 0 && len(alter.DropNotNulls) == 0 {
+	// Existing value columns which are no longer compatible with their projections were validated
+	// as being migratable, and are migrated to their new types.
+	var c = constrainter{dialect: a.endpoint.Dialect}
+	for _, col := range table.Values {
+		if !a.is.HasField(table.Path, col.Field) {
+			continue
+		}
+		existing, err := a.is.GetField(table.Path, col.Field)
+		if err != nil {
+			return "", nil, err
+		}
+		rawConfig := spec.Bindings[bindingIndex].FieldSelection.FieldConfigJsonMap[col.Field]
+		if migratable, err := c.Migratable(existing, &col.Projection.Projection, rawConfig); err != nil {
+			return "", nil, fmt.Errorf("determining migratability for column %q of table %q: %w", existing.Name, table.Identifier, err)
+		} else if migratable {
+			alter.ColumnTypeChanges = append(alter.ColumnTypeChanges, col)
+		}
+	}
+
+	// We only currently handle adding columns, dropping nullability constraints, and migrating
+	// column types for SQL materializations.
+	if len(alter.AddColumns) == 0 && len(alter.DropNotNulls) == 0 && len(alter.ColumnTypeChanges) == 0 {
 		return "", nil, nil
 	}
 
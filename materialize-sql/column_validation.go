@@ -12,6 +12,7 @@ import (
 // ColumnValidator validates existing columns against proposed Flow collection projections.
 type ColumnValidator struct {
 	validationStrategies map[string]ColumnValidationFn
+	migrations           map[string][]string
 }
 
 // NewColumnValidator creates a ColumnValidator from one or more ColValidations, which are
@@ -20,6 +21,7 @@ type ColumnValidator struct {
 func NewColumnValidator(validations ...ColValidation) ColumnValidator {
 	cv := ColumnValidator{
 		validationStrategies: make(map[string]ColumnValidationFn),
+		migrations:           make(map[string][]string),
 	}
 
 	for _, v := range validations {
@@ -31,6 +33,9 @@ func NewColumnValidator(validations ...ColValidation) ColumnValidator {
 				panic(fmt.Sprintf("NewColumnValidator duplicated endpoint type %q", t))
 			}
 			cv.validationStrategies[t] = v.Validate
+			for _, m := range v.MigrateTo {
+				cv.migrations[t] = append(cv.migrations[t], strings.ToLower(m))
+			}
 		}
 	}
 
@@ -47,11 +52,20 @@ func (cv ColumnValidator) ValidateColumn(existing boilerplate.EndpointField, p p
 	return v(p), nil
 }
 
+// CanMigrate reports whether an existing column can be migrated in place to the endpoint type
+// `newDDL`, which is the nullable DDL of the type its projection is now mapped to.
+func (cv ColumnValidator) CanMigrate(existing boilerplate.EndpointField, newDDL string) bool {
+	return slices.Contains(cv.migrations[strings.ToLower(existing.Type)], strings.ToLower(newDDL))
+}
+
 // ColValidation represents a column validation strategy for one or more endpoint column types. Each
-// of the column types in `Types` is validated with the validation function.
+// of the column types in `Types` is validated with the validation function. Columns of these types
+// may be migrated in place to any of the nullable DDLs in `MigrateTo` when they are no longer
+// compatible with their projection, which should only list migrations that won't lose data.
 type ColValidation struct {
-	Types    []string
-	Validate ColumnValidationFn
+	Types     []string
+	Validate  ColumnValidationFn
+	MigrateTo []string
 }
 
 // ColumnValidationFn is a function that reports if the Flow projection is compatible. Individual
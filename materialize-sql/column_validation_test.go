@@ -0,0 +1,48 @@
+package sql
+
+import (
+	"testing"
+
+	boilerplate "github.com/estuary/connectors/materialize-boilerplate"
+	pf "github.com/estuary/flow/go/protocols/flow"
+	"github.com/stretchr/testify/require"
+)
+
+// newMigrationTestDialect returns the test Dialect with a ColumnValidator which permits migrating
+// bigint columns to double precision and text.
+func newMigrationTestDialect() Dialect {
+	var dialect = newTestDialect()
+	dialect.ColumnValidator = NewColumnValidator(
+		ColValidation{Types: []string{"bigint"}, Validate: IntegerCompatible, MigrateTo: []string{"DOUBLE PRECISION", "TEXT"}},
+		ColValidation{Types: []string{"boolean"}, Validate: BooleanCompatible},
+	)
+	return dialect
+}
+
+func TestConstrainterMigratable(t *testing.T) {
+	var c = constrainter{dialect: newMigrationTestDialect()}
+	var existing = boilerplate.EndpointField{Name: "value", Type: "bigint"}
+
+	var projection = func(types ...string) *pf.Projection {
+		return &pf.Projection{Field: "value", Inference: pf.Inference{Types: types, Exists: pf.Inference_MAY, String_: &pf.Inference_String{}}}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		proposed   *pf.Projection
+		existing   boilerplate.EndpointField
+		migratable bool
+	}{
+		{name: "compatible", proposed: projection("integer"), existing: existing, migratable: false},
+		{name: "integer to number", proposed: projection("number"), existing: existing, migratable: true},
+		{name: "integer to string", proposed: projection("string"), existing: existing, migratable: true},
+		{name: "integer to object", proposed: projection("object"), existing: existing, migratable: false},
+		{name: "no migrations for type", proposed: projection("string"), existing: boilerplate.EndpointField{Name: "value", Type: "boolean"}, migratable: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Migratable(tt.existing, tt.proposed, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.migratable, got)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package sql
+
+import (
+	"fmt"
+	"slices"
+
+	m "github.com/estuary/connectors/go/protocols/materialize"
+	"github.com/estuary/flow/go/protocols/fdb/tuple"
+	pf "github.com/estuary/flow/go/protocols/flow"
+	"github.com/segmentio/encoding/json"
+)
+
+// IsHardDelete returns true if the current document of the StoreIterator should be deleted from
+// the table instead of stored. This is the case when the table has hard deletes enabled and the
+// runtime marked the document as a deletion, or the document is a change event with a `_meta/op`
+// of "d" as emitted by CDC captures.
+func (t *Table) IsHardDelete(it *m.StoreIterator) bool {
+	if !t.HardDelete {
+		return false
+	} else if it.Delete {
+		return true
+	}
+
+	var doc struct {
+		Meta struct {
+			Op string `json:"op"`
+		} `json:"_meta"`
+	}
+	if err := json.Unmarshal(it.RawJSON, &doc); err != nil {
+		return false // Documents lacking a `_meta` object aren't change events.
+	}
+	return doc.Meta.Op == "d"
+}
+
+// ConvertHardDelete converts key and values Tuples of a hard-deleted document into database
+// parameters, with a NULL document. Drivers which stage stores and merge them into their target
+// tables delete matched rows having a NULL document, so the table must have a Document column.
+func (t *Table) ConvertHardDelete(key, values tuple.Tuple) (out []interface{}, err error) {
+	out = make([]interface{}, 0, len(t.Keys)+len(t.Values)+1)
+	if out, err = convertTuple(key, t.Keys, out); err != nil {
+		return nil, err
+	} else if out, err = convertTuple(values, t.Values, out); err != nil {
+		return nil, err
+	}
+	return append(out, nil), nil
+}
+
+// validateHardDelete returns an error if the binding of Resource would use hard deletes but can't
+// materialize a root document, which drivers that stage hard deletes as rows having a NULL
+// document rely on to tell deletions apart from stored documents.
+func validateHardDelete(endpoint *Endpoint, res Resource, collection pf.CollectionSpec) error {
+	if !endpoint.HardDelete || !endpoint.HardDeleteRequiresDocument || res.DeltaUpdates() || IsHistory(res) {
+		return nil
+	} else if !slices.ContainsFunc(collection.Projections, func(p pf.Projection) bool { return p.IsRootDocumentProjection() }) {
+		return fmt.Errorf("table %s uses hard deletes, which require collection %s to have a root document projection", res.Path(), collection.Name)
+	}
+	return nil
+}
+
+// validateHardDeleteTable returns an error if the Table uses hard deletes without a Document
+// column, for drivers which require one. This can't happen for bindings which passed validation,
+// and is checked so that deletions are never silently stored as regular rows.
+func validateHardDeleteTable(endpoint *Endpoint, table Table) error {
+	if endpoint.HardDeleteRequiresDocument && table.HardDelete && table.Document == nil {
+		return fmt.Errorf("table %s uses hard deletes but does not materialize a root document", table.Identifier)
+	}
+	return nil
+}
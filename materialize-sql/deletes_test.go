@@ -0,0 +1,59 @@
+package sql
+
+import (
+	"encoding/json"
+	"testing"
+
+	m "github.com/estuary/connectors/go/protocols/materialize"
+	pf "github.com/estuary/flow/go/protocols/flow"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsHardDelete(t *testing.T) {
+	var table = Table{TableShape: TableShape{HardDelete: true}}
+	for _, tc := range []struct {
+		name   string
+		it     m.StoreIterator
+		expect bool
+	}{
+		{"insert", m.StoreIterator{RawJSON: json.RawMessage(`{"id":1,"_meta":{"op":"c"}}`)}, false},
+		{"update", m.StoreIterator{RawJSON: json.RawMessage(`{"id":1,"_meta":{"op":"u"}}`), Exists: true}, false},
+		{"deletion", m.StoreIterator{RawJSON: json.RawMessage(`{"id":1,"_meta":{"op":"d","source":{"table":"t"}}}`), Exists: true}, true},
+		{"runtime deletion", m.StoreIterator{RawJSON: json.RawMessage(`{"id":1}`), Delete: true}, true},
+		{"no metadata", m.StoreIterator{RawJSON: json.RawMessage(`{"id":1}`)}, false},
+		{"invalid metadata", m.StoreIterator{RawJSON: json.RawMessage(`{"id":1,"_meta":"d"}`)}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, table.IsHardDelete(&tc.it))
+		})
+	}
+
+	// Deletions are stored as regular rows unless hard deletes are enabled.
+	table.HardDelete = false
+	require.False(t, table.IsHardDelete(&m.StoreIterator{Delete: true}))
+}
+
+func TestValidateHardDelete(t *testing.T) {
+	var endpoint = &Endpoint{HardDelete: true, HardDeleteRequiresDocument: true}
+	var collection = pf.CollectionSpec{
+		Name:        "acmeCo/widgets",
+		Projections: []pf.Projection{{Field: "id", Ptr: "/id"}, {Field: "flow_document", Ptr: ""}},
+	}
+	require.NoError(t, validateHardDelete(endpoint, testResource{}, collection))
+
+	collection.Projections = collection.Projections[:1]
+	require.ErrorContains(t, validateHardDelete(endpoint, testResource{}, collection), "require collection acmeCo/widgets to have a root document projection")
+
+	// History tables don't use hard deletes, and nor do endpoints without them enabled.
+	require.NoError(t, validateHardDelete(endpoint, testHistoryResource{history: true}, collection))
+	require.NoError(t, validateHardDelete(&Endpoint{}, testResource{}, collection))
+
+	// Drivers which delete rows by key don't need a root document.
+	require.NoError(t, validateHardDelete(&Endpoint{HardDelete: true}, testResource{}, collection))
+
+	var table = Table{TableShape: TableShape{HardDelete: true}, Identifier: "widgets"}
+	require.ErrorContains(t, validateHardDeleteTable(endpoint, table), "table widgets uses hard deletes but does not materialize a root document")
+	require.NoError(t, validateHardDeleteTable(&Endpoint{HardDelete: true}, table))
+	table.Document = &Column{}
+	require.NoError(t, validateHardDeleteTable(endpoint, table))
+}
@@ -120,6 +120,12 @@ func (d *Driver) Validate(ctx context.Context, req *pm.Request_Validate) (*pm.Re
 	for idx, bindingSpec := range req.Bindings {
 		res := resources[idx]
 
+		if err := validateHistory(endpoint, res, bindingSpec, is, loadedSpec); err != nil {
+			return nil, err
+		} else if err := validateHardDelete(endpoint, res, bindingSpec.Collection); err != nil {
+			return nil, err
+		}
+
 		constraints, err := validator.ValidateBinding(
 			res.Path(),
 			res.DeltaUpdates(),
@@ -173,6 +179,12 @@ func (d *Driver) Apply(ctx context.Context, req *pm.Request_Apply) (*pm.Response
 	return boilerplate.ApplyChanges(ctx, req, newSqlApplier(client, is, endpoint), is, endpoint.ConcurrentApply)
 }
 
+// SupportsDryRunApply implements the boilerplate.DryRunApplier interface, since Apply uses
+// boilerplate.ApplyChanges.
+func (d *Driver) SupportsDryRunApply() bool {
+	return true
+}
+
 func (d *Driver) NewTransactor(ctx context.Context, open pm.Request_Open) (m.Transactor, *pm.Response_Opened, error) {
 	var loadedVersion string
 
@@ -212,6 +224,10 @@ func (d *Driver) NewTransactor(ctx context.Context, open pm.Request_Open) (m.Tra
 			return nil, nil, err
 		} else {
 			table.StateKey = spec.StateKey
+			table.HardDelete = endpoint.HardDelete && !table.DeltaUpdates && !table.History
+			if err := validateHardDeleteTable(endpoint, table); err != nil {
+				return nil, nil, err
+			}
 			tables = append(tables, table)
 		}
 	}
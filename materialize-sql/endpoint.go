@@ -109,6 +109,12 @@ type Endpoint struct {
 	// ConcurrentApply of Apply actions, for system that may benefit from a scatter/gather strategy
 	// for changing many tables in a single apply.
 	ConcurrentApply bool
+	// HardDelete rows of standard-updates tables when the documents stored for them are
+	// deletions, instead of storing the deletion documents as regular rows.
+	HardDelete bool
+	// HardDeleteRequiresDocument is set by drivers which stage hard deletes as rows having a
+	// NULL document, which requires tables using hard deletes to materialize a root document.
+	HardDeleteRequiresDocument bool
 }
 
 // PrereqErr is a wrapper for recording accumulated errors during prerequisite checking and
@@ -0,0 +1,141 @@
+package sql
+
+import (
+	"fmt"
+	"slices"
+
+	boilerplate "github.com/estuary/connectors/materialize-boilerplate"
+	"github.com/estuary/flow/go/protocols/flow"
+	pm "github.com/estuary/flow/go/protocols/materialize"
+)
+
+// Names of the system columns added to tables which are materialized in history mode.
+const (
+	HistoryValidFrom = "valid_from"
+	HistoryValidTo   = "valid_to"
+	HistoryIsCurrent = "is_current"
+)
+
+// HistoryResource is implemented by the Resources of drivers which support materializing tables in
+// history mode, which is a slowly-changing dimension of type 2.
+type HistoryResource interface {
+	// History is true if the resource should be materialized in history mode.
+	History() bool
+}
+
+// IsHistory returns true if the Resource should be materialized in history mode.
+func IsHistory(r Resource) bool {
+	h, ok := r.(HistoryResource)
+	return ok && h.History()
+}
+
+// HistoryColumns are the system columns of a table which is materialized in history mode. Rows of
+// the table are versions of a key, and each stored document closes out the current version of its
+// key by setting ValidTo and clearing IsCurrent, and then inserts a new current version having
+// ValidFrom set. All of these columns are nullable so that they never have to be altered when
+// applying changes to the field selection.
+type HistoryColumns struct {
+	ValidFrom Column
+	ValidTo   Column
+	IsCurrent Column
+}
+
+// All returns the history columns in the order they're created.
+func (h *HistoryColumns) All() []*Column {
+	return []*Column{&h.ValidFrom, &h.ValidTo, &h.IsCurrent}
+}
+
+// historyProjections returns the Projections of the history system columns.
+func historyProjections() []Projection {
+	var timestamp = func(field, comment string) Projection {
+		return Projection{
+			Projection: flow.Projection{
+				Field: field,
+				Inference: flow.Inference{
+					Types:   []string{"string", "null"},
+					Exists:  flow.Inference_MAY,
+					String_: &flow.Inference_String{Format: "date-time"},
+				},
+			},
+			Comment: comment,
+		}
+	}
+
+	return []Projection{
+		timestamp(HistoryValidFrom, "The time at which this version of the key was stored."),
+		timestamp(HistoryValidTo, "The time at which this version of the key was replaced by a newer version, or null if it's the current version."),
+		{
+			Projection: flow.Projection{
+				Field: HistoryIsCurrent,
+				Inference: flow.Inference{
+					Types:  []string{"boolean", "null"},
+					Exists: flow.Inference_MAY,
+				},
+			},
+			Comment: "Whether this is the current version of the key.",
+		},
+	}
+}
+
+// resolveHistoryColumns maps the history system columns of a table using the given Dialect. They
+// aren't bound as parameters of any statement and so have no meaningful placeholders.
+func resolveHistoryColumns(dialect Dialect) (*HistoryColumns, error) {
+	var out = new(HistoryColumns)
+	for i, p := range historyProjections() {
+		resolved, err := ResolveColumn(0, &p, dialect)
+		if err != nil {
+			return nil, fmt.Errorf("resolving history column %s: %w", p.Field, err)
+		}
+		*out.All()[i] = resolved
+	}
+	return out, nil
+}
+
+// validateHistoryFields returns an error if any of the fields of the bound collection conflict
+// with the history system columns.
+func validateHistoryFields(collection flow.CollectionSpec) error {
+	var reserved = []string{HistoryValidFrom, HistoryValidTo, HistoryIsCurrent}
+	for _, p := range collection.Projections {
+		if slices.Contains(reserved, p.Field) {
+			return fmt.Errorf("collection %s has a field %q which conflicts with a history mode column", collection.Name, p.Field)
+		}
+	}
+	return nil
+}
+
+// validateHistory returns an error if the binding can't be materialized in the history mode of its
+// Resource. Tables aren't converted between history mode and other modes, since their primary keys
+// differ, and so changing the mode of an existing table requires that it be backfilled.
+func validateHistory(
+	endpoint *Endpoint,
+	res Resource,
+	binding *pm.Request_Validate_Binding,
+	is *boilerplate.InfoSchema,
+	storedSpec *flow.MaterializationSpec,
+) error {
+	var history = IsHistory(res)
+	if history && res.DeltaUpdates() {
+		return fmt.Errorf("table %s cannot use both delta updates and history mode", res.Path())
+	} else if history {
+		if err := validateHistoryFields(binding.Collection); err != nil {
+			return err
+		}
+	}
+
+	if storedSpec == nil || !is.HasResource(res.Path()) {
+		return nil
+	}
+	for _, existing := range storedSpec.Bindings {
+		if !slices.Equal(existing.ResourcePath, res.Path()) || existing.Backfill != binding.Backfill {
+			continue
+		}
+
+		var existingRes = endpoint.NewResource(endpoint)
+		if err := flow.UnmarshalStrict(existing.ResourceConfigJson, existingRes); err != nil {
+			return fmt.Errorf("unmarshalling existing resource binding for collection %q: %w", existing.Collection.Name.String(), err)
+		} else if IsHistory(existingRes) != history {
+			return fmt.Errorf("changing history mode of existing table %s requires the binding to be backfilled", res.Path())
+		}
+	}
+	return nil
+}
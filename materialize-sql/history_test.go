@@ -0,0 +1,54 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/estuary/flow/go/protocols/flow"
+	"github.com/stretchr/testify/require"
+)
+
+type testHistoryResource struct {
+	testResource
+	history bool
+}
+
+type testResource struct{}
+
+func (testResource) Validate() error        { return nil }
+func (testResource) Path() TablePath        { return TablePath{"one", "two", "three"} }
+func (testResource) DeltaUpdates() bool     { return false }
+func (r testHistoryResource) History() bool { return r.history }
+
+func TestResolveHistoryTable(t *testing.T) {
+	require.False(t, IsHistory(testResource{}))
+	require.False(t, IsHistory(testHistoryResource{}))
+	require.True(t, IsHistory(testHistoryResource{history: true}))
+
+	var shape = FlowCheckpointsTable("one", "two", "three")
+	table, err := ResolveTable(shape, newTestDialect())
+	require.NoError(t, err)
+	require.Nil(t, table.HistoryColumns)
+
+	shape.History = true
+	table, err = ResolveTable(shape, newTestDialect())
+	require.NoError(t, err)
+	require.NotNil(t, table.HistoryColumns)
+
+	// History columns are nullable and aren't included in the columns bound as parameters.
+	require.Equal(t, "valid_from", table.HistoryColumns.ValidFrom.Identifier)
+	require.Equal(t, "TIMESTAMPTZ", table.HistoryColumns.ValidFrom.DDL)
+	require.Equal(t, "TIMESTAMPTZ", table.HistoryColumns.ValidTo.DDL)
+	require.Equal(t, "BOOLEAN", table.HistoryColumns.IsCurrent.DDL)
+	require.Len(t, table.Columns(), len(shape.Keys)+len(shape.Values))
+}
+
+func TestValidateHistoryFields(t *testing.T) {
+	var collection = flow.CollectionSpec{
+		Name:        "acmeCo/widgets",
+		Projections: []flow.Projection{{Field: "id"}, {Field: "name"}},
+	}
+	require.NoError(t, validateHistoryFields(collection))
+
+	collection.Projections = append(collection.Projections, flow.Projection{Field: "is_current"})
+	require.ErrorContains(t, validateHistoryFields(collection), `field "is_current" which conflicts`)
+}
@@ -23,6 +23,12 @@ type TableShape struct {
 	Comment string
 	// The table is operating in delta-updates mode (instead of a standard materialization).
 	DeltaUpdates bool
+	// Rows are deleted from the table (instead of updated) when the documents stored for them
+	// are deletions. It's never set for tables operating in delta-updates or history mode.
+	HardDelete bool
+	// The table is operating in history mode, and keeps a row for every stored version of
+	// each key (instead of only the current one).
+	History bool
 
 	Keys, Values []Projection
 	Document     *Projection
@@ -41,6 +47,8 @@ type Table struct {
 	// Keys, Values, and the (optional) Document column.
 	Keys, Values []Column
 	Document     *Column
+	// System columns of tables operating in history mode, or nil otherwise.
+	HistoryColumns *HistoryColumns
 
 	// The stateKey associated with this table's binding
 	StateKey string
@@ -169,6 +177,13 @@ func ResolveTable(shape TableShape, dialect Dialect) (Table, error) {
 		*col = resolved
 	}
 
+	if shape.History {
+		var err error
+		if table.HistoryColumns, err = resolveHistoryColumns(dialect); err != nil {
+			return Table{}, fmt.Errorf("resolving history columns of %s: %w", shape.Path, err)
+		}
+	}
+
 	return table, nil
 }
 
@@ -204,6 +219,7 @@ func BuildTableShape(spec *pf.MaterializationSpec, index int, resource Resource)
 		Source:       binding.Collection.Name,
 		Comment:      comment,
 		DeltaUpdates: resource.DeltaUpdates(),
+		History:      IsHistory(resource),
 		Keys:         keys,
 		Values:       values,
 		Document:     document,
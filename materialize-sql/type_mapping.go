@@ -540,6 +540,19 @@ func (c constrainter) Compatible(existing boilerplate.EndpointField, proposed *p
 	return c.dialect.ValidateColumn(existing, *p)
 }
 
+func (c constrainter) Migratable(existing boilerplate.EndpointField, proposed *pf.Projection, rawFieldConfig json.RawMessage) (bool, error) {
+	if compatible, err := c.Compatible(existing, proposed, rawFieldConfig); err != nil || compatible {
+		return false, err
+	}
+
+	newDesc, err := c.DescriptionForType(proposed, rawFieldConfig)
+	if err != nil {
+		return false, err
+	}
+
+	return c.dialect.CanMigrate(existing, newDesc), nil
+}
+
 func (c constrainter) DescriptionForType(p *pf.Projection, rawFieldConfig json.RawMessage) (string, error) {
 	pp := buildProjection(p, rawFieldConfig)
 
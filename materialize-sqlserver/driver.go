@@ -32,10 +32,11 @@ type tunnelConfig struct {
 
 // config represents the endpoint configuration for sql server.
 type config struct {
-	Address  string `json:"address" jsonschema:"title=Address,description=Host and port of the database (in the form of host[:port]). Port 1433 is used as the default if no specific port is provided." jsonschema_extras:"order=0"`
-	User     string `json:"user" jsonschema:"title=User,description=Database user to connect as." jsonschema_extras:"order=1"`
-	Password string `json:"password" jsonschema:"title=Password,description=Password for the specified database user." jsonschema_extras:"secret=true,order=2"`
-	Database string `json:"database" jsonschema:"title=Database,description=Name of the logical database to materialize to." jsonschema_extras:"order=3"`
+	Address    string `json:"address" jsonschema:"title=Address,description=Host and port of the database (in the form of host[:port]). Port 1433 is used as the default if no specific port is provided." jsonschema_extras:"order=0"`
+	User       string `json:"user" jsonschema:"title=User,description=Database user to connect as." jsonschema_extras:"order=1"`
+	Password   string `json:"password" jsonschema:"title=Password,description=Password for the specified database user." jsonschema_extras:"secret=true,order=2"`
+	Database   string `json:"database" jsonschema:"title=Database,description=Name of the logical database to materialize to." jsonschema_extras:"order=3"`
+	HardDelete bool   `json:"hardDelete,omitempty" jsonschema:"title=Hard Delete,description=Delete rows from standard-updates tables when their source documents are deleted (such as CDC deletions having a '_meta/op' of 'd'). By default deletions are stored as regular rows." jsonschema_extras:"order=4"`
 
 	NetworkTunnel *tunnelConfig `json:"networkTunnel,omitempty" jsonschema:"title=Network Tunnel,description=Connect to your system through an SSH server that acts as a bastion host for your network."`
 }
@@ -91,8 +92,9 @@ func (c *config) ToURI() string {
 }
 
 type tableConfig struct {
-	Table string `json:"table" jsonschema:"title=Table,description=Name of the database table" jsonschema_extras:"x-collection-name=true"`
-	Delta bool   `json:"delta_updates,omitempty" jsonschema:"default=false,title=Delta Update,description=Should updates to this table be done via delta updates. Default is false."`
+	Table       string `json:"table" jsonschema:"title=Table,description=Name of the database table" jsonschema_extras:"x-collection-name=true"`
+	Delta       bool   `json:"delta_updates,omitempty" jsonschema:"default=false,title=Delta Update,description=Should updates to this table be done via delta updates. Default is false."`
+	HistoryMode bool   `json:"history_mode,omitempty" jsonschema:"default=false,title=History Mode,description=Should this table keep every version of each document as a separate row (a type 2 slowly-changing dimension). Versions are tracked by the valid_from and valid_to and is_current columns. Default is false."`
 }
 
 func newTableConfig(ep *sql.Endpoint) sql.Resource {
@@ -115,6 +117,10 @@ func (c tableConfig) DeltaUpdates() bool {
 	return c.Delta
 }
 
+func (c tableConfig) History() bool {
+	return c.HistoryMode
+}
+
 func newSqlServerDriver() *sql.Driver {
 	return &sql.Driver{
 		DocumentationURL: "https://go.estuary.dev/materialize-sqlserver",
@@ -190,6 +196,7 @@ func newSqlServerDriver() *sql.Driver {
 				NewTransactor:       prepareNewTransactor(templates),
 				Tenant:              tenant,
 				ConcurrentApply:     false,
+				HardDelete:          cfg.HardDelete,
 			}, nil
 		},
 	}
@@ -261,6 +268,10 @@ type binding struct {
 	// into the target table. Note that in case of delta updates, "needsMerge"
 	// will always be false
 	needsMerge bool
+	// deleteKeys are the keys of documents to be hard-deleted from the target
+	// table. They're held until commit rather than executed as they're read,
+	// since the store transaction is busy with a bulk insert at that time.
+	deleteKeys [][]any
 
 	createLoadTableSQL string
 	loadQuerySQL       string
@@ -273,6 +284,9 @@ type binding struct {
 	tempStoreTruncate   string
 	mergeInto           string
 	directCopy          string
+	deleteKey           string
+	historyClose        string
+	historyInsert       string
 }
 
 func (t *transactor) addBinding(ctx context.Context, target sql.Table) error {
@@ -292,6 +306,9 @@ func (t *transactor) addBinding(ctx context.Context, target sql.Table) error {
 		{&b.tempLoadTableName, t.templates.tempLoadTableName},
 		{&b.mergeInto, t.templates.mergeInto},
 		{&b.directCopy, t.templates.directCopy},
+		{&b.deleteKey, t.templates.deleteKey},
+		{&b.historyClose, t.templates.historyClose},
+		{&b.historyInsert, t.templates.historyInsert},
 	} {
 		var err error
 		if *m.sql, err = sql.RenderTableTemplate(target, m.tpl); err != nil {
@@ -446,11 +463,12 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 		// The last binding is fully processed for this RPC now, we can drain its
 		// remaining batches
 		if lastBinding != it.Binding {
-			var batch = batches[lastBinding]
-			var b = d.bindings[lastBinding]
+			if batch, ok := batches[lastBinding]; ok {
+				var b = d.bindings[lastBinding]
 
-			if _, err := batch.ExecContext(ctx); err != nil {
-				return nil, fmt.Errorf("store batch insert on %q: %w", b.target.Identifier, err)
+				if _, err := batch.ExecContext(ctx); err != nil {
+					return nil, fmt.Errorf("store batch insert on %q: %w", b.target.Identifier, err)
+				}
 			}
 
 			lastBinding = it.Binding
@@ -458,6 +476,17 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 
 		var b = d.bindings[it.Binding]
 
+		if b.target.IsHardDelete(it) {
+			if !it.Exists {
+				continue // There's nothing to delete if the key was never stored.
+			} else if converted, err := b.target.ConvertKey(it.Key); err != nil {
+				return nil, fmt.Errorf("converting delete parameters: %w", err)
+			} else {
+				b.deleteKeys = append(b.deleteKeys, converted)
+			}
+			continue
+		}
+
 		converted, err := b.target.ConvertAll(it.Key, it.Values, it.RawJSON)
 		if err != nil {
 			return nil, fmt.Errorf("converting store parameters: %w", err)
@@ -487,8 +516,10 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 		}
 	}
 
-	if _, err := batches[lastBinding].ExecContext(ctx); err != nil {
-		return nil, fmt.Errorf("store batch insert on %q: %w", d.bindings[lastBinding].tempStoreTableName, err)
+	if batch, ok := batches[lastBinding]; ok {
+		if _, err := batch.ExecContext(ctx); err != nil {
+			return nil, fmt.Errorf("store batch insert on %q: %w", d.bindings[lastBinding].tempStoreTableName, err)
+		}
 	}
 
 	return func(ctx context.Context, runtimeCheckpoint *protocol.Checkpoint) (*pf.ConnectorState, m.OpFuture) {
@@ -496,7 +527,24 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 			defer txn.Rollback()
 
 			for _, b := range d.bindings {
-				if b.needsMerge {
+				for _, key := range b.deleteKeys {
+					if _, err := txn.ExecContext(ctx, b.deleteKey, key...); err != nil {
+						return fmt.Errorf("store delete on %q: %w", b.target.Identifier, err)
+					}
+				}
+
+				if b.target.History {
+					// Close out the current versions of keys that already exist, and insert
+					// the new versions of all stored keys.
+					if b.needsMerge {
+						if _, err := txn.ExecContext(ctx, b.historyClose); err != nil {
+							return fmt.Errorf("store closing out current versions on %q: %w", b.target.Identifier, err)
+						}
+					}
+					if _, err := txn.ExecContext(ctx, b.historyInsert); err != nil {
+						return fmt.Errorf("store inserting new versions on %q: %w", b.target.Identifier, err)
+					}
+				} else if b.needsMerge {
 					log.WithField("table", b.target.Identifier).Info("store: starting merging data into table")
 					if _, err := txn.ExecContext(ctx, b.mergeInto); err != nil {
 						return fmt.Errorf("store batch merge on %q: %w", b.target.Identifier, err)
@@ -514,8 +562,9 @@ func (d *transactor) Store(it *m.StoreIterator) (_ m.StartCommitFunc, err error)
 					return fmt.Errorf("truncating store table: %w", err)
 				}
 
-				// reset the value for next transaction
+				// reset the values for next transaction
 				b.needsMerge = false
+				b.deleteKeys = nil
 			}
 
 			var err error
@@ -165,6 +165,9 @@ type templates struct {
 	createTargetTable  *template.Template
 	directCopy         *template.Template
 	mergeInto          *template.Template
+	deleteKey          *template.Template
+	historyClose       *template.Template
+	historyInsert      *template.Template
 	loadInsert         *template.Template
 	loadQuery          *template.Template
 	updateFence        *template.Template
@@ -190,7 +193,12 @@ CREATE TABLE {{$.Identifier}} (
 		{{- if $ind }},{{ end }}
 		{{$col.Identifier}} {{$col.DDL}}
 	{{- end }}
-	{{- if not $.DeltaUpdates }},
+	{{- if $.HistoryColumns }}
+	{{- range $col := $.HistoryColumns.All }},
+		{{$col.Identifier}} {{$col.DDL}}
+	{{- end }}
+	{{- end }}
+	{{- if not (or $.DeltaUpdates $.History) }},
 
 		PRIMARY KEY (
 	{{- range $ind, $key := $.Keys }}
@@ -200,6 +208,14 @@ CREATE TABLE {{$.Identifier}} (
 	)
 	{{- end }}
 );
+{{- if $.HistoryColumns }}
+CREATE UNIQUE INDEX {{ ColumnIdentifier (printf "%s_current_key" (Last $.Path)) }} ON {{$.Identifier}} (
+	{{- range $ind, $key := $.Keys }}
+		{{- if $ind }}, {{end -}}
+		{{$key.Identifier}}
+	{{- end -}}
+) WHERE {{ $.HistoryColumns.IsCurrent.Identifier }} = 1;
+{{- end }}
 END;
 {{ end }}
 
@@ -294,6 +310,9 @@ SELECT {{ $.Binding }}, r.{{$.Document.Identifier}}
 		{{ if $ind }} AND {{ else }} ON  {{ end -}}
 		l.{{ $key.Identifier }} = r.{{ $key.Identifier }}
 	{{- end }}
+	{{- if $.HistoryColumns }}
+		 AND r.{{ $.HistoryColumns.IsCurrent.Identifier }} = 1
+	{{- end }}
 {{ else -}}
 SELECT TOP 0 -1, NULL
 {{ end }}
@@ -357,6 +376,54 @@ SELECT TOP 0 -1, NULL
 	);
 {{ end }}
 
+-- Templated query which closes out the current versions of keys of a history-mode table which
+-- are present in the temporary store table:
+
+{{ define "historyClose" }}
+{{ if $.HistoryColumns -}}
+	UPDATE t SET
+		{{ $.HistoryColumns.ValidTo.Identifier }} = SYSUTCDATETIME(),
+		{{ $.HistoryColumns.IsCurrent.Identifier }} = 0
+	FROM {{ $.Identifier }} AS t
+	JOIN {{ template "temp_store_name" . }} AS r
+	{{- range $ind, $key := $.Keys }}
+		{{ if $ind }} AND {{ else }} ON  {{ end -}}
+		t.{{ $key.Identifier }} = r.{{ $key.Identifier }}
+	{{- end }}
+	WHERE t.{{ $.HistoryColumns.IsCurrent.Identifier }} = 1;
+{{- end }}
+{{ end }}
+
+-- Templated query which inserts the rows of the temporary store table as the current versions of
+-- their keys in a history-mode table:
+
+{{ define "historyInsert" }}
+{{ if $.HistoryColumns -}}
+	INSERT INTO {{ $.Identifier }}
+		(
+			{{ range $ind, $key := $.Columns }}
+				{{- if $ind }}, {{ end -}}
+				{{ $key.Identifier -}}
+			{{- end }}, {{ $.HistoryColumns.ValidFrom.Identifier }}, {{ $.HistoryColumns.IsCurrent.Identifier }}
+		)
+	SELECT
+			{{ range $ind, $key := $.Columns }}
+				{{- if $ind }}, {{ end -}}
+				{{ $key.Identifier -}}
+			{{- end }}, SYSUTCDATETIME(), 1
+	FROM {{ template "temp_store_name" . }};
+{{- end }}
+{{ end }}
+
+{{ define "deleteKey" }}
+	DELETE FROM {{ $.Identifier }}
+	{{- range $ind, $key := $.Keys }}
+	{{ if $ind }} AND   {{ else }} WHERE {{ end -}}
+	{{ $key.Identifier }} = {{ $key.Placeholder }}
+	{{- end -}}
+	;
+{{ end }}
+
 {{ define "updateFence" }}
 UPDATE {{ Identifier $.TablePath }}
 	SET   "checkpoint" = {{ Literal (Base64Std $.Checkpoint) }}
@@ -378,6 +445,9 @@ UPDATE {{ Identifier $.TablePath }}
 		createTargetTable:  tplAll.Lookup("createTargetTable"),
 		directCopy:         tplAll.Lookup("directCopy"),
 		mergeInto:          tplAll.Lookup("mergeInto"),
+		deleteKey:          tplAll.Lookup("deleteKey"),
+		historyClose:       tplAll.Lookup("historyClose"),
+		historyInsert:      tplAll.Lookup("historyInsert"),
 		loadInsert:         tplAll.Lookup("loadInsert"),
 		loadQuery:          tplAll.Lookup("loadQuery"),
 		updateFence:        tplAll.Lookup("updateFence"),
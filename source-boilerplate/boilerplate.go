@@ -89,6 +89,11 @@ func RunMain(connector Connector) {
 		}
 	}()
 
+	// Prometheus metrics are only served when an address is provided for them.
+	if addr := os.Getenv("METRICS_ADDRESS"); addr != "" {
+		go serveMetrics(addr)
+	}
+
 	var server = ConnectorServer{connector}
 
 	if err := server.Capture(stream); err != nil {
@@ -203,6 +208,7 @@ func (out *PullOutput) Documents(binding int, docs ...json.RawMessage) error {
 			return fmt.Errorf("writing captured documents: %w", err)
 		}
 	}
+	observeDocuments(binding, docs)
 	return nil
 }
 
@@ -227,6 +233,7 @@ func (out *PullOutput) Checkpoint(checkpoint json.RawMessage, merge bool) error
 	if err := out.Send(msg); err != nil {
 		return fmt.Errorf("writing checkpoint: %w", err)
 	}
+	observeCheckpoint(checkpoint)
 	return nil
 }
 
@@ -271,6 +278,8 @@ func (out *PullOutput) DocumentsAndCheckpoint(checkpoint json.RawMessage, merge
 	if err := out.Send(cp); err != nil {
 		return fmt.Errorf("writing checkpoint: %w", err)
 	}
+	observeDocuments(binding, docs)
+	observeCheckpoint(checkpoint)
 	return nil
 }
 
@@ -0,0 +1,63 @@
+package boilerplate
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+// Metrics of the documents and checkpoints emitted through a PullOutput. Connectors
+// may register additional metrics of their own with the default Prometheus registry,
+// and they will be served alongside these.
+var (
+	documentsCaptured = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "capture_documents_total",
+		Help: "The number of documents captured, per binding index.",
+	}, []string{"binding"})
+	documentBytesCaptured = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "capture_document_bytes_total",
+		Help: "The total size in bytes of the documents captured, per binding index.",
+	}, []string{"binding"})
+	checkpointsEmitted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "capture_checkpoints_total",
+		Help: "The number of state checkpoints emitted.",
+	})
+	checkpointBytesEmitted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "capture_checkpoint_bytes_total",
+		Help: "The total size in bytes of the state checkpoints emitted.",
+	})
+)
+
+// observeDocuments updates the document metrics of a binding.
+func observeDocuments(binding int, docs []json.RawMessage) {
+	var label = strconv.Itoa(binding)
+	var bytes int
+	for _, doc := range docs {
+		bytes += len(doc)
+	}
+	documentsCaptured.WithLabelValues(label).Add(float64(len(docs)))
+	documentBytesCaptured.WithLabelValues(label).Add(float64(bytes))
+}
+
+// observeCheckpoint updates the checkpoint metrics.
+func observeCheckpoint(checkpoint json.RawMessage) {
+	checkpointsEmitted.Inc()
+	checkpointBytesEmitted.Add(float64(len(checkpoint)))
+}
+
+// serveMetrics serves the Prometheus metrics of the connector at `/metrics` on the
+// provided address. Like the pprof server it's purely diagnostic, so failures are
+// only logged.
+func serveMetrics(addr string) {
+	var mux = http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	log.WithField("addr", addr).Info("starting metrics server")
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.WithField("err", err).Warn("metrics server shut down unexpectedly")
+	}
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/estuary/connectors/sqlcapture"
+	"github.com/go-mysql-org/go-mysql/client"
 	"github.com/go-mysql-org/go-mysql/mysql"
 	"github.com/sirupsen/logrus"
 )
@@ -26,7 +28,97 @@ func (db *mysqlDatabase) WatermarksTable() string {
 	return db.config.Advanced.WatermarksTable
 }
 
-func (db *mysqlDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.DiscoveryInfo, state *sqlcapture.TableState, callback func(event *sqlcapture.ChangeEvent) error) error {
+// EstimatedRowCount returns the storage engine's estimate of the number of rows in a table.
+func (db *mysqlDatabase) EstimatedRowCount(ctx context.Context, info *sqlcapture.DiscoveryInfo) (int, error) {
+	var results, err = db.conn.Execute(`SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?;`, info.Schema, info.Name)
+	if err != nil {
+		return 0, fmt.Errorf("error querying row count estimate: %w", err)
+	}
+	defer results.Close()
+	if len(results.Values) == 0 {
+		return 0, nil
+	}
+	// The estimate is NULL for views and some storage engines.
+	switch estimate := results.Values[0][0].Value().(type) {
+	case uint64:
+		return int(estimate), nil
+	case int64:
+		return int(max(estimate, 0)), nil
+	}
+	return 0, nil
+}
+
+// PositionalWatermarks returns true if the capture is configured to use binlog
+// positions as watermarks instead of writing into the watermarks table.
+func (db *mysqlDatabase) PositionalWatermarks() bool {
+	return db.config.Advanced.ReadOnlyWatermarks
+}
+
+// CurrentPosition returns the current binlog position as a '<logfile>:<position>' cursor.
+func (db *mysqlDatabase) CurrentPosition(ctx context.Context) (string, error) {
+	var results, err = db.conn.Execute("SHOW MASTER STATUS;")
+	if err != nil {
+		return "", fmt.Errorf("error getting latest binlog position: %w", err)
+	}
+	defer results.Close()
+	if len(results.Values) == 0 {
+		return "", fmt.Errorf("failed to query latest binlog position (is binary logging enabled?)")
+	}
+	var row = results.Values[0]
+	return fmt.Sprintf("%s:%d", row[0].AsString(), row[1].AsInt64()), nil
+}
+
+// PositionReached returns true if the cursor is at or past the position. Binlog
+// file names are ordered by their numeric extension.
+func (db *mysqlDatabase) PositionReached(cursor, position string) (bool, error) {
+	parsedCursor, err := splitCursor(cursor)
+	if err != nil {
+		return false, err
+	} else if parsedCursor.GTIDs != nil {
+		return false, nil // A resume cursor set by hand, which will be replaced by the first flush
+	}
+	parsedPosition, err := splitCursor(position)
+	if err != nil {
+		return false, err
+	} else if parsedPosition.GTIDs != nil {
+		return false, fmt.Errorf("position %q isn't a binlog position", position)
+	}
+	var cursorFile, cursorPos = parsedCursor.Position.Name, parsedCursor.Position.Pos
+	var positionFile, positionPos = parsedPosition.Position.Name, parsedPosition.Position.Pos
+	cursorBase, cursorSeq, err := splitBinlogName(cursorFile)
+	if err != nil {
+		return false, err
+	}
+	positionBase, positionSeq, err := splitBinlogName(positionFile)
+	if err != nil {
+		return false, err
+	}
+	if cursorBase != positionBase {
+		return false, fmt.Errorf("binlog %q of cursor doesn't match binlog %q of position", cursorFile, positionFile)
+	}
+	return cursorSeq > positionSeq || (cursorSeq == positionSeq && cursorPos >= positionPos), nil
+}
+
+// splitBinlogName splits a binlog file name like 'mysql-bin.000123' into its
+// base name and sequence number.
+func splitBinlogName(name string) (string, int64, error) {
+	var idx = strings.LastIndex(name, ".")
+	if idx < 0 {
+		return "", 0, fmt.Errorf("binlog name %q has no sequence number", name)
+	}
+	var seq, err = strconv.ParseInt(name[idx+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid sequence number in binlog name %q: %w", name, err)
+	}
+	return name[:idx], seq, nil
+}
+
+// SignalsTable returns the name of the table from which signals are read.
+func (db *mysqlDatabase) SignalsTable() string {
+	return db.config.Advanced.SignalsTable
+}
+
+func (db *mysqlDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.DiscoveryInfo, state *sqlcapture.TableState, filter *sqlcapture.RowFilter, callback func(event *sqlcapture.ChangeEvent) error) error {
 	var keyColumns = state.KeyColumns
 	var resumeAfter = state.Scanned
 	var schema, table = info.Schema, info.Name
@@ -37,6 +129,12 @@ func (db *mysqlDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.Di
 		columnTypes[name] = column.DataType
 	}
 
+	// Only rows matching the binding's row filter and any backfill-specific condition are scanned.
+	var where, err = sqlcapture.BackfillCondition(state, filter, &filterDialect)
+	if err != nil {
+		return err
+	}
+
 	// Compute backfill query and arguments list
 	var query string
 	var args []any
@@ -47,7 +145,7 @@ func (db *mysqlDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.Di
 			"stream": streamID,
 			"offset": state.BackfilledCount,
 		}).Debug("scanning keyless table chunk")
-		query = db.keylessScanQuery(info, schema, table)
+		query = db.keylessScanQuery(info, schema, table, where)
 		args = []any{state.BackfilledCount}
 	case sqlcapture.TableModePreciseBackfill, sqlcapture.TableModeUnfilteredBackfill:
 		var isPrecise = (state.Mode == sqlcapture.TableModePreciseBackfill)
@@ -73,27 +171,33 @@ func (db *mysqlDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.Di
 			for i := range resumeKey {
 				args = append(args, resumeKey[:i+1]...)
 			}
-			query = db.buildScanQuery(false, isPrecise, keyColumns, columnTypes, schema, table)
+			query = db.buildScanQuery(false, isPrecise, keyColumns, columnTypes, schema, table, where)
 		} else {
 			logrus.WithFields(logrus.Fields{
 				"stream":     streamID,
 				"keyColumns": keyColumns,
 			}).Debug("scanning initial table chunk")
-			query = db.buildScanQuery(true, isPrecise, keyColumns, columnTypes, schema, table)
+			query = db.buildScanQuery(true, isPrecise, keyColumns, columnTypes, schema, table, where)
 		}
 	default:
 		return fmt.Errorf("invalid backfill mode %q", state.Mode)
 	}
 
+	conn, err := db.acquireScanConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.releaseScanConn(conn)
+
 	// If this is the first chunk being backfilled, run an `EXPLAIN` on it and log the results
-	db.explainQuery(streamID, query, args)
+	db.explainQuery(conn, streamID, query, args)
 
 	// Execute the backfill query to fetch rows from the database
 	logrus.WithFields(logrus.Fields{"query": query, "args": args}).Debug("executing query")
 
 	// There is no helper function for a streaming select query with arguments,
 	// so we have to drop down a level and prepare the statement ourselves here.
-	var stmt, err = db.conn.Prepare(query)
+	stmt, err := conn.Prepare(query)
 	if err != nil {
 		return fmt.Errorf("error preparing query %q: %w", query, err)
 	}
@@ -161,15 +265,18 @@ var columnBinaryKeyComparison = map[string]bool{
 	"longtext":   true,
 }
 
-func (db *mysqlDatabase) keylessScanQuery(info *sqlcapture.DiscoveryInfo, schemaName, tableName string) string {
+func (db *mysqlDatabase) keylessScanQuery(info *sqlcapture.DiscoveryInfo, schemaName, tableName, where string) string {
 	var query = new(strings.Builder)
 	fmt.Fprintf(query, "SELECT * FROM `%s`.`%s`", schemaName, tableName)
+	if where != "" {
+		fmt.Fprintf(query, " WHERE (%s)", where)
+	}
 	fmt.Fprintf(query, " LIMIT %d", db.config.Advanced.BackfillChunkSize)
 	fmt.Fprintf(query, " OFFSET ?;")
 	return query.String()
 }
 
-func (db *mysqlDatabase) buildScanQuery(start, isPrecise bool, keyColumns []string, columnTypes map[string]interface{}, schemaName, tableName string) string {
+func (db *mysqlDatabase) buildScanQuery(start, isPrecise bool, keyColumns []string, columnTypes map[string]interface{}, schemaName, tableName, where string) string {
 	// Construct lists of key specifiers and placeholders. They will be joined with commas and used in the query itself.
 	var pkey []string
 	for _, colName := range keyColumns {
@@ -187,10 +294,16 @@ func (db *mysqlDatabase) buildScanQuery(start, isPrecise bool, keyColumns []stri
 	var query = new(strings.Builder)
 	fmt.Fprintf(query, "SELECT * FROM `%s`.`%s`", schemaName, tableName)
 
+	// An additional filter condition wraps the key predicate, since the latter
+	// is a disjunction of terms.
+	var filter, filterEnd string
+	if where != "" {
+		filter, filterEnd = fmt.Sprintf("(%s) AND (", where), ")"
+	}
 	if !start {
 		for i := 0; i != len(pkey); i++ {
 			if i == 0 {
-				fmt.Fprintf(query, " WHERE (")
+				fmt.Fprintf(query, " WHERE %s(", filter)
 			} else {
 				fmt.Fprintf(query, ") OR (")
 			}
@@ -200,37 +313,58 @@ func (db *mysqlDatabase) buildScanQuery(start, isPrecise bool, keyColumns []stri
 			}
 			fmt.Fprintf(query, "%s > ?", pkey[i])
 		}
-		fmt.Fprintf(query, ")")
+		fmt.Fprintf(query, ")%s", filterEnd)
+	} else if where != "" {
+		fmt.Fprintf(query, " WHERE (%s)", where)
 	}
 	fmt.Fprintf(query, " ORDER BY %s", strings.Join(pkey, ", "))
 	fmt.Fprintf(query, " LIMIT %d;", db.config.Advanced.BackfillChunkSize)
 	return query.String()
 }
 
+// filterDialect renders row filters as MySQL conditions. Backslashes must be escaped
+// in string literals unless the NO_BACKSLASH_ESCAPES mode is set, and escaping them
+// is harmless when it is. Columns compared with strings are cast to binary strings,
+// since the default collations are case- and accent-insensitive.
+var filterDialect = sqlcapture.FilterDialect{
+	QuoteColumn: quoteColumnName,
+	QuoteString: func(str string) string {
+		return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(str) + "'"
+	},
+	BinaryString: func(column string) string {
+		return "CAST(" + column + " AS BINARY)"
+	},
+	True:  "TRUE",
+	False: "FALSE",
+}
+
 func quoteColumnName(name string) string {
 	// Per https://dev.mysql.com/doc/refman/8.0/en/identifiers.html, the identifier quote character
 	// is the backtick (`). If the identifier itself contains a backtick, it must be doubled.
 	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
 }
 
-func (db *mysqlDatabase) explainQuery(streamID, query string, args []interface{}) {
+func (db *mysqlDatabase) explainQuery(conn *client.Conn, streamID, query string, args []interface{}) {
 	// Only EXPLAIN the backfill query once per connector invocation
+	db.explainMu.Lock()
 	if db.explained == nil {
 		db.explained = make(map[string]struct{})
 	}
 	if _, ok := db.explained[streamID]; ok {
+		db.explainMu.Unlock()
 		return
 	}
 	db.explained[streamID] = struct{}{}
+	db.explainMu.Unlock()
 
 	// Ask the database to analyze the backfill query.
 	// ANALYZE is not universally supported, so try a few forms.
-	var explainResult, err = db.conn.Execute("ANALYZE "+query, args...)
+	var explainResult, err = conn.Execute("ANALYZE "+query, args...)
 	if err != nil {
-		explainResult, err = db.conn.Execute("EXPLAIN ANALYZE "+query, args...)
+		explainResult, err = conn.Execute("EXPLAIN ANALYZE "+query, args...)
 	}
 	if err != nil {
-		explainResult, err = db.conn.Execute("EXPLAIN "+query, args...)
+		explainResult, err = conn.Execute("EXPLAIN "+query, args...)
 	}
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -236,6 +236,10 @@ func (db *mysqlDatabase) translateRecordField(columnType interface{}, val interf
 				return acc, nil
 			case "binary", "varbinary":
 				return val, nil
+			case "inet4", "inet6", "uuid":
+				// MariaDB address and UUID types are received as text from backfill
+				// queries, and replicated values are converted to text by decodeRow.
+				return string(val), nil
 			case "blob", "tinyblob", "mediumblob", "longblob":
 				return val, nil
 			case "time":
@@ -659,4 +663,9 @@ var mysqlTypeToJSON = map[string]columnSchema{
 	"year":      {jsonType: "integer"},
 
 	"json": {},
+
+	// MariaDB-specific types
+	"inet4": {jsonType: "string"},
+	"inet6": {jsonType: "string"},
+	"uuid":  {jsonType: "string", format: "uuid"},
 }
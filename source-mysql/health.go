@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	cerrors "github.com/estuary/connectors/go/connector-errors"
+	"github.com/estuary/connectors/sqlcapture"
+)
+
+// binlogFile is one of the binlog files currently retained by the server.
+type binlogFile struct {
+	Name string
+	Seq  int64
+	Size int64
+}
+
+// ReplicationHealth reports how far the cursor is behind the end of the binlog, and
+// whether the binlog file containing it is at risk of being purged.
+func (db *mysqlDatabase) ReplicationHealth(ctx context.Context, cursor string) (*sqlcapture.ReplicationHealth, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	parsed, err := splitCursor(cursor)
+	if err != nil {
+		return nil, err
+	} else if parsed.GTIDs != nil {
+		return nil, nil // The cursor will be a binlog position after the first flush
+	}
+	var cursorFile, cursorPos = parsed.Position.Name, int64(parsed.Position.Pos)
+	_, cursorSeq, err := splitBinlogName(cursorFile)
+	if err != nil {
+		return nil, err
+	}
+
+	binlogs, err := db.listBinlogs()
+	if err != nil {
+		return nil, err
+	} else if len(binlogs) == 0 {
+		return nil, fmt.Errorf("no binlog files are retained (is binary logging enabled?)")
+	}
+	var oldest, newest = binlogs[0], binlogs[len(binlogs)-1]
+
+	var health = &sqlcapture.ReplicationHealth{
+		Details: map[string]any{
+			"binlogFiles":  len(binlogs),
+			"oldestBinlog": oldest.Name,
+			"newestBinlog": newest.Name,
+		},
+	}
+	if expiry, err := getBinlogExpiry(db.conn); err == nil {
+		health.Details["binlogRetention"] = expiry.String()
+	}
+
+	if cursorSeq < oldest.Seq {
+		var msg = fmt.Sprintf("binlog file %q containing the capture's position has been purged (the oldest retained binlog file is %q), so changes have been lost and the capture must be backfilled again", cursorFile, oldest.Name)
+		if db.config.Advanced.FailOnRetentionLoss {
+			return nil, cerrors.NewUserError(nil, msg)
+		}
+		health.Warnings = append(health.Warnings, msg)
+		return health, nil
+	}
+
+	// The number of bytes of binlog remaining to be read after the cursor.
+	var behindBytes int64
+	for _, binlog := range binlogs {
+		if binlog.Seq == cursorSeq {
+			behindBytes += max(binlog.Size-cursorPos, 0)
+		} else if binlog.Seq > cursorSeq {
+			behindBytes += binlog.Size
+		}
+	}
+	health.Details["behindBytes"] = behindBytes
+
+	// Binlog files are purged oldest first, so once the cursor lies in the oldest
+	// of several retained files it's next in line to be removed.
+	if cursorSeq == oldest.Seq && len(binlogs) > 1 {
+		health.Warnings = append(health.Warnings, fmt.Sprintf("the capture's position lies in the oldest retained binlog file %q, which will be purged when it expires unless the capture catches up", cursorFile))
+	}
+	return health, nil
+}
+
+// listBinlogs returns the binlog files retained by the server, in order.
+func (db *mysqlDatabase) listBinlogs() ([]binlogFile, error) {
+	var results, err = db.conn.Execute("SHOW BINARY LOGS;")
+	if err != nil {
+		return nil, fmt.Errorf("error listing binlog files: %w", err)
+	}
+	defer results.Close()
+
+	var binlogs []binlogFile
+	for _, row := range results.Values {
+		var binlog = binlogFile{Name: string(row[0].AsString())}
+		switch size := row[1].Value().(type) {
+		case uint64:
+			binlog.Size = int64(size)
+		case int64:
+			binlog.Size = size
+		}
+		if _, binlog.Seq, err = splitBinlogName(binlog.Name); err != nil {
+			return nil, err
+		}
+		binlogs = append(binlogs, binlog)
+	}
+	return binlogs, nil
+}
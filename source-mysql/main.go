@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"crypto/tls"
+	_ "embed"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	cerrors "github.com/estuary/connectors/go/connector-errors"
@@ -30,6 +32,11 @@ import (
 	_ "time/tzdata"
 )
 
+// connectorVersion is the version of this connector, as reported in Debezium change event envelopes.
+//
+//go:embed VERSION
+var connectorVersion string
+
 type sshForwarding struct {
 	SSHEndpoint string `json:"sshEndpoint" jsonschema:"title=SSH Endpoint,description=Endpoint of the remote SSH server that supports tunneling (in the form of ssh://user@hostname[:port])" jsonschema_extras:"pattern=^ssh://.+@.+$"`
 	PrivateKey  string `json:"privateKey" jsonschema:"title=SSH Private Key,description=Private key to connect to the remote SSH server." jsonschema_extras:"secret=true,multiline=true"`
@@ -126,10 +133,27 @@ type advancedConfig struct {
 	DBName                   string `json:"dbname,omitempty" jsonschema:"title=Database Name,default=mysql,description=The name of database to connect to. In general this shouldn't matter. The connector can discover and capture from all databases it's authorized to access."`
 	SkipBinlogRetentionCheck bool   `json:"skip_binlog_retention_check,omitempty" jsonschema:"title=Skip Binlog Retention Sanity Check,default=false,description=Bypasses the 'dangerously short binlog retention' sanity check at startup. Only do this if you understand the danger and have a specific need."`
 	NodeID                   uint32 `json:"node_id,omitempty" jsonschema:"title=Node ID,description=Node ID for the capture. Each node in a replication cluster must have a unique 32-bit ID. The specific value doesn't matter so long as it is unique. If unset or zero the connector will pick a value."`
+	ReadOnlyWatermarks       bool   `json:"read_only_watermarks,omitempty" jsonschema:"title=Read-Only Watermarks,description=Use binlog positions read at backfill chunk boundaries as watermarks instead of writing into the watermarks table. This permits capturing without write access to the database and from read replicas which write their own binlog."`
+	SignalsTable             string `json:"signals_table,omitempty" jsonschema:"title=Signals Table,description=The name of an optional table from which backfill signals are read. Must be fully-qualified in '<schema>.<table>' form. Each row inserted into it must have a 'signal' column holding a JSON object with an 'action' of 'backfill' and the fully-qualified 'table' to backfill plus an optional 'where' row filter expression limiting the backfilled rows."`
 	SkipBackfills            string `json:"skip_backfills,omitempty" jsonschema:"title=Skip Backfills,description=A comma-separated list of fully-qualified table names which should not be backfilled."`
 	BackfillChunkSize        int    `json:"backfill_chunk_size,omitempty" jsonschema:"title=Backfill Chunk Size,default=50000,description=The number of rows which should be fetched from the database in a single backfill query."`
+	BackfillConcurrency      int    `json:"backfill_concurrency,omitempty" jsonschema:"title=Backfill Concurrency,default=1,description=The maximum number of tables which may be backfilled at the same time. Each concurrently backfilled table uses its own database connection."`
+	Flavor                   string `json:"flavor,omitempty" jsonschema:"title=Database Flavor,description=Whether the database is MySQL or MariaDB. If unset the flavor will be detected from the database version.,enum=mysql,enum=mariadb"`
+	OnTableDrop              string `json:"on_table_drop,omitempty" jsonschema:"title=Table Drop Handling,default=fail,description=How to handle a captured table being dropped. By default the capture fails with an error. If set to 'ignore' the table stops being captured.,enum=fail,enum=ignore"`
+	DebeziumEnvelope         bool   `json:"debezium_envelope,omitempty" jsonschema:"title=Debezium Envelope,description=Emit the '_meta' property of each captured document as a complete Debezium change event envelope with 'before' and 'after' states and Debezium source properties. Collections must be re-discovered after changing this setting."`
+	TransactionMetadata      bool   `json:"transaction_metadata,omitempty" jsonschema:"title=Transaction Metadata,description=Include the ID and sequence number of the source transaction in the '_meta' property of each replicated change and suggest an additional binding which receives one BEGIN and one END document per transaction with the number of changes to each table. Collections must be re-discovered after changing this setting."`
+	FailOnRetentionLoss      bool   `json:"fail_on_retention_loss,omitempty" jsonschema:"title=Fail on Retention Loss,description=Fail the capture with an error when the periodic replication health check finds that changes were removed from the database before they could be captured. By default this is only logged as a warning."`
+	OnTableRename            string `json:"on_table_rename,omitempty" jsonschema:"title=Table Rename Handling,default=fail,description=How to handle a captured table being renamed. By default the capture fails with an error. If set to 'follow' the table continues to be captured under its new name and a table renamed into the name of a captured table (as online schema migration tools like gh-ost do) is backfilled in its place.,enum=fail,enum=follow"`
 }
 
+// Handling policies for DROP TABLE and RENAME TABLE of captured tables.
+const (
+	tableDropFail     = "fail"
+	tableDropIgnore   = "ignore"
+	tableRenameFail   = "fail"
+	tableRenameFollow = "follow"
+)
+
 // Validate checks that the configuration possesses all required properties.
 func (c *Config) Validate() error {
 	var requiredProperties = [][]string{
@@ -154,6 +178,9 @@ func (c *Config) Validate() error {
 	if c.Advanced.WatermarksTable != "" && !strings.Contains(c.Advanced.WatermarksTable, ".") {
 		return fmt.Errorf("invalid 'watermarksTable' configuration: table name %q must be fully-qualified as \"<schema>.<table>\"", c.Advanced.WatermarksTable)
 	}
+	if c.Advanced.SignalsTable != "" && !strings.Contains(c.Advanced.SignalsTable, ".") {
+		return fmt.Errorf("invalid 'signals_table' configuration: table name %q must be fully-qualified as \"<schema>.<table>\"", c.Advanced.SignalsTable)
+	}
 	if c.Advanced.SkipBackfills != "" {
 		for _, skipStreamID := range strings.Split(c.Advanced.SkipBackfills, ",") {
 			if !strings.Contains(skipStreamID, ".") {
@@ -161,6 +188,18 @@ func (c *Config) Validate() error {
 			}
 		}
 	}
+	if c.Advanced.BackfillConcurrency < 0 {
+		return fmt.Errorf("invalid 'backfill_concurrency' configuration: concurrency %d must not be negative", c.Advanced.BackfillConcurrency)
+	}
+	if c.Advanced.Flavor != "" && c.Advanced.Flavor != mysql.MySQLFlavor && c.Advanced.Flavor != mysql.MariaDBFlavor {
+		return fmt.Errorf("invalid 'flavor' configuration: unknown database flavor %q", c.Advanced.Flavor)
+	}
+	if c.Advanced.OnTableDrop != "" && c.Advanced.OnTableDrop != tableDropFail && c.Advanced.OnTableDrop != tableDropIgnore {
+		return fmt.Errorf("invalid 'on_table_drop' configuration: unknown policy %q", c.Advanced.OnTableDrop)
+	}
+	if c.Advanced.OnTableRename != "" && c.Advanced.OnTableRename != tableRenameFail && c.Advanced.OnTableRename != tableRenameFollow {
+		return fmt.Errorf("invalid 'on_table_rename' configuration: unknown policy %q", c.Advanced.OnTableRename)
+	}
 	return nil
 }
 
@@ -186,6 +225,9 @@ func (c *Config) SetDefaults(name string) {
 	if c.Advanced.BackfillChunkSize <= 0 {
 		c.Advanced.BackfillChunkSize = 50000
 	}
+	if c.Advanced.BackfillConcurrency <= 0 {
+		c.Advanced.BackfillConcurrency = 1
+	}
 
 	// The address config property should accept a host or host:port
 	// value, and if the port is unspecified it should be the MySQL
@@ -210,6 +252,17 @@ type mysqlDatabase struct {
 	explained        map[string]struct{} // Tracks tables which have had an `EXPLAIN` run on them during this connector invocation.
 	datetimeLocation *time.Location      // The location in which to interpret DATETIME column values as timestamps.
 	includeTxIDs     map[string]bool     // Tracks which tables should have XID properties in their replication metadata.
+	flavor           string              // The database flavor, either "mysql" or "mariadb".
+
+	// Connections used for concurrent table scans, which are opened on demand
+	// up to the configured backfill concurrency. The main connection is one of
+	// them, since nothing else uses it while backfill chunks are being scanned.
+	scanConns struct {
+		sync.Mutex
+		idle   chan *client.Conn
+		opened []*client.Conn
+	}
+	explainMu sync.Mutex // Guards `explained` when multiple tables are scanned concurrently
 }
 
 func (db *mysqlDatabase) connect(ctx context.Context) error {
@@ -220,36 +273,12 @@ func (db *mysqlDatabase) connect(ctx context.Context) error {
 		"serverID": db.config.Advanced.NodeID,
 	}).Info("initializing connector")
 
-	var address = db.config.Address
-	// If SSH Tunnel is configured, we are going to create a tunnel from localhost:5432
-	// to address through the bastion server, so we use the tunnel's address
-	if db.config.NetworkTunnel != nil && db.config.NetworkTunnel.SSHForwarding != nil && db.config.NetworkTunnel.SSHForwarding.SSHEndpoint != "" {
-		address = "localhost:3306"
-	}
-
 	// Normal database connection used for table scanning
-	var conn *client.Conn
-	var err error
-	var withTLS = func(c *client.Conn) {
-		// TODO(wgd): Consider adding an optional 'serverName' config parameter which
-		// if set makes this false and sets 'ServerName' so it will be verified properly.
-		c.SetTLSConfig(&tls.Config{InsecureSkipVerify: true})
-	}
-	if conn, err = client.Connect(address, db.config.User, db.config.Password, db.config.Advanced.DBName, withTLS); err == nil {
-		logrus.WithField("addr", address).Debug("connected with TLS")
-		db.conn = conn
-	} else if conn, err = client.Connect(address, db.config.User, db.config.Password, db.config.Advanced.DBName); err == nil {
-		logrus.WithField("addr", address).Warn("connected without TLS")
-		db.conn = conn
-	} else {
-		if err, ok := perrors.Cause(err).(*mysql.MyError); ok {
-			if err.Code == mysql.ER_ACCESS_DENIED_ERROR {
-				return cerrors.NewUserError(err, "incorrect username or password")
-			}
-		}
-
-		return fmt.Errorf("unable to connect to database: %w", err)
+	var conn, err = db.openConnection()
+	if err != nil {
+		return err
 	}
+	db.conn = conn
 
 	if db.config.Timezone != "" {
 		// The user-entered timezone value is verified to parse without error in (*Config).Validate,
@@ -284,14 +313,117 @@ func (db *mysqlDatabase) connect(ctx context.Context) error {
 		}
 	}
 
+	// MySQL and MariaDB have diverged in how binlog replication and GTIDs work, so
+	// we need to know which one we're talking to. An explicitly configured flavor
+	// takes precedence, and if the flavor can't be detected we assume MySQL.
+	db.flavor = db.config.Advanced.Flavor
+	if db.flavor == "" {
+		if db.flavor, err = queryFlavor(conn); err != nil {
+			logrus.WithField("err", err).Warn("unable to determine database flavor, assuming MySQL")
+			db.flavor = mysql.MySQLFlavor
+		}
+	}
+	logrus.WithField("flavor", db.flavor).Info("using database flavor")
+
+	return nil
+}
+
+// openConnection opens a new normal (non-replication) database connection.
+func (db *mysqlDatabase) openConnection() (*client.Conn, error) {
+	var address = db.config.Address
+	// If SSH Tunnel is configured, we are going to create a tunnel from localhost:5432
+	// to address through the bastion server, so we use the tunnel's address
+	if db.config.NetworkTunnel != nil && db.config.NetworkTunnel.SSHForwarding != nil && db.config.NetworkTunnel.SSHForwarding.SSHEndpoint != "" {
+		address = "localhost:3306"
+	}
+
+	var conn *client.Conn
+	var err error
+	var withTLS = func(c *client.Conn) {
+		// TODO(wgd): Consider adding an optional 'serverName' config parameter which
+		// if set makes this false and sets 'ServerName' so it will be verified properly.
+		c.SetTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+	if conn, err = client.Connect(address, db.config.User, db.config.Password, db.config.Advanced.DBName, withTLS); err == nil {
+		logrus.WithField("addr", address).Debug("connected with TLS")
+	} else if conn, err = client.Connect(address, db.config.User, db.config.Password, db.config.Advanced.DBName); err == nil {
+		logrus.WithField("addr", address).Warn("connected without TLS")
+	} else {
+		if err, ok := perrors.Cause(err).(*mysql.MyError); ok {
+			if err.Code == mysql.ER_ACCESS_DENIED_ERROR {
+				return nil, cerrors.NewUserError(err, "incorrect username or password")
+			}
+		}
+
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
 	// Set our desired timezone (specifically for the backfill connection, this has
 	// no effect on the database as a whole) to UTC. This is required for backfills of
 	// TIMESTAMP columns to behave consistently, and has no effect on DATETIME columns.
-	if _, err := db.conn.Execute("SET SESSION time_zone = '+00:00';"); err != nil {
-		return fmt.Errorf("error setting session time_zone: %w", err)
+	if _, err := conn.Execute("SET SESSION time_zone = '+00:00';"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error setting session time_zone: %w", err)
 	}
+	return conn, nil
+}
 
-	return nil
+// acquireScanConn returns a connection on which a table scan may be performed,
+// opening a new one if all of the existing ones are in use and the configured
+// backfill concurrency permits it. The connection must be released afterwards.
+func (db *mysqlDatabase) acquireScanConn(ctx context.Context) (*client.Conn, error) {
+	if db.config.Advanced.BackfillConcurrency <= 1 {
+		return db.conn, nil
+	}
+
+	db.scanConns.Lock()
+	if db.scanConns.idle == nil {
+		db.scanConns.idle = make(chan *client.Conn, db.config.Advanced.BackfillConcurrency)
+		db.scanConns.idle <- db.conn
+	}
+	select {
+	case conn := <-db.scanConns.idle:
+		db.scanConns.Unlock()
+		return conn, nil
+	default:
+	}
+	if len(db.scanConns.opened)+1 < db.config.Advanced.BackfillConcurrency {
+		defer db.scanConns.Unlock()
+		logrus.WithField("count", len(db.scanConns.opened)+2).Debug("opening additional backfill connection")
+		var conn, err = db.openConnection()
+		if err != nil {
+			return nil, fmt.Errorf("error opening backfill connection: %w", err)
+		}
+		db.scanConns.opened = append(db.scanConns.opened, conn)
+		return conn, nil
+	}
+	db.scanConns.Unlock()
+
+	select {
+	case conn := <-db.scanConns.idle:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releaseScanConn returns a connection obtained from acquireScanConn.
+func (db *mysqlDatabase) releaseScanConn(conn *client.Conn) {
+	if db.config.Advanced.BackfillConcurrency <= 1 {
+		return
+	}
+	db.scanConns.idle <- conn
+}
+
+// BackfillConcurrency returns the configured number of tables which may be backfilled at once.
+func (db *mysqlDatabase) BackfillConcurrency() int {
+	return db.config.Advanced.BackfillConcurrency
+}
+
+// BackfillKeyRanges returns no boundaries, since splitting backfills into key
+// ranges isn't supported and tables are always scanned sequentially.
+func (db *mysqlDatabase) BackfillKeyRanges(ctx context.Context, info *sqlcapture.DiscoveryInfo, keyColumns []string) ([][]byte, error) {
+	return nil, nil
 }
 
 func queryTimeZone(conn *client.Conn) (string, error) {
@@ -307,6 +439,18 @@ func queryTimeZone(conn *client.Conn) (string, error) {
 	return tzName, nil
 }
 
+// queryFlavor determines from the database version whether it's MySQL or MariaDB.
+func queryFlavor(conn *client.Conn) (string, error) {
+	var version, err = queryStringVariable(conn, `SELECT @@GLOBAL.version;`)
+	if err != nil {
+		return "", fmt.Errorf("error querying 'version' system variable: %w", err)
+	}
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return mysql.MariaDBFlavor, nil
+	}
+	return mysql.MySQLFlavor, nil
+}
+
 func queryStringVariable(conn *client.Conn, query string) (string, error) {
 	var results, err = conn.Execute(query)
 	if err != nil {
@@ -323,6 +467,11 @@ func queryStringVariable(conn *client.Conn, query string) (string, error) {
 }
 
 func (db *mysqlDatabase) Close(ctx context.Context) error {
+	for _, conn := range db.scanConns.opened {
+		if err := conn.Close(); err != nil {
+			return fmt.Errorf("error closing backfill connection: %w", err)
+		}
+	}
 	if err := db.conn.Close(); err != nil {
 		return fmt.Errorf("error closing database connection: %w", err)
 	}
@@ -333,6 +482,22 @@ func (db *mysqlDatabase) EmptySourceMetadata() sqlcapture.SourceMetadata {
 	return &mysqlSourceInfo{}
 }
 
+func (db *mysqlDatabase) DebeziumInfo() *sqlcapture.DebeziumInfo {
+	if !db.config.Advanced.DebeziumEnvelope {
+		return nil
+	}
+	// The Debezium 'db' property of MySQL events is the schema, which the source
+	// metadata provides.
+	return &sqlcapture.DebeziumInfo{
+		Connector: "mysql",
+		Version:   strings.TrimSpace(connectorVersion),
+	}
+}
+
+func (db *mysqlDatabase) TransactionMetadata() bool {
+	return db.config.Advanced.TransactionMetadata
+}
+
 func (db *mysqlDatabase) FallbackCollectionKey() []string {
 	return []string{"/_meta/source/cursor"}
 }
@@ -386,8 +551,35 @@ type mysqlSourceInfo struct {
 	sqlcapture.SourceCommon
 	EventCursor string `json:"cursor" jsonschema:"description=Cursor value representing the current position in the binlog."`
 	TxID        string `json:"txid,omitempty" jsonschema:"description=The global transaction identifier associated with a change by MySQL. Only set if GTIDs are enabled."`
+
+	transaction string // Identifier of the transaction, which is tracked for every change regardless of TxID.
 }
 
 func (s *mysqlSourceInfo) Common() sqlcapture.SourceCommon {
 	return s.SourceCommon
 }
+
+// TransactionID identifies the transaction of a replicated change by its GTID, or by
+// the binlog position at which it began if GTIDs aren't enabled.
+func (s *mysqlSourceInfo) TransactionID() string {
+	return s.transaction
+}
+
+// DebeziumSource returns the MySQL-specific properties of a Debezium source block.
+func (s *mysqlSourceInfo) DebeziumSource() map[string]any {
+	var props = map[string]any{"db": s.Schema}
+	// Replication event cursors are formatted as "<binlog file>:<position>:<row index>".
+	if parts := strings.Split(s.EventCursor, ":"); len(parts) == 3 {
+		props["file"] = parts[0]
+		if pos, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+			props["pos"] = pos
+		}
+		if row, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+			props["row"] = row
+		}
+	}
+	if s.TxID != "" {
+		props["gtid"] = s.TxID
+	}
+	return props
+}
@@ -23,6 +23,7 @@ func (db *mysqlDatabase) SetupPrerequisites(ctx context.Context) []error {
 	}
 
 	for _, prereq := range []func(ctx context.Context) error{
+		db.prerequisiteFlavor,
 		db.prerequisiteBinlogEnabled,
 		db.prerequisiteBinlogFormat,
 		db.prerequisiteBinlogExpiry,
@@ -105,6 +106,23 @@ func (db *mysqlDatabase) prerequisiteVersion(ctx context.Context) error {
 	return nil
 }
 
+func (db *mysqlDatabase) prerequisiteFlavor(ctx context.Context) error {
+	// An autodetected flavor is correct by definition, but an explicitly configured
+	// one could be wrong and would cause confusing replication errors later on.
+	if db.config.Advanced.Flavor == "" {
+		return nil
+	}
+	var detected, err = queryFlavor(db.conn)
+	if err != nil {
+		logrus.WithField("err", err).Warn("unable to verify configured database flavor")
+		return nil
+	}
+	if detected != db.config.Advanced.Flavor {
+		return fmt.Errorf("database flavor is configured as %q but the database appears to be %q", db.config.Advanced.Flavor, detected)
+	}
+	return nil
+}
+
 func (db *mysqlDatabase) prerequisiteBinlogEnabled(ctx context.Context) error {
 	var results, err = db.conn.Execute(`SHOW VARIABLES LIKE 'log_bin';`)
 	if err != nil {
@@ -156,6 +174,9 @@ func (db *mysqlDatabase) prerequisiteBinlogExpiry(ctx context.Context) error {
 }
 
 func (db *mysqlDatabase) prerequisiteWatermarksTable(ctx context.Context) error {
+	if db.config.Advanced.ReadOnlyWatermarks {
+		return nil // The watermarks table isn't used at all
+	}
 	var table = db.config.Advanced.WatermarksTable
 	var logEntry = logrus.WithField("table", table)
 
@@ -226,7 +247,7 @@ func (db *mysqlDatabase) prerequisiteUserPermissions(ctx context.Context) error
 		// The use of strings.Contains here could result in false negatives, but this is
 		// much less of a concern than potential false positives and the extra code needed
 		// to parse this column value into an actual list of schema names.
-		if doDB, ok := row["Binlog_Do_DB"].(string); ok && doDB != "" && !strings.Contains(doDB, watermarksSchema) {
+		if doDB, ok := row["Binlog_Do_DB"].(string); ok && doDB != "" && !db.config.Advanced.ReadOnlyWatermarks && !strings.Contains(doDB, watermarksSchema) {
 			return fmt.Errorf("binlog-do-db is set to %q, which doesn't include the watermark table schema %q", doDB, watermarksSchema)
 		}
 	}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"regexp"
 	"slices"
@@ -43,13 +44,15 @@ func (db *mysqlDatabase) ReplicationStream(ctx context.Context, startCursor stri
 	}
 
 	var pos mysql.Position
+	var gtids mysql.GTIDSet
 	if startCursor != "" {
-		var binlogName, binlogPos, err = splitCursor(startCursor)
+		var cursor, err = splitCursor(startCursor)
 		if err != nil {
 			return nil, fmt.Errorf("invalid resume cursor: %w", err)
+		} else if cursor.GTIDs != nil && db.flavor != mysql.MariaDBFlavor {
+			return nil, fmt.Errorf("invalid resume cursor %q: GTID cursors are only supported for MariaDB", startCursor)
 		}
-		pos.Name = binlogName
-		pos.Pos = uint32(binlogPos)
+		pos, gtids = cursor.Position, cursor.GTIDs
 	} else {
 		// Get the initial binlog cursor from the source database's latest binlog position
 		var results, err = db.conn.Execute("SHOW MASTER STATUS;")
@@ -67,7 +70,7 @@ func (db *mysqlDatabase) ReplicationStream(ctx context.Context, startCursor stri
 
 	var syncConfig = replication.BinlogSyncerConfig{
 		ServerID: uint32(db.config.Advanced.NodeID),
-		Flavor:   "mysql", // TODO(wgd): See what happens if we change this and run against MariaDB?
+		Flavor:   db.flavor,
 		Host:     host,
 		Port:     uint16(port),
 		User:     db.config.User,
@@ -88,16 +91,25 @@ func (db *mysqlDatabase) ReplicationStream(ctx context.Context, startCursor stri
 		ReadTimeout:     5 * time.Minute,
 	}
 
-	logrus.WithFields(logrus.Fields{"pos": pos}).Info("starting replication")
+	// When starting from a GTID position the binlog filename will be filled in
+	// by the initial rotate event which the server sends.
+	var startSync = func(syncer *replication.BinlogSyncer) (*replication.BinlogStreamer, error) {
+		if gtids != nil {
+			return syncer.StartSyncGTID(gtids)
+		}
+		return syncer.StartSync(pos)
+	}
+
+	logrus.WithFields(logrus.Fields{"pos": pos, "gtids": gtids}).Info("starting replication")
 	var streamer *replication.BinlogStreamer
 	var syncer = replication.NewBinlogSyncer(syncConfig)
-	if streamer, err = syncer.StartSync(pos); err == nil {
+	if streamer, err = startSync(syncer); err == nil {
 		logrus.Debug("replication connected with TLS")
 	} else {
 		syncer.Close()
 		syncConfig.TLSConfig = nil
 		syncer = replication.NewBinlogSyncer(syncConfig)
-		if streamer, err = syncer.StartSync(pos); err == nil {
+		if streamer, err = startSync(syncer); err == nil {
 			logrus.Warn("replication connected without TLS")
 		} else {
 			return nil, fmt.Errorf("error starting binlog sync: %w", err)
@@ -117,16 +129,33 @@ func (db *mysqlDatabase) ReplicationStream(ctx context.Context, startCursor stri
 	return stream, nil
 }
 
-func splitCursor(cursor string) (string, int64, error) {
+// binlogCursor is a parsed resume cursor, which is either a "<logfile>:<position>"
+// binlog position or a MariaDB GTID position like "0-1-100" or "0-1-100,1-2-57".
+// A GTID cursor may be set by hand to resume a MariaDB capture from the equivalent
+// position on another server of a replication cluster, where the binlog filenames
+// and offsets differ. It's replaced by a binlog position at the first flush.
+type binlogCursor struct {
+	Position mysql.Position
+	GTIDs    mysql.GTIDSet // Non-nil if the cursor is a MariaDB GTID position
+}
+
+func splitCursor(cursor string) (binlogCursor, error) {
+	if !strings.Contains(cursor, ":") {
+		gtids, err := mysql.ParseMariadbGTIDSet(cursor)
+		if err != nil {
+			return binlogCursor{}, fmt.Errorf("input %q must have <logfile>:<position> or MariaDB GTID shape: %w", cursor, err)
+		}
+		return binlogCursor{GTIDs: gtids}, nil
+	}
 	seps := strings.Split(cursor, ":")
 	if len(seps) != 2 {
-		return "", 0, fmt.Errorf("input %q must have <logfile>:<position> shape", cursor)
+		return binlogCursor{}, fmt.Errorf("input %q must have <logfile>:<position> shape", cursor)
 	}
-	position, err := strconv.ParseInt(seps[1], 10, 64)
+	position, err := strconv.ParseUint(seps[1], 10, 32)
 	if err != nil {
-		return "", 0, fmt.Errorf("invalid position value %q: %w", seps[1], err)
+		return binlogCursor{}, fmt.Errorf("invalid position value %q: %w", seps[1], err)
 	}
-	return seps[0], position, nil
+	return binlogCursor{Position: mysql.Position{Name: seps[0], Pos: uint32(position)}}, nil
 }
 
 func splitHostPort(addr string) (string, int64, error) {
@@ -153,6 +182,7 @@ type mysqlReplicationStream struct {
 
 	gtidTimestamp time.Time // The OriginalCommitTimestamp value of the last GTID Event
 	gtidString    string    // The GTID value of the last GTID event, formatted as a "<uuid>:<counter>" string.
+	txnPosition   string    // The binlog position at which the current transaction began, formatted as "<file>:<pos>".
 
 	// The active tables set and associated metadata, guarded by a
 	// mutex so it can be modified from the main goroutine while it's
@@ -273,7 +303,7 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 			switch event.Header.EventType {
 			case replication.WRITE_ROWS_EVENTv1, replication.WRITE_ROWS_EVENTv2:
 				for rowIdx, row := range data.Rows {
-					var after, err = decodeRow(streamID, columnNames, row)
+					var after, err = decodeRow(streamID, columnNames, columnTypes, row)
 					if err != nil {
 						return fmt.Errorf("error decoding row values: %w", err)
 					}
@@ -287,6 +317,7 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 					var sourceInfo = &mysqlSourceInfo{
 						SourceCommon: sourceCommon,
 						EventCursor:  fmt.Sprintf("%s:%d:%d", rs.cursor.Name, rs.cursor.Pos, rowIdx),
+						transaction:  rs.transactionID(),
 					}
 					if rs.db.includeTxIDs[streamID] {
 						sourceInfo.TxID = rs.gtidString
@@ -304,11 +335,11 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 				for rowIdx := range data.Rows {
 					// Update events contain alternating (before, after) pairs of rows
 					if rowIdx%2 == 1 {
-						before, err := decodeRow(streamID, columnNames, data.Rows[rowIdx-1])
+						before, err := decodeRow(streamID, columnNames, columnTypes, data.Rows[rowIdx-1])
 						if err != nil {
 							return fmt.Errorf("error decoding row values: %w", err)
 						}
-						after, err := decodeRow(streamID, columnNames, data.Rows[rowIdx])
+						after, err := decodeRow(streamID, columnNames, columnTypes, data.Rows[rowIdx])
 						if err != nil {
 							return fmt.Errorf("error decoding row values: %w", err)
 						}
@@ -325,6 +356,7 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 						var sourceInfo = &mysqlSourceInfo{
 							SourceCommon: sourceCommon,
 							EventCursor:  fmt.Sprintf("%s:%d:%d", rs.cursor.Name, rs.cursor.Pos, rowIdx/2),
+							transaction:  rs.transactionID(),
 						}
 						if rs.db.includeTxIDs[streamID] {
 							sourceInfo.TxID = rs.gtidString
@@ -342,7 +374,7 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 				}
 			case replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2:
 				for rowIdx, row := range data.Rows {
-					var before, err = decodeRow(streamID, columnNames, row)
+					var before, err = decodeRow(streamID, columnNames, columnTypes, row)
 					if err != nil {
 						return fmt.Errorf("error decoding row values: %w", err)
 					}
@@ -357,6 +389,7 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 						SourceCommon: sourceCommon,
 						EventCursor:  fmt.Sprintf("%s:%d:%d", rs.cursor.Name, rs.cursor.Pos, rowIdx),
 						TxID:         rs.gtidString,
+						transaction:  rs.transactionID(),
 					}
 					if rs.db.includeTxIDs[streamID] {
 						sourceInfo.TxID = rs.gtidString
@@ -391,6 +424,7 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 
 			if len(data.SID) != 16 || (data.GNO == 0 && bytes.Equal(data.SID, []byte("\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"))) {
 				rs.gtidString = ""
+				rs.txnPosition = fmt.Sprintf("%s:%d", rs.cursor.Name, rs.cursor.Pos)
 			} else {
 				var sourceUUID, err = uuid.FromBytes(data.SID)
 				if err != nil {
@@ -400,7 +434,22 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 			}
 		case *replication.PreviousGTIDsEvent:
 			logrus.WithField("gtids", data.GTIDSets).Trace("PreviousGTIDs Event")
+		case *replication.MariadbGTIDEvent:
+			logrus.WithField("data", data).Trace("MariaDB GTID Event")
+			// MariaDB GTID events don't include a commit timestamp, so the event
+			// timestamp (with only one-second precision) is the best we can do.
+			rs.gtidTimestamp = time.Unix(int64(event.Header.Timestamp), 0)
+			rs.gtidString = data.GTID.String() // Formatted as "<domain>-<server>-<sequence>"
+		case *replication.MariadbGTIDListEvent:
+			logrus.WithField("gtids", data.GTIDs).Trace("MariaDB GTID List Event")
+		case *replication.MariadbAnnotateRowsEvent:
+			logrus.WithField("query", string(data.Query)).Trace("MariaDB Annotate Rows Event")
+		case *replication.MariadbBinlogCheckPointEvent:
+			logrus.WithField("info", string(data.Info)).Trace("MariaDB Binlog Checkpoint Event")
 		case *replication.QueryEvent:
+			if strings.EqualFold(string(data.Query), "BEGIN") {
+				rs.txnPosition = fmt.Sprintf("%s:%d", rs.cursor.Name, rs.cursor.Pos)
+			}
 			if err := rs.handleQuery(ctx, string(data.Schema), string(data.Query)); err != nil {
 				return fmt.Errorf("error processing query event: %w", err)
 			}
@@ -416,6 +465,16 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 		case *replication.GenericEvent:
 			if event.Header.EventType == replication.HEARTBEAT_EVENT {
 				logrus.Debug("received server heartbeat")
+				// Heartbeats are only sent when the server has no further events to send,
+				// so the current position is between transactions and may be flushed. This
+				// is what allows positional watermarks to be reached on an idle database.
+				if rs.db.config.Advanced.ReadOnlyWatermarks {
+					if err := rs.emitEvent(ctx, &sqlcapture.FlushEvent{
+						Cursor: fmt.Sprintf("%s:%d", rs.cursor.Name, rs.cursor.Pos),
+					}); err != nil {
+						return err
+					}
+				}
 			} else {
 				logrus.WithField("event", event.Header.EventType.String()).Debug("Generic Event")
 			}
@@ -427,7 +486,7 @@ func (rs *mysqlReplicationStream) run(ctx context.Context) error {
 	}
 }
 
-func decodeRow(streamID string, colNames []string, row []interface{}) (map[string]interface{}, error) {
+func decodeRow(streamID string, colNames []string, columnTypes map[string]interface{}, row []interface{}) (map[string]interface{}, error) {
 	// If we have more or fewer values than expected, something has gone wrong
 	// with our metadata tracking and it's best to die immediately. The fix in
 	// this case is almost always going to be deleting and recreating the
@@ -441,11 +500,82 @@ func decodeRow(streamID string, colNames []string, row []interface{}) (map[strin
 
 	var fields = make(map[string]interface{})
 	for idx, val := range row {
-		fields[colNames[idx]] = val
+		var name = colNames[idx]
+		if typeName, ok := columnTypes[name].(string); ok && val != nil {
+			var err error
+			switch typeName {
+			case "inet4", "inet6":
+				val, err = decodeBinaryAddress(typeName, val)
+			case "uuid":
+				val, err = decodeBinaryUUID(val)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error decoding column %q of stream %q: %w", name, streamID, err)
+			}
+		}
+		fields[name] = val
 	}
 	return fields, nil
 }
 
+// decodeBinaryAddress converts a replicated MariaDB INET4 or INET6 value, which
+// the binlog holds as the binary address in network byte order, into the text
+// form in which the same value is received from backfill queries.
+func decodeBinaryAddress(typeName string, val interface{}) (string, error) {
+	var bs, err = binaryValue(typeName, val)
+	if err != nil {
+		return "", err
+	}
+	var addr = net.IP(bs)
+	switch {
+	case typeName == "inet4" && len(bs) == net.IPv4len:
+		return addr.String(), nil
+	case typeName == "inet6" && len(bs) == net.IPv6len:
+		if addr.To4() != nil {
+			// Go formats IPv4-mapped addresses in dotted-quad form, but MariaDB retains the prefix.
+			return "::ffff:" + addr.To4().String(), nil
+		}
+		return addr.String(), nil
+	}
+	return "", fmt.Errorf("invalid binary %s value of length %d", typeName, len(bs))
+}
+
+// decodeBinaryUUID converts a replicated MariaDB UUID value into the text form in
+// which the same value is received from backfill queries. The binlog holds the value
+// in MariaDB's storage format, which reverses the order of the groups of an RFC 4122
+// UUID of versions 1 to 5 so that time-based UUIDs are ordered by time, and leaves
+// other UUIDs as-is.
+func decodeBinaryUUID(val interface{}) (string, error) {
+	var bs, err = binaryValue("uuid", val)
+	if err != nil {
+		return "", err
+	} else if len(bs) != 16 {
+		return "", fmt.Errorf("invalid binary uuid value of length %d", len(bs))
+	}
+	var id uuid.UUID
+	if bs[8] > 0 && bs[8] < 0x60 && bs[6]&0x80 != 0 {
+		copy(id[0:4], bs[12:16])
+		copy(id[4:6], bs[10:12])
+		copy(id[6:8], bs[8:10])
+		copy(id[8:10], bs[6:8])
+		copy(id[10:16], bs[0:6])
+	} else {
+		copy(id[:], bs)
+	}
+	return id.String(), nil
+}
+
+func binaryValue(typeName string, val interface{}) ([]byte, error) {
+	switch val := val.(type) {
+	case string:
+		return []byte(val), nil
+	case []byte:
+		return val, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s value of type %T", typeName, val)
+	}
+}
+
 // Query Events in the MySQL binlog are normalized enough that we can use
 // prefix matching to detect many types of query that we just completely
 // don't care about. This is good, because the Vitess SQL parser disagrees
@@ -512,33 +642,60 @@ func (rs *mysqlReplicationStream) handleQuery(ctx context.Context, schema, query
 			}
 		}
 	case *sqlparser.DropTable:
+		var dropped []string
 		for _, table := range stmt.FromTables {
 			if streamID := resolveTableName(schema, table); rs.tableActive(streamID) {
-				return fmt.Errorf("unsupported operation (go.estuary.dev/eVVwet): %s", query)
+				if rs.db.config.Advanced.OnTableDrop != tableDropIgnore {
+					return fmt.Errorf("unsupported operation (go.estuary.dev/eVVwet): %s", query)
+				}
+				dropped = append(dropped, streamID)
 			}
 		}
+		if err := rs.handleDropTables(ctx, dropped); err != nil {
+			return err
+		}
 	case *sqlparser.TruncateTable:
 		if streamID := resolveTableName(schema, stmt.Table); rs.tableActive(streamID) {
-			logrus.WithField("table", streamID).Warn("ignoring TRUNCATE on active table")
-		}
-	case *sqlparser.RenameTable:
-		for _, pair := range stmt.TablePairs {
-			if streamID := resolveTableName(schema, pair.FromTable); rs.tableActive(streamID) {
-				return fmt.Errorf("operation conflicts with %s (go.estuary.dev/eVVwet): %s", streamID, query)
+			// How the TRUNCATE should be handled is a per-binding decision which
+			// is made by the generic sqlcapture logic.
+			logrus.WithField("table", streamID).Info("observed TRUNCATE on active table")
+			var tableSchema = stmt.Table.Qualifier.String()
+			if tableSchema == "" {
+				tableSchema = schema
 			}
-			if streamID := resolveTableName(schema, pair.ToTable); rs.tableActive(streamID) {
-				return fmt.Errorf("operation conflicts with %s (go.estuary.dev/eVVwet): %s", streamID, query)
+			var sourceCommon = sqlcapture.SourceCommon{
+				Schema: tableSchema,
+				Table:  stmt.Table.Name.String(),
+			}
+			if !rs.gtidTimestamp.IsZero() {
+				sourceCommon.Millis = rs.gtidTimestamp.UnixMilli()
+			}
+			var sourceInfo = &mysqlSourceInfo{
+				SourceCommon: sourceCommon,
+				EventCursor:  fmt.Sprintf("%s:%d:%d", rs.cursor.Name, rs.cursor.Pos, 0),
+				transaction:  rs.transactionID(),
+			}
+			if rs.db.includeTxIDs[streamID] {
+				sourceInfo.TxID = rs.gtidString
+			}
+			if err := rs.emitEvent(ctx, &sqlcapture.TruncateEvent{Source: sourceInfo}); err != nil {
+				return err
 			}
 		}
+	case *sqlparser.RenameTable:
+		if err := rs.handleRenameTable(ctx, stmt, query, schema); err != nil {
+			return err
+		}
 	case *sqlparser.Insert:
 		if streamID := resolveTableName(schema, stmt.Table); rs.tableActive(streamID) {
 			return fmt.Errorf("unsupported DML query (go.estuary.dev/IK5EVx): %s", query)
 		}
 	case *sqlparser.Update:
-		// TODO(wgd): It would be nice to only halt on UPDATE statements impacting
-		// active tables. Unfortunately UPDATE queries are complicated and it's not
-		// as simple to implement that check as for INSERT and DELETE.
-		return fmt.Errorf("unsupported DML query (go.estuary.dev/IK5EVx): %s", query)
+		for _, streamID := range updatedTables(schema, stmt) {
+			if rs.tableActive(streamID) {
+				return fmt.Errorf("unsupported DML query (go.estuary.dev/IK5EVx): %s", query)
+			}
+		}
 	case *sqlparser.Delete:
 		for _, target := range stmt.Targets {
 			if streamID := resolveTableName(schema, target); rs.tableActive(streamID) {
@@ -554,6 +711,169 @@ func (rs *mysqlReplicationStream) handleQuery(ctx context.Context, schema, query
 	return nil
 }
 
+// handleDropTables stops capturing the dropped tables and informs the generic
+// sqlcapture logic that their bindings should be ignored from now on.
+func (rs *mysqlReplicationStream) handleDropTables(ctx context.Context, streamIDs []string) error {
+	if len(streamIDs) == 0 {
+		return nil
+	}
+	rs.tables.Lock()
+	for _, streamID := range streamIDs {
+		logrus.WithField("table", streamID).Warn("observed DROP TABLE on active table")
+		delete(rs.tables.active, streamID)
+		delete(rs.tables.keyColumns, streamID)
+		delete(rs.tables.metadata, streamID)
+	}
+	rs.tables.Unlock()
+
+	for _, streamID := range streamIDs {
+		if err := rs.emitEvent(ctx, &sqlcapture.TableDropEvent{StreamID: streamID}); err != nil {
+			return err
+		}
+	}
+	return rs.emitDDLFlush(ctx)
+}
+
+// handleRenameTable applies the configured rename handling to a RENAME TABLE
+// statement. Active tables which are renamed are followed to their new names,
+// unless another table is renamed into the name of an active table, in which
+// case that table is captured in its place and backfilled anew. This is how
+// online schema migration tools like gh-ost swap the migrated copy of a table
+// into place.
+func (rs *mysqlReplicationStream) handleRenameTable(ctx context.Context, stmt *sqlparser.RenameTable, query, schema string) error {
+	if rs.db.config.Advanced.OnTableRename != tableRenameFollow {
+		for _, pair := range stmt.TablePairs {
+			if streamID := resolveTableName(schema, pair.FromTable); rs.tableActive(streamID) {
+				return fmt.Errorf("operation conflicts with %s (go.estuary.dev/eVVwet): %s", streamID, query)
+			}
+			if streamID := resolveTableName(schema, pair.ToTable); rs.tableActive(streamID) {
+				return fmt.Errorf("operation conflicts with %s (go.estuary.dev/eVVwet): %s", streamID, query)
+			}
+		}
+		return nil
+	}
+
+	// The table pairs are applied in order, so a single statement may move a
+	// table more than once. Work out where each renamed table ends up.
+	var originals = make(map[string]string) // Map from the final stream ID of each renamed table to its original stream ID
+	var newNames = make(map[string]sqlparser.TableName)
+	for _, pair := range stmt.TablePairs {
+		var from, to = resolveTableName(schema, pair.FromTable), resolveTableName(schema, pair.ToTable)
+		var original, ok = originals[from]
+		if !ok {
+			original = from
+		}
+		delete(originals, from)
+		originals[to] = original
+		newNames[to] = pair.ToTable
+	}
+
+	// An active table whose name is taken over by a table we weren't capturing is
+	// replaced, and any other active table which was renamed is followed. We can't
+	// tell which binding should follow a table renamed onto the name of another
+	// active table, so that's an error.
+	var renamed, replaced []string
+	for streamID, original := range originals {
+		if streamID == original {
+			continue
+		} else if rs.tableActive(streamID) && rs.tableActive(original) {
+			return fmt.Errorf("operation conflicts with %s (go.estuary.dev/eVVwet): %s", streamID, query)
+		} else if rs.tableActive(streamID) {
+			replaced = append(replaced, streamID)
+		}
+	}
+	for streamID, original := range originals {
+		if streamID != original && rs.tableActive(original) && !slices.Contains(replaced, original) {
+			renamed = append(renamed, streamID)
+		}
+	}
+	if len(renamed) == 0 && len(replaced) == 0 {
+		return nil
+	}
+	slices.Sort(renamed)
+	slices.Sort(replaced)
+
+	for _, streamID := range renamed {
+		var original = originals[streamID]
+		var newSchema, newTable = newNames[streamID].Qualifier.String(), newNames[streamID].Name.String()
+		if newSchema == "" {
+			newSchema = schema
+		}
+		logrus.WithFields(logrus.Fields{"table": original, "renamedTo": streamID}).Info("following renamed table")
+
+		rs.tables.Lock()
+		rs.tables.active[streamID] = rs.tables.active[original]
+		rs.tables.keyColumns[streamID] = rs.tables.keyColumns[original]
+		rs.tables.metadata[streamID] = rs.tables.metadata[original]
+		delete(rs.tables.active, original)
+		delete(rs.tables.keyColumns, original)
+		delete(rs.tables.metadata, original)
+		rs.tables.Unlock()
+		if rs.db.includeTxIDs[original] {
+			rs.db.includeTxIDs[streamID] = true
+		}
+
+		if err := rs.emitEvent(ctx, &sqlcapture.TableRenameEvent{
+			StreamID:  original,
+			NewSchema: newSchema,
+			NewTable:  newTable,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(replaced) > 0 {
+		// We have no record of the structure of a table which wasn't being captured,
+		// so the replacement tables are discovered using a separate connection (since
+		// the main one may be in use for backfills). This reflects the current schema
+		// of the table, which could be wrong if the table has been altered since the
+		// rename, but the same is true when initializing metadata for any new table.
+		var tables, err = rs.discoverTables(ctx)
+		if err != nil {
+			return fmt.Errorf("error discovering replacement tables: %w", err)
+		}
+		for _, streamID := range replaced {
+			var discovery = tables[streamID]
+			if discovery == nil {
+				return fmt.Errorf("table %q was replaced by %q, which no longer exists", streamID, originals[streamID])
+			}
+			logrus.WithFields(logrus.Fields{"table": streamID, "replacedBy": originals[streamID]}).Info("active table replaced by rename")
+
+			rs.tables.Lock()
+			rs.tables.metadata[streamID] = initTableMetadata(streamID, discovery)
+			rs.tables.dirtyMetadata = append(rs.tables.dirtyMetadata, streamID)
+			rs.tables.Unlock()
+
+			if err := rs.emitEvent(ctx, &sqlcapture.TableReplaceEvent{
+				StreamID:  streamID,
+				Discovery: discovery,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return rs.emitDDLFlush(ctx)
+}
+
+// discoverTables runs table discovery over a new database connection.
+func (rs *mysqlReplicationStream) discoverTables(ctx context.Context) (map[string]*sqlcapture.DiscoveryInfo, error) {
+	var db = &mysqlDatabase{config: rs.db.config}
+	if err := db.connect(ctx); err != nil {
+		return nil, err
+	}
+	defer db.Close(ctx)
+	return db.DiscoverTables(ctx)
+}
+
+// emitDDLFlush emits a FlushEvent after a DDL statement which changed the set of
+// captured tables, so that the resulting state changes are checkpointed along
+// with a cursor which won't replay the statement.
+func (rs *mysqlReplicationStream) emitDDLFlush(ctx context.Context) error {
+	return rs.emitEvent(ctx, &sqlcapture.FlushEvent{
+		Cursor: fmt.Sprintf("%s:%d", rs.cursor.Name, rs.cursor.Pos),
+	})
+}
+
 func (rs *mysqlReplicationStream) handleAlterTable(ctx context.Context, stmt *sqlparser.AlterTable, query string, streamID string) error {
 	// This lock and assignment to `meta` isn't actually needed unless we are able to handle the
 	// alteration. But if we can't handle the alteration the connector is probably going to crash,
@@ -726,6 +1046,61 @@ func resolveTableName(defaultSchema string, name sqlparser.TableName) string {
 	return sqlcapture.JoinStreamID(schema, table)
 }
 
+// updatedTables returns the stream IDs of the tables which may be modified by an
+// UPDATE statement. A multiple-table UPDATE may reference tables which are only read
+// from, so when the columns of the SET clause are qualified with a table name or alias
+// only the tables so named are returned. Otherwise every table referenced by the table
+// expressions of the statement is conservatively assumed to be modified.
+func updatedTables(defaultSchema string, stmt *sqlparser.Update) []string {
+	var referenced []string
+	var aliases = make(map[string]string) // Map from table name or alias to the referenced stream ID
+	var walk func(exprs sqlparser.TableExprs)
+	walk = func(exprs sqlparser.TableExprs) {
+		for _, expr := range exprs {
+			switch expr := expr.(type) {
+			case *sqlparser.AliasedTableExpr:
+				// Derived tables can't be the target of an UPDATE, so only table names matter.
+				if name, ok := expr.Expr.(sqlparser.TableName); ok {
+					var streamID = resolveTableName(defaultSchema, name)
+					referenced = append(referenced, streamID)
+					if !expr.As.IsEmpty() {
+						aliases[expr.As.String()] = streamID
+					} else {
+						aliases[name.Name.String()] = streamID
+					}
+				}
+			case *sqlparser.JoinTableExpr:
+				walk(sqlparser.TableExprs{expr.LeftExpr, expr.RightExpr})
+			case *sqlparser.ParenTableExpr:
+				walk(expr.Exprs)
+			}
+		}
+	}
+	walk(stmt.TableExprs)
+	if len(referenced) <= 1 {
+		return referenced
+	}
+
+	var updated []string
+	for _, expr := range stmt.Exprs {
+		var qualifier = expr.Name.Qualifier
+		var streamID string
+		if qualifier.IsEmpty() {
+			return referenced // An unqualified column could belong to any of the tables
+		} else if !qualifier.Qualifier.IsEmpty() {
+			streamID = resolveTableName(defaultSchema, qualifier)
+		} else if aliasedID, ok := aliases[qualifier.Name.String()]; ok {
+			streamID = aliasedID
+		} else {
+			return referenced // Should be impossible, but be conservative if it happens
+		}
+		if !slices.Contains(updated, streamID) {
+			updated = append(updated, streamID)
+		}
+	}
+	return updated
+}
+
 func (rs *mysqlReplicationStream) tableMetadata(streamID string) (*mysqlTableMetadata, bool) {
 	rs.tables.RLock()
 	defer rs.tables.RUnlock()
@@ -826,21 +1201,7 @@ func (rs *mysqlReplicationStream) ActivateTable(ctx context.Context, streamID st
 		}
 	} else if discovery != nil {
 		// If metadata JSON is not present, construct new default metadata based on the discovery info.
-		logrus.WithField("stream", streamID).Debug("initializing table metadata")
-		metadata = new(mysqlTableMetadata)
-		var colTypes = make(map[string]interface{})
-		for colName, colInfo := range discovery.Columns {
-			colTypes[colName] = colInfo.DataType
-		}
-
-		metadata.Schema.Columns = discovery.ColumnNames
-		metadata.Schema.ColumnTypes = colTypes
-
-		logrus.WithFields(logrus.Fields{
-			"stream":  streamID,
-			"columns": metadata.Schema.Columns,
-			"types":   metadata.Schema.ColumnTypes,
-		}).Debug("initialized table metadata")
+		metadata = initTableMetadata(streamID, discovery)
 	}
 
 	// Finally, mark the table as active and store the updated metadata.
@@ -851,6 +1212,34 @@ func (rs *mysqlReplicationStream) ActivateTable(ctx context.Context, streamID st
 	return nil
 }
 
+func initTableMetadata(streamID string, discovery *sqlcapture.DiscoveryInfo) *mysqlTableMetadata {
+	logrus.WithField("stream", streamID).Debug("initializing table metadata")
+	var metadata = new(mysqlTableMetadata)
+	var colTypes = make(map[string]interface{})
+	for colName, colInfo := range discovery.Columns {
+		colTypes[colName] = colInfo.DataType
+	}
+
+	metadata.Schema.Columns = discovery.ColumnNames
+	metadata.Schema.ColumnTypes = colTypes
+
+	logrus.WithFields(logrus.Fields{
+		"stream":  streamID,
+		"columns": metadata.Schema.Columns,
+		"types":   metadata.Schema.ColumnTypes,
+	}).Debug("initialized table metadata")
+	return metadata
+}
+
+// transactionID identifies the transaction currently being processed by its GTID,
+// or by the binlog position at which it began if it has no GTID.
+func (rs *mysqlReplicationStream) transactionID() string {
+	if rs.gtidString != "" {
+		return rs.gtidString
+	}
+	return rs.txnPosition
+}
+
 func (rs *mysqlReplicationStream) emitEvent(ctx context.Context, event sqlcapture.DatabaseEvent) error {
 	select {
 	case rs.events <- event:
@@ -1,6 +1,15 @@
 package main
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/estuary/connectors/sqlcapture"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
 
 func TestIgnoreQueries(t *testing.T) {
 	var cases = map[string]bool{
@@ -25,3 +34,169 @@ func TestIgnoreQueries(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitCursor(t *testing.T) {
+	for input, expect := range map[string]string{
+		`binlog.000123:45678`: "(binlog.000123, 45678)",
+		`mysql-bin.000001:4`:  "(mysql-bin.000001, 4)",
+		`0-1-100`:             "0-1-100",
+		`0-1-100,1-2-57`:      "0-1-100,1-2-57",
+	} {
+		var cursor, err = splitCursor(input)
+		if err != nil {
+			t.Errorf("error parsing cursor %q: %v", input, err)
+		} else if cursor.GTIDs != nil && cursor.GTIDs.String() != expect {
+			t.Errorf("GTID cursor mismatch for %q: got %q, expected %q", input, cursor.GTIDs, expect)
+		} else if cursor.GTIDs == nil && cursor.Position.String() != expect {
+			t.Errorf("binlog cursor mismatch for %q: got %q, expected %q", input, cursor.Position, expect)
+		}
+	}
+	for _, input := range []string{`binlog.000123`, `binlog.000123:abc`, `a:b:c`, `0-1`} {
+		if _, err := splitCursor(input); err == nil {
+			t.Errorf("expected an error parsing cursor %q", input)
+		}
+	}
+}
+
+func TestMariaDBAddressTypes(t *testing.T) {
+	var db = &mysqlDatabase{}
+	var columnTypes = map[string]interface{}{"addr": ""}
+
+	// Replicated values are binary addresses.
+	for _, tc := range []struct {
+		columnType string
+		input      interface{}
+		expect     string
+	}{
+		{"inet4", []byte{192, 168, 1, 2}, "192.168.1.2"},
+		{"inet6", []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, "2001:db8::1"},
+		{"inet6", string([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 10, 0, 0, 1}), "::ffff:10.0.0.1"},
+		{"uuid", []byte{0xac, 0xde, 0x48, 0x00, 0x11, 0x22, 0x9e, 0x07, 0x11, 0xee, 0x3b, 0x1c, 0x6e, 0x1f, 0x2a, 0x80}, "6e1f2a80-3b1c-11ee-9e07-acde48001122"},
+		{"uuid", string([]byte{0x01, 0x89, 0x0a, 0x5d, 0xac, 0x96, 0x77, 0x4b, 0xbc, 0xce, 0xb3, 0x02, 0x09, 0x9a, 0x80, 0x57}), "01890a5d-ac96-774b-bcce-b302099a8057"},
+	} {
+		columnTypes["addr"] = tc.columnType
+		var row, err = decodeRow("test.addrs", []string{"addr"}, columnTypes, []interface{}{tc.input})
+		if err != nil {
+			t.Errorf("error decoding %s value %q: %v", tc.columnType, tc.input, err)
+		} else if row["addr"] != tc.expect {
+			t.Errorf("decoding mismatch for %s value %q: got %v, expected %q", tc.columnType, tc.input, row["addr"], tc.expect)
+		}
+	}
+	columnTypes["addr"] = "inet6"
+	if _, err := decodeRow("test.addrs", []string{"addr"}, columnTypes, []interface{}{[]byte("::1")}); err == nil {
+		t.Errorf("expected an error decoding a non-binary inet6 value")
+	}
+
+	// Backfilled values are already text, whatever their length.
+	for _, tc := range []struct {
+		columnType string
+		input      []byte
+	}{
+		{"inet4", []byte("192.168.1.2")},
+		{"inet6", []byte("2001:db8::1")},
+		{"inet6", []byte("2001:db8::1:2:34")},
+		{"uuid", []byte("6e1f2a80-3b1c-11ee-9e07-acde48001122")},
+	} {
+		var result, err = db.translateRecordField(tc.columnType, tc.input)
+		if err != nil {
+			t.Errorf("error translating %s value %q: %v", tc.columnType, tc.input, err)
+		} else if result != string(tc.input) {
+			t.Errorf("translation mismatch for %s value %q: got %v", tc.columnType, tc.input, result)
+		}
+	}
+}
+
+func TestUpdatedTables(t *testing.T) {
+	var cases = map[string][]string{
+		`UPDATE foo SET x = 1`: {"test.foo"},
+		`UPDATE other.foo SET x = 1 WHERE id IN (SELECT id FROM bar)`:       {"other.foo"},
+		`UPDATE foo, bar SET foo.x = bar.x WHERE foo.id = bar.id`:           {"test.foo"},
+		`UPDATE foo AS a JOIN bar AS b ON a.id = b.id SET b.x = a.x`:        {"test.bar"},
+		`UPDATE foo a, (other.bar b JOIN baz c ON b.id = c.id) SET c.x = 1`: {"test.baz"},
+		`UPDATE foo, other.bar SET other.bar.x = 1, foo.y = 2`:              {"other.bar", "test.foo"},
+		`UPDATE foo, bar SET x = 1 WHERE foo.id = bar.id`:                   {"test.foo", "test.bar"},
+	}
+	for query, expect := range cases {
+		var stmt, err = sqlparser.Parse(query)
+		if err != nil {
+			t.Fatalf("error parsing query %q: %v", query, err)
+		}
+		if result := updatedTables("test", stmt.(*sqlparser.Update)); !slices.Equal(result, expect) {
+			t.Errorf("updated tables mismatch for %q: got %v, expected %v", query, result, expect)
+		}
+	}
+}
+
+func TestTableDDLHandling(t *testing.T) {
+	var newStream = func(advanced advancedConfig) *mysqlReplicationStream {
+		var rs = &mysqlReplicationStream{
+			db:     &mysqlDatabase{config: &Config{Advanced: advanced}},
+			events: make(chan sqlcapture.DatabaseEvent, 16),
+		}
+		rs.cursor.Name = "binlog.000001"
+		rs.cursor.Pos = 1234
+		rs.tables.active = map[string]struct{}{"test.foo": {}, "test.bar": {}}
+		rs.tables.keyColumns = map[string][]string{"test.foo": {"id"}, "test.bar": {"id"}}
+		rs.tables.metadata = map[string]*mysqlTableMetadata{"test.foo": {}, "test.bar": {}}
+		return rs
+	}
+	var summarize = func(rs *mysqlReplicationStream) []string {
+		var summary []string
+		for len(rs.events) > 0 {
+			switch event := (<-rs.events).(type) {
+			case *sqlcapture.TableDropEvent:
+				summary = append(summary, "drop:"+event.StreamID)
+			case *sqlcapture.TableRenameEvent:
+				summary = append(summary, fmt.Sprintf("rename:%s:%s.%s", event.StreamID, event.NewSchema, event.NewTable))
+			case *sqlcapture.FlushEvent:
+				summary = append(summary, "flush:"+event.Cursor)
+			default:
+				summary = append(summary, event.String())
+			}
+		}
+		return summary
+	}
+
+	for _, tc := range []struct {
+		name     string
+		advanced advancedConfig
+		query    string
+		expect   []string
+		active   []string
+		err      string
+	}{
+		{"drop fails by default", advancedConfig{}, "DROP TABLE foo", nil, nil, "unsupported operation"},
+		{"drop ignored", advancedConfig{OnTableDrop: tableDropIgnore}, "DROP TABLE foo, other.baz", []string{"drop:test.foo", "flush:binlog.000001:1234"}, []string{"test.bar"}, ""},
+		{"drop of other table", advancedConfig{OnTableDrop: tableDropIgnore}, "DROP TABLE baz", nil, []string{"test.bar", "test.foo"}, ""},
+		{"rename fails by default", advancedConfig{}, "RENAME TABLE foo TO baz", nil, nil, "operation conflicts with test.foo"},
+		{"rename followed", advancedConfig{OnTableRename: tableRenameFollow}, "RENAME TABLE foo TO other.Baz", []string{"rename:test.foo:other.Baz", "flush:binlog.000001:1234"}, []string{"other.baz", "test.bar"}, ""},
+		{"rename and back", advancedConfig{OnTableRename: tableRenameFollow}, "RENAME TABLE foo TO tmp, tmp TO foo", nil, []string{"test.bar", "test.foo"}, ""},
+		{"rename twice", advancedConfig{OnTableRename: tableRenameFollow}, "RENAME TABLE foo TO tmp, tmp TO baz", []string{"rename:test.foo:test.baz", "flush:binlog.000001:1234"}, []string{"test.bar", "test.baz"}, ""},
+		{"rename onto active table", advancedConfig{OnTableRename: tableRenameFollow}, "RENAME TABLE bar TO tmp, foo TO bar", nil, nil, "operation conflicts with test.bar"},
+		{"swap active tables", advancedConfig{OnTableRename: tableRenameFollow}, "RENAME TABLE foo TO tmp, bar TO foo, tmp TO bar", nil, nil, "operation conflicts with"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var rs = newStream(tc.advanced)
+			var err = rs.handleQuery(context.Background(), "test", tc.query)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if events := summarize(rs); !slices.Equal(events, tc.expect) {
+				t.Errorf("events mismatch: got %q, expected %q", events, tc.expect)
+			}
+			var active []string
+			for streamID := range rs.tables.active {
+				active = append(active, streamID)
+			}
+			slices.Sort(active)
+			if !slices.Equal(active, tc.active) {
+				t.Errorf("active tables mismatch: got %q, expected %q", active, tc.active)
+			}
+		})
+	}
+}
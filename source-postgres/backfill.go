@@ -1,17 +1,21 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/estuary/connectors/sqlcapture"
+	"github.com/jackc/pglogrepl"
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/sirupsen/logrus"
 )
 
 // ScanTableChunk fetches a chunk of rows from the specified table, resuming from `resumeKey` if non-nil.
-func (db *postgresDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.DiscoveryInfo, state *sqlcapture.TableState, callback func(event *sqlcapture.ChangeEvent) error) error {
+func (db *postgresDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.DiscoveryInfo, state *sqlcapture.TableState, filter *sqlcapture.RowFilter, callback func(event *sqlcapture.ChangeEvent) error) error {
 	var keyColumns = state.KeyColumns
 	var resumeAfter = state.Scanned
 	var schema, table = info.Schema, info.Name
@@ -23,6 +27,12 @@ func (db *postgresDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture
 		columnTypes[name] = column.DataType
 	}
 
+	// Only rows matching the binding's row filter and any backfill-specific condition are scanned.
+	var where, err = sqlcapture.BackfillCondition(state, filter, &filterDialect)
+	if err != nil {
+		return err
+	}
+
 	// Compute backfill query and arguments list
 	var disableParallelWorkers bool
 	var query string
@@ -34,7 +44,7 @@ func (db *postgresDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture
 			afterCTID = string(resumeAfter)
 		}
 		logEntry.WithField("ctid", afterCTID).Debug("scanning keyless table chunk")
-		query = db.keylessScanQuery(info, schema, table)
+		query = db.keylessScanQuery(info, schema, table, where)
 		args = []any{afterCTID}
 		disableParallelWorkers = true
 	case sqlcapture.TableModePreciseBackfill, sqlcapture.TableModeUnfilteredBackfill:
@@ -50,27 +60,43 @@ func (db *postgresDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture
 				"keyColumns": keyColumns,
 				"resumeKey":  resumeKey,
 			}).Debug("scanning subsequent table chunk")
-			query = db.buildScanQuery(false, keyColumns, columnTypes, schema, table)
 			args = resumeKey
 		} else {
 			logEntry.WithField("keyColumns", keyColumns).Debug("scanning initial table chunk")
-			query = db.buildScanQuery(true, keyColumns, columnTypes, schema, table)
 		}
+		if state.ScanEnd != nil {
+			var endKey, err = sqlcapture.UnpackTuple(state.ScanEnd, decodeKeyFDB)
+			if err != nil {
+				return fmt.Errorf("error unpacking end key for %q: %w", streamID, err)
+			}
+			if len(endKey) != len(keyColumns) {
+				return fmt.Errorf("expected %d end-key values but got %d", len(keyColumns), len(endKey))
+			}
+			logEntry.WithField("endKey", endKey).Debug("scanning table key range")
+			args = append(args, endKey...)
+		}
+		query = db.buildScanQuery(resumeAfter == nil, state.ScanEnd != nil, keyColumns, columnTypes, schema, table, where)
 	default:
 		return fmt.Errorf("invalid backfill mode %q", state.Mode)
 	}
 
+	conn, err := db.acquireScanConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.releaseScanConn(conn)
+
 	// Keyless backfill queries need to return results in CTID order, but we can't ask
 	// for that because `ORDER BY ctid` forces a sort, so we rely on it being true as an
 	// implementation detail of how `WHERE ctid > $1` queries execute in practice. But
 	// parallel query execution breaks that assumption, so we need to force the query
 	// planner to not use parallel workers in such cases.
 	if disableParallelWorkers {
-		if _, err := db.conn.Exec(ctx, "SET max_parallel_workers_per_gather TO 0"); err != nil {
+		if _, err := conn.Exec(ctx, "SET max_parallel_workers_per_gather TO 0"); err != nil {
 			logrus.WithField("err", err).Warn("error attempting to disable parallel workers")
 		} else {
 			defer func() {
-				if _, err := db.conn.Exec(ctx, "SET max_parallel_workers_per_gather TO DEFAULT"); err != nil {
+				if _, err := conn.Exec(ctx, "SET max_parallel_workers_per_gather TO DEFAULT"); err != nil {
 					logrus.WithField("err", err).Warn("error resetting max_parallel_workers to default")
 				}
 			}()
@@ -78,11 +104,11 @@ func (db *postgresDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture
 	}
 
 	// If this is the first chunk being backfilled, run an `EXPLAIN` on it and log the results
-	db.explainQuery(ctx, streamID, query, args)
+	db.explainQuery(ctx, conn, streamID, query, args)
 
 	// Execute the backfill query to fetch rows from the database
 	logEntry.WithFields(logrus.Fields{"query": query, "args": args}).Debug("executing query")
-	rows, err := db.conn.Query(ctx, query, args...)
+	rows, err := conn.Query(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("unable to execute query %q: %w", query, err)
 	}
@@ -108,7 +134,7 @@ func (db *postgresDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture
 			var ctid = fields["ctid"].(pgtype.TID)
 			delete(fields, "ctid")
 
-			rowKey, err = ctid.EncodeText(db.conn.ConnInfo(), nil)
+			rowKey, err = ctid.EncodeText(conn.ConnInfo(), nil)
 			if err != nil {
 				return fmt.Errorf("internal error: failed to encode ctid %#v: %w", ctid, err)
 			}
@@ -167,6 +193,165 @@ func (db *postgresDatabase) WatermarksTable() string {
 	return db.config.Advanced.WatermarksTable
 }
 
+// EstimatedRowCount returns the planner's estimate of the number of rows in a table.
+func (db *postgresDatabase) EstimatedRowCount(ctx context.Context, info *sqlcapture.DiscoveryInfo) (int, error) {
+	var estimate int64
+	const query = `SELECT c.reltuples::bigint FROM pg_catalog.pg_class c
+	                 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	                 WHERE n.nspname = $1 AND c.relname = $2;`
+	if err := db.conn.QueryRow(ctx, query, info.Schema, info.Name).Scan(&estimate); err != nil {
+		return 0, fmt.Errorf("error querying row count estimate: %w", err)
+	}
+	// Tables which have never been vacuumed or analyzed have a reltuples value of -1.
+	return int(max(estimate, 0)), nil
+}
+
+// keyRangeSampleRows is the number of rows per key range which are sampled to choose the
+// range boundaries of tables which can't be split between their minimum and maximum keys.
+const keyRangeSampleRows = 1000
+
+// The column types of single-column keys which are split between their minimum and maximum values.
+var integerKeyTypes = map[string]bool{
+	"int2": true,
+	"int4": true,
+	"int8": true,
+}
+
+// BackfillKeyRanges returns boundaries which split a table into the configured number of key
+// ranges. Tables with a single integer key are split evenly between its minimum and maximum
+// values, while other tables are split at evenly spaced keys from a random sample of pages.
+func (db *postgresDatabase) BackfillKeyRanges(ctx context.Context, info *sqlcapture.DiscoveryInfo, keyColumns []string) ([][]byte, error) {
+	var count = db.config.Advanced.BackfillKeyRanges
+	if count <= 1 || len(keyColumns) == 0 {
+		return nil, nil
+	}
+	var columnTypes = make(map[string]interface{})
+	for name, column := range info.Columns {
+		columnTypes[name] = column.DataType
+	}
+	if colType, ok := columnTypes[keyColumns[0]].(string); ok && len(keyColumns) == 1 && integerKeyTypes[colType] {
+		return db.integerKeyRanges(ctx, info, keyColumns, columnTypes, count)
+	}
+	return db.sampledKeyRanges(ctx, info, keyColumns, columnTypes, count)
+}
+
+func (db *postgresDatabase) integerKeyRanges(ctx context.Context, info *sqlcapture.DiscoveryInfo, keyColumns []string, columnTypes map[string]interface{}, count int) ([][]byte, error) {
+	var lo, hi *int64
+	var keyColumn = quoteColumnName(keyColumns[0])
+	var query = fmt.Sprintf(`SELECT MIN(%[1]s)::bigint, MAX(%[1]s)::bigint FROM "%[2]s"."%[3]s";`, keyColumn, info.Schema, info.Name)
+	if err := db.conn.QueryRow(ctx, query).Scan(&lo, &hi); err != nil {
+		return nil, fmt.Errorf("error querying key bounds: %w", err)
+	}
+	if lo == nil || hi == nil {
+		return nil, nil // The table is empty
+	}
+
+	// The difference is computed as unsigned so that it can't overflow.
+	var step = (uint64(*hi) - uint64(*lo)) / uint64(count)
+	if step == 0 {
+		return nil, nil
+	}
+	var boundaries [][]byte
+	for idx := 1; idx < count; idx++ {
+		var boundary = *lo + int64(step*uint64(idx))
+		var key, err = sqlcapture.EncodeRowKey(keyColumns, map[string]interface{}{keyColumns[0]: boundary}, columnTypes, encodeKeyFDB)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding key range boundary: %w", err)
+		}
+		boundaries = append(boundaries, key)
+	}
+	return boundaries, nil
+}
+
+func (db *postgresDatabase) sampledKeyRanges(ctx context.Context, info *sqlcapture.DiscoveryInfo, keyColumns []string, columnTypes map[string]interface{}, count int) ([][]byte, error) {
+	// Sampling a table of unknown size could mean reading all of it, so such tables aren't split.
+	var estimate, err = db.EstimatedRowCount(ctx, info)
+	if err != nil {
+		return nil, err
+	} else if estimate <= 0 {
+		return nil, nil
+	}
+	var percent = min(100, 100*float64(count*keyRangeSampleRows)/float64(estimate))
+
+	var quotedKeys []string
+	for _, colName := range keyColumns {
+		quotedKeys = append(quotedKeys, quoteColumnName(colName))
+	}
+	var query = fmt.Sprintf(`SELECT %s FROM "%s"."%s" TABLESAMPLE SYSTEM ($1);`, strings.Join(quotedKeys, ", "), info.Schema, info.Name)
+	rows, err := db.conn.Query(ctx, query, percent)
+	if err != nil {
+		return nil, fmt.Errorf("error sampling table keys: %w", err)
+	}
+	defer rows.Close()
+
+	var sample [][]byte
+	for rows.Next() {
+		var vals, err = rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get row values: %w", err)
+		}
+		var fields = make(map[string]interface{})
+		for idx, colName := range keyColumns {
+			fields[colName] = vals[idx]
+		}
+		key, err := sqlcapture.EncodeRowKey(keyColumns, fields, columnTypes, encodeKeyFDB)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding sampled key: %w", err)
+		}
+		sample = append(sample, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error sampling table keys: %w", err)
+	}
+	if len(sample) < count {
+		return nil, nil
+	}
+
+	// Serialized row keys sort in the same order as the table keys.
+	slices.SortFunc(sample, bytes.Compare)
+	var boundaries [][]byte
+	for idx := 1; idx < count; idx++ {
+		boundaries = append(boundaries, sample[idx*len(sample)/count])
+	}
+	return boundaries, nil
+}
+
+// PositionalWatermarks returns true if the capture is configured to use WAL
+// positions as watermarks instead of writing into the watermarks table.
+func (db *postgresDatabase) PositionalWatermarks() bool {
+	return db.config.Advanced.ReadOnlyWatermarks
+}
+
+// CurrentPosition returns the current WAL position. On a standby this is the
+// position up to which WAL has been replayed, since that's what subsequent
+// backfill queries will observe.
+func (db *postgresDatabase) CurrentPosition(ctx context.Context) (string, error) {
+	var lsn pglogrepl.LSN
+	const query = `SELECT CASE WHEN pg_is_in_recovery() THEN pg_last_wal_replay_lsn() ELSE pg_current_wal_lsn() END;`
+	if err := db.conn.QueryRow(ctx, query).Scan(&lsn); err != nil {
+		return "", fmt.Errorf("error querying current WAL position: %w", err)
+	}
+	return lsn.String(), nil
+}
+
+// PositionReached returns true if the cursor LSN is at or past the position LSN.
+func (db *postgresDatabase) PositionReached(cursor, position string) (bool, error) {
+	cursorLSN, err := pglogrepl.ParseLSN(cursor)
+	if err != nil {
+		return false, fmt.Errorf("error parsing cursor: %w", err)
+	}
+	positionLSN, err := pglogrepl.ParseLSN(position)
+	if err != nil {
+		return false, fmt.Errorf("error parsing position: %w", err)
+	}
+	return cursorLSN >= positionLSN, nil
+}
+
+// SignalsTable returns the name of the table from which signals are read.
+func (db *postgresDatabase) SignalsTable() string {
+	return db.config.Advanced.SignalsTable
+}
+
 // The set of column types for which we need to specify `COLLATE "C"` to get
 // proper ordering and comparison. Represented as a map[string]bool so that it can be
 // combined with the "is the column typename a string" check into one if statement.
@@ -176,39 +361,74 @@ var columnBinaryKeyComparison = map[string]bool{
 	"text":    true,
 }
 
-func (db *postgresDatabase) keylessScanQuery(info *sqlcapture.DiscoveryInfo, schemaName, tableName string) string {
+func (db *postgresDatabase) keylessScanQuery(info *sqlcapture.DiscoveryInfo, schemaName, tableName, where string) string {
 	var query = new(strings.Builder)
 	fmt.Fprintf(query, `SELECT ctid, * FROM "%s"."%s"`, schemaName, tableName)
 	fmt.Fprintf(query, ` WHERE ctid > $1`)
+	if where != "" {
+		fmt.Fprintf(query, ` AND (%s)`, where)
+	}
 	fmt.Fprintf(query, ` LIMIT %d;`, db.config.Advanced.BackfillChunkSize)
 	return query.String()
 }
 
-func (db *postgresDatabase) buildScanQuery(start bool, keyColumns []string, columnTypes map[string]interface{}, schemaName, tableName string) string {
+// buildScanQuery builds a query for the next chunk of a table in key order. Unless `start` is
+// set the first key-length query arguments are the key of the row to resume after, and when
+// `bounded` is set the following key-length arguments are the inclusive upper bound of the scan.
+func (db *postgresDatabase) buildScanQuery(start, bounded bool, keyColumns []string, columnTypes map[string]interface{}, schemaName, tableName, where string) string {
 	// Construct lists of key specifiers and placeholders. They will be joined with commas and used in the query itself.
 	var pkey []string
-	var args []string
-	for idx, colName := range keyColumns {
+	for _, colName := range keyColumns {
 		var quotedName = quoteColumnName(colName)
 		if colType, ok := columnTypes[colName].(string); ok && columnBinaryKeyComparison[colType] {
 			pkey = append(pkey, quotedName+` COLLATE "C"`)
 		} else {
 			pkey = append(pkey, quotedName)
 		}
-		args = append(args, fmt.Sprintf("$%d", idx+1))
+	}
+	var nextArg = 1
+	var placeholders = func() string {
+		var args []string
+		for range keyColumns {
+			args = append(args, fmt.Sprintf("$%d", nextArg))
+			nextArg++
+		}
+		return strings.Join(args, ", ")
 	}
 
 	// Construct the query itself
 	var query = new(strings.Builder)
 	fmt.Fprintf(query, `SELECT * FROM "%s"."%s"`, schemaName, tableName)
+	var conditions []string
 	if !start {
-		fmt.Fprintf(query, ` WHERE (%s) > (%s)`, strings.Join(pkey, ", "), strings.Join(args, ", "))
+		conditions = append(conditions, fmt.Sprintf(`(%s) > (%s)`, strings.Join(pkey, ", "), placeholders()))
+	}
+	if bounded {
+		conditions = append(conditions, fmt.Sprintf(`(%s) <= (%s)`, strings.Join(pkey, ", "), placeholders()))
+	}
+	if where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	if len(conditions) > 0 {
+		fmt.Fprintf(query, ` WHERE %s`, strings.Join(conditions, " AND "))
 	}
 	fmt.Fprintf(query, ` ORDER BY %s`, strings.Join(pkey, ", "))
 	fmt.Fprintf(query, " LIMIT %d;", db.config.Advanced.BackfillChunkSize)
 	return query.String()
 }
 
+// filterDialect renders row filters as PostgreSQL conditions. Columns compared with
+// strings use the "C" collation, since the ordering of the default collation is
+// locale-specific.
+var filterDialect = sqlcapture.FilterDialect{
+	QuoteColumn: quoteColumnName,
+	BinaryString: func(column string) string {
+		return column + `::text COLLATE "C"`
+	},
+	True:  "TRUE",
+	False: "FALSE",
+}
+
 func quoteColumnName(name string) string {
 	// From https://www.postgresql.org/docs/14/sql-syntax-lexical.html#SQL-SYNTAX-IDENTIFIERS:
 	//
@@ -217,15 +437,18 @@ func quoteColumnName(name string) string {
 	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
 }
 
-func (db *postgresDatabase) explainQuery(ctx context.Context, streamID, query string, args []interface{}) {
+func (db *postgresDatabase) explainQuery(ctx context.Context, conn *pgx.Conn, streamID, query string, args []interface{}) {
 	// Only EXPLAIN the backfill query once per connector invocation
+	db.explainMu.Lock()
 	if db.explained == nil {
 		db.explained = make(map[string]struct{})
 	}
 	if _, ok := db.explained[streamID]; ok {
+		db.explainMu.Unlock()
 		return
 	}
 	db.explained[streamID] = struct{}{}
+	db.explainMu.Unlock()
 
 	// Ask the database to EXPLAIN the backfill query
 	var explainQuery = "EXPLAIN " + query
@@ -233,7 +456,7 @@ func (db *postgresDatabase) explainQuery(ctx context.Context, streamID, query st
 		"id":    streamID,
 		"query": explainQuery,
 	}).Info("explain backfill query")
-	explainResult, err := db.conn.Query(ctx, explainQuery, args...)
+	explainResult, err := conn.Query(ctx, explainQuery, args...)
 	if err != nil {
 		logrus.WithField("id", streamID).Error("unable to execute query")
 		return
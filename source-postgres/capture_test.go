@@ -267,6 +267,27 @@ func TestCursorResume(t *testing.T) {
 	cupaloy.SnapshotT(t, summary)
 }
 
+// TestScanQueryKeyRange checks the placeholders of backfill queries which resume
+// after a key and stop at the end of a key range.
+func TestScanQueryKeyRange(t *testing.T) {
+	var db = &postgresDatabase{config: &Config{Advanced: advancedConfig{BackfillChunkSize: 100}}}
+	var keyColumns = []string{"epoch", "count"}
+	var columnTypes = map[string]interface{}{"epoch": "varchar", "count": "int4"}
+	for _, tc := range []struct {
+		start, bounded bool
+		expect         string
+	}{
+		{true, false, `SELECT * FROM "test"."t" ORDER BY "epoch" COLLATE "C", "count" LIMIT 100;`},
+		{false, false, `SELECT * FROM "test"."t" WHERE ("epoch" COLLATE "C", "count") > ($1, $2) ORDER BY "epoch" COLLATE "C", "count" LIMIT 100;`},
+		{true, true, `SELECT * FROM "test"."t" WHERE ("epoch" COLLATE "C", "count") <= ($1, $2) ORDER BY "epoch" COLLATE "C", "count" LIMIT 100;`},
+		{false, true, `SELECT * FROM "test"."t" WHERE ("epoch" COLLATE "C", "count") > ($1, $2) AND ("epoch" COLLATE "C", "count") <= ($3, $4) ORDER BY "epoch" COLLATE "C", "count" LIMIT 100;`},
+	} {
+		if query := db.buildScanQuery(tc.start, tc.bounded, keyColumns, columnTypes, "test", "t", ""); query != tc.expect {
+			t.Errorf("start=%v bounded=%v: got %q, expected %q", tc.start, tc.bounded, query, tc.expect)
+		}
+	}
+}
+
 // TestComplexDataset tries to throw together a bunch of different bits of complexity
 // to synthesize something vaguely "realistic". It features a multiple-column primary
 // key, a dataset large enough that the initial table scan gets divided across many
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cerrors "github.com/estuary/connectors/go/connector-errors"
+	"github.com/estuary/connectors/sqlcapture"
+	"github.com/jackc/pgx/v4"
+)
+
+// The replication slot is queried as JSON so that the 'wal_status' and 'safe_wal_size'
+// columns are simply absent rather than an error on PostgreSQL versions before 13.
+const replicationSlotHealthQuery = `
+  SELECT to_jsonb(s) || jsonb_build_object('retained_bytes', pg_wal_lsn_diff(
+    CASE WHEN pg_is_in_recovery() THEN pg_last_wal_replay_lsn() ELSE pg_current_wal_lsn() END,
+    s.restart_lsn))
+  FROM pg_catalog.pg_replication_slots s
+  WHERE s.slot_name = $1;`
+
+type replicationSlotHealth struct {
+	Active        bool   `json:"active"`
+	WALStatus     string `json:"wal_status"`    // PostgreSQL 13 or later
+	SafeWALSize   *int64 `json:"safe_wal_size"` // PostgreSQL 13 or later, and null unless 'max_slot_wal_keep_size' is set
+	RetainedBytes int64  `json:"retained_bytes"`
+}
+
+// ReplicationHealth reports how much WAL the replication slot of the capture retains,
+// and whether the slot is at risk of being invalidated by 'max_slot_wal_keep_size'.
+// The slot tracks its own position, so the cursor isn't needed.
+func (db *postgresDatabase) ReplicationHealth(ctx context.Context, cursor string) (*sqlcapture.ReplicationHealth, error) {
+	var slotName = db.config.Advanced.SlotName
+	var slotJSON []byte
+	if err := db.conn.QueryRow(ctx, replicationSlotHealthQuery, slotName).Scan(&slotJSON); errors.Is(err, pgx.ErrNoRows) {
+		return db.retentionLost(fmt.Sprintf("replication slot %q no longer exists", slotName))
+	} else if err != nil {
+		return nil, fmt.Errorf("error querying replication slot %q: %w", slotName, err)
+	}
+	var slot replicationSlotHealth
+	if err := json.Unmarshal(slotJSON, &slot); err != nil {
+		return nil, fmt.Errorf("error decoding replication slot %q: %w", slotName, err)
+	}
+
+	var health = &sqlcapture.ReplicationHealth{
+		Details: map[string]any{
+			"slot":          slotName,
+			"active":        slot.Active,
+			"retainedBytes": slot.RetainedBytes,
+		},
+	}
+	if slot.WALStatus != "" {
+		health.Details["walStatus"] = slot.WALStatus
+	}
+	if slot.SafeWALSize != nil {
+		health.Details["safeWALBytes"] = *slot.SafeWALSize
+	}
+
+	switch slot.WALStatus {
+	case "lost":
+		return db.retentionLost(fmt.Sprintf("replication slot %q has been invalidated because WAL which it required was removed, so changes have been lost and the capture must be backfilled again", slotName))
+	case "unreserved":
+		health.Warnings = append(health.Warnings, fmt.Sprintf("replication slot %q retains more WAL than 'max_slot_wal_keep_size' permits, and will be invalidated at the next checkpoint unless the capture catches up", slotName))
+	default:
+		// The slot is at risk once most of the WAL permitted by 'max_slot_wal_keep_size' is in use.
+		if slot.SafeWALSize != nil && *slot.SafeWALSize < (slot.RetainedBytes+*slot.SafeWALSize)/4 {
+			health.Warnings = append(health.Warnings, fmt.Sprintf("replication slot %q will be invalidated once %d more bytes of WAL are written, due to 'max_slot_wal_keep_size'", slotName, *slot.SafeWALSize))
+		}
+	}
+	return health, nil
+}
+
+// retentionLost reports that changes were removed from the WAL before they could be
+// captured, which fails the capture if it's configured to do so.
+func (db *postgresDatabase) retentionLost(msg string) (*sqlcapture.ReplicationHealth, error) {
+	if db.config.Advanced.FailOnRetentionLoss {
+		return nil, cerrors.NewUserError(nil, msg)
+	}
+	return &sqlcapture.ReplicationHealth{Warnings: []string{msg}}, nil
+}
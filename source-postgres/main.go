@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	cerrors "github.com/estuary/connectors/go/connector-errors"
@@ -25,6 +27,11 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// connectorVersion is the version of this connector, as reported in Debezium change event envelopes.
+//
+//go:embed VERSION
+var connectorVersion string
+
 type sshForwarding struct {
 	SSHEndpoint string `json:"sshEndpoint" jsonschema:"title=SSH Endpoint,description=Endpoint of the remote SSH server that supports tunneling (in the form of ssh://user@hostname[:port])" jsonschema_extras:"pattern=^ssh://.+@.+$"`
 	PrivateKey  string `json:"privateKey" jsonschema:"title=SSH Private Key,description=Private key to connect to the remote SSH server." jsonschema_extras:"secret=true,multiline=true"`
@@ -98,13 +105,26 @@ type Config struct {
 }
 
 type advancedConfig struct {
-	PublicationName   string   `json:"publicationName,omitempty" jsonschema:"default=flow_publication,description=The name of the PostgreSQL publication to replicate from."`
-	SlotName          string   `json:"slotName,omitempty" jsonschema:"default=flow_slot,description=The name of the PostgreSQL replication slot to replicate from."`
-	WatermarksTable   string   `json:"watermarksTable,omitempty" jsonschema:"default=public.flow_watermarks,description=The name of the table used for watermark writes during backfills. Must be fully-qualified in '<schema>.<table>' form."`
-	SkipBackfills     string   `json:"skip_backfills,omitempty" jsonschema:"title=Skip Backfills,description=A comma-separated list of fully-qualified table names which should not be backfilled."`
-	BackfillChunkSize int      `json:"backfill_chunk_size,omitempty" jsonschema:"title=Backfill Chunk Size,default=50000,description=The number of rows which should be fetched from the database in a single backfill query."`
-	SSLMode           string   `json:"sslmode,omitempty" jsonschema:"title=SSL Mode,description=Overrides SSL connection behavior by setting the 'sslmode' parameter.,enum=disable,enum=allow,enum=prefer,enum=require,enum=verify-ca,enum=verify-full"`
-	DiscoverSchemas   []string `json:"discover_schemas,omitempty" jsonschema:"title=Discovery Schema Selection,description=If this is specified only tables in the selected schema(s) will be automatically discovered. Omit all entries to discover tables from all schemas."`
+	PublicationName     string   `json:"publicationName,omitempty" jsonschema:"default=flow_publication,description=The name of the PostgreSQL publication to replicate from."`
+	SlotName            string   `json:"slotName,omitempty" jsonschema:"default=flow_slot,description=The name of the PostgreSQL replication slot to replicate from."`
+	WatermarksTable     string   `json:"watermarksTable,omitempty" jsonschema:"default=public.flow_watermarks,description=The name of the table used for watermark writes during backfills. Must be fully-qualified in '<schema>.<table>' form."`
+	ReadOnlyWatermarks  bool     `json:"read_only_watermarks,omitempty" jsonschema:"title=Read-Only Watermarks,description=Use WAL positions read at backfill chunk boundaries as watermarks instead of writing into the watermarks table. This permits capturing without write access to the database and from standbys which support logical decoding (PostgreSQL 16 or later)."`
+	SignalsTable        string   `json:"signals_table,omitempty" jsonschema:"title=Signals Table,description=The name of an optional table from which backfill signals are read. Must be fully-qualified in '<schema>.<table>' form and included in the publication. Each row inserted into it must have a 'signal' column holding a JSON object with an 'action' of 'backfill' and the fully-qualified 'table' to backfill plus an optional 'where' row filter expression limiting the backfilled rows."`
+	SkipBackfills       string   `json:"skip_backfills,omitempty" jsonschema:"title=Skip Backfills,description=A comma-separated list of fully-qualified table names which should not be backfilled."`
+	BackfillChunkSize   int      `json:"backfill_chunk_size,omitempty" jsonschema:"title=Backfill Chunk Size,default=50000,description=The number of rows which should be fetched from the database in a single backfill query."`
+	BackfillConcurrency int      `json:"backfill_concurrency,omitempty" jsonschema:"title=Backfill Concurrency,default=1,description=The maximum number of tables which may be backfilled at the same time. Each concurrently backfilled table uses its own database connection."`
+	BackfillKeyRanges   int      `json:"backfill_key_ranges,omitempty" jsonschema:"title=Backfill Key Ranges,default=1,description=The number of key ranges into which the backfill of each table is split. The key ranges of a table are backfilled at the same time up to the backfill concurrency. Tables with a single integer primary key are split evenly between its minimum and maximum values and other tables at keys sampled from the table."`
+	SSLMode             string   `json:"sslmode,omitempty" jsonschema:"title=SSL Mode,description=Overrides SSL connection behavior by setting the 'sslmode' parameter.,enum=disable,enum=allow,enum=prefer,enum=require,enum=verify-ca,enum=verify-full"`
+	DiscoverSchemas     []string `json:"discover_schemas,omitempty" jsonschema:"title=Discovery Schema Selection,description=If this is specified only tables in the selected schema(s) will be automatically discovered. Omit all entries to discover tables from all schemas."`
+
+	StreamingTransactions bool `json:"streaming_transactions,omitempty" jsonschema:"title=Streaming Transactions,description=Receive large in-progress transactions from the database as they're written rather than after they commit. Requires PostgreSQL 14 or later."`
+	TwoPhaseCommit        bool `json:"two_phase_commit,omitempty" jsonschema:"title=Two-Phase Commit,description=Decode prepared transactions when they're prepared rather than when they're committed. Requires PostgreSQL 15 or later."`
+
+	ExcludeOrigins []string `json:"exclude_origins,omitempty" jsonschema:"title=Exclude Origins,description=Changes from transactions which were replicated into this database from any of the named replication origins will not be captured. This can be used to avoid loops in bidirectional replication setups."`
+
+	DebeziumEnvelope    bool `json:"debezium_envelope,omitempty" jsonschema:"title=Debezium Envelope,description=Emit the '_meta' property of each captured document as a complete Debezium change event envelope with 'before' and 'after' states and Debezium source properties. Collections must be re-discovered after changing this setting."`
+	TransactionMetadata bool `json:"transaction_metadata,omitempty" jsonschema:"title=Transaction Metadata,description=Include the ID and sequence number of the source transaction in the '_meta' property of each replicated change and suggest an additional binding which receives one BEGIN and one END document per transaction with the number of changes to each table. Collections must be re-discovered after changing this setting."`
+	FailOnRetentionLoss bool `json:"fail_on_retention_loss,omitempty" jsonschema:"title=Fail on Retention Loss,description=Fail the capture with an error when the periodic replication health check finds that changes were removed from the database before they could be captured. By default this is only logged as a warning."`
 }
 
 // Validate checks that the configuration possesses all required properties.
@@ -123,6 +143,9 @@ func (c *Config) Validate() error {
 	if c.Advanced.WatermarksTable != "" && !strings.Contains(c.Advanced.WatermarksTable, ".") {
 		return fmt.Errorf("invalid 'watermarksTable' configuration: table name %q must be fully-qualified as \"<schema>.<table>\"", c.Advanced.WatermarksTable)
 	}
+	if c.Advanced.SignalsTable != "" && !strings.Contains(c.Advanced.SignalsTable, ".") {
+		return fmt.Errorf("invalid 'signals_table' configuration: table name %q must be fully-qualified as \"<schema>.<table>\"", c.Advanced.SignalsTable)
+	}
 	if c.Advanced.SkipBackfills != "" {
 		for _, skipStreamID := range strings.Split(c.Advanced.SkipBackfills, ",") {
 			if !strings.Contains(skipStreamID, ".") {
@@ -140,6 +163,17 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("discovery schema selection must not contain any entries that are blank: To discover tables from all schemas, remove all entries from the discovery schema selection list. To discover tables only from specific schemas, provide their names as non-blank entries")
 		}
 	}
+	if c.Advanced.BackfillConcurrency < 0 {
+		return fmt.Errorf("invalid 'backfill_concurrency' configuration: concurrency %d must not be negative", c.Advanced.BackfillConcurrency)
+	}
+	if c.Advanced.BackfillKeyRanges < 0 {
+		return fmt.Errorf("invalid 'backfill_key_ranges' configuration: range count %d must not be negative", c.Advanced.BackfillKeyRanges)
+	}
+	for _, origin := range c.Advanced.ExcludeOrigins {
+		if origin == "" {
+			return fmt.Errorf("invalid 'exclude_origins' configuration: origin names must not be blank")
+		}
+	}
 
 	return nil
 }
@@ -160,6 +194,12 @@ func (c *Config) SetDefaults() {
 	if c.Advanced.BackfillChunkSize <= 0 {
 		c.Advanced.BackfillChunkSize = 50000
 	}
+	if c.Advanced.BackfillConcurrency <= 0 {
+		c.Advanced.BackfillConcurrency = 1
+	}
+	if c.Advanced.BackfillKeyRanges <= 0 {
+		c.Advanced.BackfillKeyRanges = 1
+	}
 
 	// The address config property should accept a host or host:port
 	// value, and if the port is unspecified it should be the PostgreSQL
@@ -209,6 +249,16 @@ type postgresDatabase struct {
 	conn         *pgx.Conn
 	explained    map[string]struct{} // Tracks tables which have had an `EXPLAIN` run on them during this connector invocation
 	includeTxIDs map[string]bool     // Tracks which tables should have XID properties in their replication metadata
+
+	// Connections used for concurrent table scans, which are opened on demand
+	// up to the configured backfill concurrency. The main connection is one of
+	// them, since nothing else uses it while backfill chunks are being scanned.
+	scanConns struct {
+		sync.Mutex
+		idle   chan *pgx.Conn
+		opened []*pgx.Conn
+	}
+	explainMu sync.Mutex // Guards `explained` when multiple tables are scanned concurrently
 }
 
 func (db *postgresDatabase) connect(ctx context.Context) error {
@@ -220,9 +270,19 @@ func (db *postgresDatabase) connect(ctx context.Context) error {
 	}).Info("initializing connector")
 
 	// Normal database connection used for table scanning
+	var conn, err = db.openConnection(ctx)
+	if err != nil {
+		return err
+	}
+	db.conn = conn
+	return nil
+}
+
+// openConnection opens a new normal (non-replication) database connection.
+func (db *postgresDatabase) openConnection(ctx context.Context) (*pgx.Conn, error) {
 	var config, err = pgx.ParseConfig(db.config.ToURI())
 	if err != nil {
-		return fmt.Errorf("error parsing database uri: %w", err)
+		return nil, fmt.Errorf("error parsing database uri: %w", err)
 	}
 	if config.ConnectTimeout == 0 {
 		config.ConnectTimeout = 10 * time.Second
@@ -234,21 +294,77 @@ func (db *postgresDatabase) connect(ctx context.Context) error {
 		if errors.As(err, &pgErr) {
 			switch pgErr.Code {
 			case "28P01":
-				return cerrors.NewUserError(err, "incorrect username or password")
+				return nil, cerrors.NewUserError(err, "incorrect username or password")
 			case "3D000":
-				return cerrors.NewUserError(err, fmt.Sprintf("database %q does not exist", db.config.Database))
+				return nil, cerrors.NewUserError(err, fmt.Sprintf("database %q does not exist", db.config.Database))
 			case "42501":
-				return cerrors.NewUserError(err, fmt.Sprintf("user %q does not have CONNECT privilege to database %q", db.config.User, db.config.Database))
+				return nil, cerrors.NewUserError(err, fmt.Sprintf("user %q does not have CONNECT privilege to database %q", db.config.User, db.config.Database))
 			}
 		}
 
-		return fmt.Errorf("unable to connect to database: %w", err)
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
-	db.conn = conn
-	return nil
+	return conn, nil
+}
+
+// acquireScanConn returns a connection on which a table scan may be performed,
+// opening a new one if all of the existing ones are in use and the configured
+// backfill concurrency permits it. The connection must be released afterwards.
+func (db *postgresDatabase) acquireScanConn(ctx context.Context) (*pgx.Conn, error) {
+	if db.config.Advanced.BackfillConcurrency <= 1 {
+		return db.conn, nil
+	}
+
+	db.scanConns.Lock()
+	if db.scanConns.idle == nil {
+		db.scanConns.idle = make(chan *pgx.Conn, db.config.Advanced.BackfillConcurrency)
+		db.scanConns.idle <- db.conn
+	}
+	select {
+	case conn := <-db.scanConns.idle:
+		db.scanConns.Unlock()
+		return conn, nil
+	default:
+	}
+	if len(db.scanConns.opened)+1 < db.config.Advanced.BackfillConcurrency {
+		defer db.scanConns.Unlock()
+		logrus.WithField("count", len(db.scanConns.opened)+2).Debug("opening additional backfill connection")
+		var conn, err = db.openConnection(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error opening backfill connection: %w", err)
+		}
+		db.scanConns.opened = append(db.scanConns.opened, conn)
+		return conn, nil
+	}
+	db.scanConns.Unlock()
+
+	select {
+	case conn := <-db.scanConns.idle:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releaseScanConn returns a connection obtained from acquireScanConn.
+func (db *postgresDatabase) releaseScanConn(conn *pgx.Conn) {
+	if db.config.Advanced.BackfillConcurrency <= 1 {
+		return
+	}
+	db.scanConns.idle <- conn
+}
+
+// BackfillConcurrency returns the configured number of tables which may be backfilled at once.
+func (db *postgresDatabase) BackfillConcurrency() int {
+	return db.config.Advanced.BackfillConcurrency
 }
 
 func (db *postgresDatabase) Close(ctx context.Context) error {
+	for _, conn := range db.scanConns.opened {
+		if err := conn.Close(ctx); err != nil {
+			return fmt.Errorf("error closing backfill connection: %w", err)
+		}
+	}
 	if err := db.conn.Close(ctx); err != nil {
 		return fmt.Errorf("error closing database connection: %w", err)
 	}
@@ -259,6 +375,21 @@ func (db *postgresDatabase) EmptySourceMetadata() sqlcapture.SourceMetadata {
 	return &postgresSource{}
 }
 
+func (db *postgresDatabase) DebeziumInfo() *sqlcapture.DebeziumInfo {
+	if !db.config.Advanced.DebeziumEnvelope {
+		return nil
+	}
+	return &sqlcapture.DebeziumInfo{
+		Connector: "postgresql",
+		Version:   strings.TrimSpace(connectorVersion),
+		Database:  db.config.Database,
+	}
+}
+
+func (db *postgresDatabase) TransactionMetadata() bool {
+	return db.config.Advanced.TransactionMetadata
+}
+
 func (db *postgresDatabase) FallbackCollectionKey() []string {
 	return []string{"/_meta/source/loc/0", "/_meta/source/loc/1", "/_meta/source/loc/2"}
 }
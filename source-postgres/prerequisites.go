@@ -39,6 +39,9 @@ func (db *postgresDatabase) SetupPrerequisites(ctx context.Context) []error {
 const (
 	reqMajorVersion = 10
 	reqMinorVersion = 0
+
+	streamingMajorVersion = 14 // Minimum version supporting protocol version 2 streamed transactions.
+	twoPhaseMajorVersion  = 15 // Minimum version supporting protocol version 3 two-phase commits.
 )
 
 func (db *postgresDatabase) prerequisiteVersion(ctx context.Context) error {
@@ -65,6 +68,12 @@ func (db *postgresDatabase) prerequisiteVersion(ctx context.Context) error {
 			"major":   major,
 			"minor":   minor,
 		}).Info("queried database version")
+		if db.config.Advanced.StreamingTransactions && major < streamingMajorVersion {
+			return fmt.Errorf("streaming transactions require Postgres version %d or later: attempted to capture from database version %d.%d", streamingMajorVersion, major, minor)
+		}
+		if db.config.Advanced.TwoPhaseCommit && major < twoPhaseMajorVersion {
+			return fmt.Errorf("two-phase commit decoding requires Postgres version %d or later: attempted to capture from database version %d.%d", twoPhaseMajorVersion, major, minor)
+		}
 		return nil
 	}
 
@@ -182,6 +191,9 @@ func (db *postgresDatabase) prerequisitePublication(ctx context.Context) error {
 }
 
 func (db *postgresDatabase) prerequisiteWatermarksTable(ctx context.Context) error {
+	if db.config.Advanced.ReadOnlyWatermarks {
+		return nil // The watermarks table isn't used at all
+	}
 	var table = db.config.Advanced.WatermarksTable
 	var logEntry = logrus.WithField("table", table)
 
@@ -214,6 +226,9 @@ func (db *postgresDatabase) prerequisiteWatermarksInPublication(ctx context.Cont
 	// table and publication have already attempted to be created if they don't exist. If either the
 	// watermarks table or publication doesn't exist another error will be generated here which is a
 	// bit redundant.
+	if db.config.Advanced.ReadOnlyWatermarks {
+		return nil
+	}
 
 	var pubName = db.config.Advanced.PublicationName
 	var watermarks = db.config.Advanced.WatermarksTable
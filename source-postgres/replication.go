@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -60,11 +61,22 @@ func (db *postgresDatabase) ReplicationStream(ctx context.Context, startCursor s
 		"slot":        slot,
 	}).Info("starting replication")
 
+	// Streamed transactions require protocol version 2 and two-phase commits
+	// require version 3, so only request the newer versions when necessary.
+	var protoVersion = 1
+	var pluginArgs = []string{fmt.Sprintf(`"publication_names" '%s'`, publication)}
+	if db.config.Advanced.StreamingTransactions {
+		protoVersion = 2
+		pluginArgs = append(pluginArgs, `"streaming" 'on'`)
+	}
+	if db.config.Advanced.TwoPhaseCommit {
+		protoVersion = 3
+		pluginArgs = append(pluginArgs, `"two_phase" 'on'`)
+	}
+	pluginArgs = append([]string{fmt.Sprintf(`"proto_version" '%d'`, protoVersion)}, pluginArgs...)
+
 	if err := pglogrepl.StartReplication(ctx, conn, slot, startLSN, pglogrepl.StartReplicationOptions{
-		PluginArgs: []string{
-			`"proto_version" '1'`,
-			fmt.Sprintf(`"publication_names" '%s'`, publication),
-		},
+		PluginArgs: pluginArgs,
 	}); err != nil {
 		conn.Close(ctx)
 		// The number one source of errors at this point in the capture is that another
@@ -90,11 +102,15 @@ func (db *postgresDatabase) ReplicationStream(ctx context.Context, startCursor s
 		nextTxnMillis:   0,
 		connInfo:        pgtype.NewConnInfo(),
 		relations:       make(map[uint32]*pglogrepl.RelationMessage),
+		relationData:    make(map[uint32][]byte),
+		prepared:        make(map[uint32]*txnBuffer),
 		// standbyStatusDeadline is left uninitialized so an update will be sent ASAP
 	}
 	stream.tables.active = make(map[string]struct{})
 	stream.tables.keyColumns = make(map[string][]string)
 	stream.tables.discovery = make(map[string]*sqlcapture.DiscoveryInfo)
+	stream.tables.metadata = make(map[string]*postgresTableMetadata)
+	stream.streaming.txns = make(map[uint32]*txnBuffer)
 	return stream, nil
 }
 
@@ -120,6 +136,10 @@ type postgresSource struct {
 	//    ordering of all events. It's equal to [loc[0], loc[1]].
 
 	TxID uint32 `json:"txid,omitempty" jsonschema:"description=The 32-bit transaction ID assigned by Postgres to the commit which produced this change."`
+
+	Origin string `json:"origin,omitempty" jsonschema:"description=The replication origin of the transaction which produced this change, if it was replicated into this database from elsewhere."`
+
+	xid uint32 // XID of the transaction, which is tracked for every change regardless of TxID.
 }
 
 // Named constants for the LSN locations within a postgresSource.Location.
@@ -133,6 +153,27 @@ func (s *postgresSource) Common() sqlcapture.SourceCommon {
 	return s.SourceCommon
 }
 
+// DebeziumSource returns the Postgres-specific properties of a Debezium source block.
+func (s *postgresSource) DebeziumSource() map[string]any {
+	var props = map[string]any{
+		"lsn":      s.Location[1],
+		"sequence": fmt.Sprintf(`["%d","%d"]`, s.Location[0], s.Location[1]),
+	}
+	if s.TxID != 0 {
+		props["txId"] = s.TxID
+	}
+	return props
+}
+
+// TransactionID identifies the transaction of a replicated change by its XID and
+// commit LSN, since XIDs alone can wrap around.
+func (s *postgresSource) TransactionID() string {
+	if s.Location[pgLocBeginFinalLSN] == 0 {
+		return "" // Backfilled rows aren't part of a transaction
+	}
+	return fmt.Sprintf("%d:%d", s.xid, s.Location[pgLocBeginFinalLSN])
+}
+
 // A replicationStream represents the process of receiving PostgreSQL
 // Logical Replication events, managing keepalives and status updates,
 // and translating changes into a more friendly representation. There
@@ -147,13 +188,14 @@ type replicationStream struct {
 	cancel   context.CancelFunc            // Cancel function for the replication goroutine's context
 	errCh    chan error                    // Error channel for the final exit status of the replication goroutine
 	events   chan sqlcapture.DatabaseEvent // The channel to which replication events will be written
-	eventBuf sqlcapture.DatabaseEvent      // A single-element buffer used in between 'receiveMessage' and the output channel
+	eventBuf []sqlcapture.DatabaseEvent    // A buffer of decoded events used in between 'receiveMessage' and the output channel
 
 	ackLSN          uint64        // The most recently Ack'd LSN, passed to startReplication or updated via CommitLSN.
 	lastTxnEndLSN   pglogrepl.LSN // End LSN (record + 1) of the last completed transaction.
 	nextTxnFinalLSN pglogrepl.LSN // Final LSN of the commit currently being processed, or zero if between transactions.
 	nextTxnMillis   int64         // Unix timestamp (in millis) at which the change originally occurred.
 	nextTxnXID      uint32        // XID of the commit currently being processed.
+	nextTxnOrigin   string        // Replication origin of the commit currently being processed, if any.
 
 	// standbyStatusDeadline is the time at which we need to stop receiving
 	// replication messages and go send a Standby Status Update message to
@@ -169,6 +211,20 @@ type replicationStream struct {
 	// and other information about the table structure at a particular moment.
 	relations map[uint32]*pglogrepl.RelationMessage
 
+	// relationData holds the raw contents of the most recent relation message
+	// for each relation ID, so that it can be copied into transaction buffers.
+	relationData map[uint32][]byte
+
+	// Streamed (in-progress) transactions are buffered until they commit or abort.
+	streaming struct {
+		current *txnBuffer            // The transaction of the stream block currently being received, if any.
+		txns    map[uint32]*txnBuffer // Streamed transactions which haven't yet committed or aborted, by XID.
+	}
+
+	preparing *txnBuffer            // The two-phase transaction currently being received, if any.
+	prepared  map[uint32]*txnBuffer // Prepared transactions awaiting COMMIT PREPARED or ROLLBACK PREPARED, by XID.
+	replay    *txnReplay            // The committed transaction whose buffered messages are being decoded, if any.
+
 	// The 'active tables' set, guarded by a mutex so it can be modified from
 	// the main goroutine while it's read by the replication goroutine.
 	tables struct {
@@ -176,9 +232,22 @@ type replicationStream struct {
 		active     map[string]struct{}
 		keyColumns map[string][]string
 		discovery  map[string]*sqlcapture.DiscoveryInfo
+		metadata   map[string]*postgresTableMetadata
 	}
 }
 
+// postgresTableMetadata is the per-table metadata which is persisted in the
+// capture state, so that changes to the columns of a table can be detected
+// even when they occur while the capture isn't running.
+type postgresTableMetadata struct {
+	Schema postgresTableSchema `json:"schema"`
+}
+
+type postgresTableSchema struct {
+	Columns     []string           `json:"columns"`
+	ColumnTypes map[string]*uint32 `json:"types"` // Column type OIDs, set to nil for dropped columns so that JSON patch merging deletes them.
+}
+
 const standbyStatusInterval = 10 * time.Second
 
 // replicationBufferSize controls how many change events can be buffered in the
@@ -218,6 +287,7 @@ func (s *replicationStream) StartReplication(ctx context.Context) error {
 		var closeCtx, cancel = context.WithTimeout(context.Background(), 1*time.Second)
 		s.conn.Close(closeCtx)
 		cancel()
+		s.discardTransactions()
 		close(s.events)
 		s.errCh <- err
 	}()
@@ -256,15 +326,28 @@ func (s *replicationStream) run(ctx context.Context) error {
 // sent.
 func (s *replicationStream) relayMessages(ctx context.Context) error {
 	for {
-		// If there's already a change event which needs to be sent to the consumer,
+		// If there are already change events which need to be sent to the consumer,
 		// try to do so until/unless the context expires first.
-		if s.eventBuf != nil {
+		for len(s.eventBuf) > 0 {
 			select {
 			case <-ctx.Done():
 				return nil
-			case s.events <- s.eventBuf:
-				s.eventBuf = nil
+			case s.events <- s.eventBuf[0]:
+				s.eventBuf[0] = nil
+				s.eventBuf = s.eventBuf[1:]
+			}
+		}
+
+		// While a committed transaction is being replayed from its buffer, decode
+		// the next buffered message rather than receiving a new one.
+		if s.replay != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := s.replayNext(); err != nil {
+				return fmt.Errorf("error decoding message: %w", err)
 			}
+			continue
 		}
 
 		// In tbe absence of a buffered message, go try to receive another from
@@ -277,17 +360,27 @@ func (s *replicationStream) relayMessages(ctx context.Context) error {
 			return err
 		}
 
-		// Once a message arrives, decode it and buffer the result until the next
+		// Once a message arrives, decode it and buffer the results until the next
 		// time this function is invoked.
-		event, err := s.decodeMessage(lsn, msg)
-		if err != nil {
+		if err := s.decodeMessage(lsn, msg); err != nil {
 			return fmt.Errorf("error decoding message: %w", err)
 		}
-		s.eventBuf = event
 	}
 }
 
-func (s *replicationStream) decodeMessage(lsn pglogrepl.LSN, msg pglogrepl.Message) (sqlcapture.DatabaseEvent, error) {
+// queueEvent appends a decoded event, if there is one, to the buffer of events
+// awaiting relay to the consumer.
+func (s *replicationStream) queueEvent(event sqlcapture.DatabaseEvent, err error) error {
+	if err != nil {
+		return err
+	}
+	if event != nil {
+		s.eventBuf = append(s.eventBuf, event)
+	}
+	return nil
+}
+
+func (s *replicationStream) decodeMessage(lsn pglogrepl.LSN, msg pglogrepl.Message) error {
 	// Some notes on the Logical Replication / pgoutput message stream, since
 	// as far as I can tell this isn't documented anywhere but comments in the
 	// relevant PostgreSQL sources.
@@ -312,69 +405,89 @@ func (s *replicationStream) decodeMessage(lsn pglogrepl.LSN, msg pglogrepl.Messa
 	switch msg := msg.(type) {
 	case *pglogrepl.RelationMessage:
 		s.relations[msg.RelationID] = msg
-		return nil, nil
+		return s.handleRelation(msg)
 	case *pglogrepl.OriginMessage:
 		// Origin messages are sent when the postgres instance we're capturing from
 		// is itself replicating from another source instance. They indicate the original
-		// source of the transaction. We just ignore these messages for now, though in the
-		// future it might be desirable to add the origin as a `_meta` property. Sauce:
+		// source of the transaction, and are reported in the `_meta` of its changes. Sauce:
 		// https://www.highgo.ca/2020/04/18/the-origin-in-postgresql-logical-decoding/
 		logrus.WithFields(logrus.Fields{
 			"originName": msg.Name,
 			"originLSN":  msg.CommitLSN,
-		}).Trace("ignoring Origin message")
-		return nil, nil
+		}).Trace("transaction origin")
+		if s.streaming.current != nil {
+			s.streaming.current.origin = msg.Name
+		} else if s.preparing != nil {
+			s.preparing.origin = msg.Name
+		} else {
+			s.nextTxnOrigin = msg.Name
+		}
+		return nil
 	case *pglogrepl.TypeMessage:
 		logrus.WithFields(logrus.Fields{
 			"datatype":  msg.DataType,
 			"namespace": msg.Namespace,
 			"name":      msg.Name,
 		}).Trace("user type definition")
-		return nil, nil
+		return nil
 	case *pglogrepl.BeginMessage:
 		if s.nextTxnFinalLSN != 0 {
-			return nil, fmt.Errorf("got BEGIN message while another transaction in progress")
+			return fmt.Errorf("got BEGIN message while another transaction in progress")
 		}
 		s.nextTxnFinalLSN = msg.FinalLSN
 		s.nextTxnMillis = msg.CommitTime.UnixMilli()
 		s.nextTxnXID = msg.Xid
-		return nil, nil
+		return nil
 	case *pglogrepl.InsertMessage:
-		return s.decodeChangeEvent(sqlcapture.InsertOp, lsn, 0, nil, msg.Tuple, msg.RelationID)
+		return s.queueEvent(s.decodeChangeEvent(sqlcapture.InsertOp, lsn, 0, nil, msg.Tuple, msg.RelationID))
 	case *pglogrepl.UpdateMessage:
-		return s.decodeChangeEvent(sqlcapture.UpdateOp, lsn, msg.OldTupleType, msg.OldTuple, msg.NewTuple, msg.RelationID)
+		return s.queueEvent(s.decodeChangeEvent(sqlcapture.UpdateOp, lsn, msg.OldTupleType, msg.OldTuple, msg.NewTuple, msg.RelationID))
 	case *pglogrepl.DeleteMessage:
-		return s.decodeChangeEvent(sqlcapture.DeleteOp, lsn, msg.OldTupleType, msg.OldTuple, nil, msg.RelationID)
+		return s.queueEvent(s.decodeChangeEvent(sqlcapture.DeleteOp, lsn, msg.OldTupleType, msg.OldTuple, nil, msg.RelationID))
 	case *pglogrepl.CommitMessage:
 		if s.nextTxnFinalLSN == 0 {
-			return nil, fmt.Errorf("got COMMIT message without a transaction in progress")
+			return fmt.Errorf("got COMMIT message without a transaction in progress")
 		} else if s.nextTxnFinalLSN != msg.CommitLSN {
-			return nil, fmt.Errorf("got COMMIT message with unexpected CommitLSN (%d; expected %d)",
+			return fmt.Errorf("got COMMIT message with unexpected CommitLSN (%d; expected %d)",
 				msg.CommitLSN, s.nextTxnFinalLSN)
 		}
 		s.nextTxnFinalLSN = 0
 		s.nextTxnMillis = 0
 		s.nextTxnXID = 0
+		s.nextTxnOrigin = ""
 		s.lastTxnEndLSN = msg.TransactionEndLSN
-		var event = &sqlcapture.FlushEvent{
-			Cursor: s.lastTxnEndLSN.String(),
-		}
 		logrus.WithField("lsn", s.lastTxnEndLSN).Debug("commit event")
-		return event, nil
+		return s.queueEvent(s.flushEvent(), nil)
 	case *pglogrepl.TruncateMessage:
+		if s.nextTxnFinalLSN == 0 {
+			return fmt.Errorf("got TRUNCATE message without a transaction in progress")
+		}
 		for _, relID := range msg.RelationIDs {
-			var relation = s.relations[relID]
+			var relation, ok = s.relation(relID)
+			if !ok {
+				return fmt.Errorf("unknown relation ID %d", relID)
+			}
 			var streamID = sqlcapture.JoinStreamID(relation.Namespace, relation.RelationName)
-			if s.tableActive(streamID) {
-				logrus.WithField("table", streamID).Warn("ignoring TRUNCATE on active table")
+			if !s.tableActive(streamID) || s.originExcluded() {
+				continue
+			}
+			// How the TRUNCATE should be handled is a per-binding decision which
+			// is made by the generic sqlcapture logic.
+			logrus.WithField("table", streamID).Info("observed TRUNCATE on active table")
+			var event = &sqlcapture.TruncateEvent{Source: s.eventSource(streamID, relation, lsn)}
+			if err := s.queueEvent(event, nil); err != nil {
+				return err
 			}
 		}
-		return nil, nil
+		return nil
+	case *bufferedMessage, *streamStartMessage, *streamStopMessage, *streamAbortMessage, *streamCommitMessage,
+		*beginPrepareMessage, *prepareMessage, *commitPreparedMessage, *rollbackPreparedMessage:
+		return s.decodeTransactionMessage(lsn, msg)
 	}
 
 	// Unhandled messages are considered a fatal error. There are a bunch of
 	// oddball message types that aren't currently implemented in this connector
-	// (e.g. truncate, streaming transactions, or two-phase commits) and if we
+	// (e.g. logical decoding messages) and if we
 	// blithely ignored them and continued we're pretty much guaranteed to end
 	// up in an inconsistent state with the Postgres tables. Much better to die
 	// quickly and give humans a chance to fix things.
@@ -398,7 +511,7 @@ func (s *replicationStream) decodeMessage(lsn pglogrepl.LSN, msg pglogrepl.Messa
 	//
 	//      But of course if this wasn't a one-off event it will fail again the
 	//      next time a problematic statement is executed on this table.
-	return nil, fmt.Errorf("unhandled message type %q: %v", msg.Type(), msg)
+	return fmt.Errorf("unhandled message type %q: %v", msg.Type(), msg)
 }
 
 func (s *replicationStream) decodeChangeEvent(
@@ -412,7 +525,7 @@ func (s *replicationStream) decodeChangeEvent(
 		return nil, fmt.Errorf("got %q message without a transaction in progress", op)
 	}
 
-	var rel, ok = s.relations[relID]
+	var rel, ok = s.relation(relID)
 	if !ok {
 		return nil, fmt.Errorf("unknown relation ID %d", relID)
 	}
@@ -424,6 +537,12 @@ func (s *replicationStream) decodeChangeEvent(
 		return nil, nil
 	}
 
+	// Likewise skip changes which were replicated into this database from one
+	// of the excluded origins, so that bidirectional replication doesn't loop.
+	if s.originExcluded() {
+		return nil, nil
+	}
+
 	bf, err := s.decodeTuple(before, beforeType, rel, nil)
 	if err != nil {
 		return nil, fmt.Errorf("'before' tuple: %w", err)
@@ -447,7 +566,7 @@ func (s *replicationStream) decodeChangeEvent(
 		return nil, fmt.Errorf("error encoding row key for %q: %w", streamID, err)
 	}
 
-	discovery, ok := s.tables.discovery[streamID]
+	discovery, ok := s.discoveryInfo(streamID)
 	if !ok {
 		return nil, fmt.Errorf("unknown discovery info for stream %q", streamID)
 	}
@@ -458,6 +577,62 @@ func (s *replicationStream) decodeChangeEvent(
 		return nil, fmt.Errorf("error translating 'after' tuple: %w", err)
 	}
 
+	var event = &sqlcapture.ChangeEvent{
+		Operation: op,
+		RowKey:    rowKey,
+		Source:    s.eventSource(streamID, rel, lsn),
+		Before:    bf,
+		After:     af,
+	}
+	return event, nil
+}
+
+// relation returns the current description of a relation. While a buffered
+// transaction is being replayed, relation messages from within that transaction
+// take precedence.
+func (s *replicationStream) relation(relID uint32) (*pglogrepl.RelationMessage, bool) {
+	if s.replay != nil {
+		if rel, ok := s.replay.relations[relID]; ok {
+			return rel, true
+		}
+	}
+	var rel, ok = s.relations[relID]
+	return rel, ok
+}
+
+// flushEvent returns a FlushEvent for the end of the most recently completed
+// transaction. While any transactions remain prepared but not yet committed or
+// rolled back, the cursor is held back to before the first of them so that the
+// database will send them again if the capture restarts.
+func (s *replicationStream) flushEvent() *sqlcapture.FlushEvent {
+	var cursor = s.lastTxnEndLSN
+	for _, txn := range s.prepared {
+		if txn.holdLSN < cursor {
+			cursor = txn.holdLSN
+		}
+	}
+	return &sqlcapture.FlushEvent{Cursor: cursor.String()}
+}
+
+// keepaliveFlush advances the cursor to the WAL position reported by a keepalive
+// message, if no transaction is currently being received. The server only reports
+// positions up to which every committed transaction has already been sent, so this
+// is safe, and it's what allows positional watermarks to be reached while the
+// database is otherwise idle.
+func (s *replicationStream) keepaliveFlush(serverWALEnd pglogrepl.LSN) {
+	if s.nextTxnFinalLSN != 0 || s.streaming.current != nil || s.preparing != nil || s.replay != nil {
+		return
+	}
+	if serverWALEnd > s.lastTxnEndLSN {
+		logrus.WithField("lsn", serverWALEnd).Trace("keepalive flush")
+		s.lastTxnEndLSN = serverWALEnd
+		s.eventBuf = append(s.eventBuf, s.flushEvent())
+	}
+}
+
+// eventSource constructs the source metadata of an event at the provided
+// LSN within the transaction currently being processed.
+func (s *replicationStream) eventSource(streamID string, rel *pglogrepl.RelationMessage, lsn pglogrepl.LSN) *postgresSource {
 	var sourceInfo = &postgresSource{
 		SourceCommon: sqlcapture.SourceCommon{
 			Millis:   s.nextTxnMillis,
@@ -470,18 +645,19 @@ func (s *replicationStream) decodeChangeEvent(
 			int(lsn),
 			int(s.nextTxnFinalLSN),
 		},
+		xid: s.nextTxnXID,
 	}
 	if s.db.includeTxIDs[streamID] {
 		sourceInfo.TxID = s.nextTxnXID
 	}
-	var event = &sqlcapture.ChangeEvent{
-		Operation: op,
-		RowKey:    rowKey,
-		Source:    sourceInfo,
-		Before:    bf,
-		After:     af,
-	}
-	return event, nil
+	sourceInfo.Origin = s.nextTxnOrigin
+	return sourceInfo
+}
+
+// originExcluded returns true if the transaction currently being processed was
+// replicated from an origin whose changes should not be captured.
+func (s *replicationStream) originExcluded() bool {
+	return s.nextTxnOrigin != "" && slices.Contains(s.db.config.Advanced.ExcludeOrigins, s.nextTxnOrigin)
 }
 
 func (s *replicationStream) decodeTuple(
@@ -591,12 +767,15 @@ func (s *replicationStream) receiveMessage(ctx context.Context) (pglogrepl.LSN,
 				if pkm.ReplyRequested {
 					s.standbyStatusDeadline = time.Now()
 				}
+				if s.db.config.Advanced.ReadOnlyWatermarks {
+					s.keepaliveFlush(pkm.ServerWALEnd)
+				}
 			case pglogrepl.XLogDataByteID:
 				var xld, err = pglogrepl.ParseXLogData(msg.Data[1:])
 				if err != nil {
 					return 0, nil, fmt.Errorf("error parsing XLogData: %w", err)
 				}
-				msg, err := pglogrepl.Parse(xld.WALData)
+				msg, err := s.parseMessage(xld.WALData)
 				if err != nil {
 					return 0, nil, fmt.Errorf("error parsing logical replication message: %w", err)
 				}
@@ -624,15 +803,142 @@ func (s *replicationStream) keyColumns(streamID string) ([]string, bool) {
 	return keyColumns, ok
 }
 
+func (s *replicationStream) discoveryInfo(streamID string) (*sqlcapture.DiscoveryInfo, bool) {
+	s.tables.RLock()
+	defer s.tables.RUnlock()
+	var info, ok = s.tables.discovery[streamID]
+	return info, ok
+}
+
 func (s *replicationStream) ActivateTable(ctx context.Context, streamID string, keyColumns []string, discovery *sqlcapture.DiscoveryInfo, metadataJSON json.RawMessage) error {
+	// If metadata JSON is present then parse it into a usable object. Otherwise the
+	// metadata will be initialized from the first relation message for the table.
+	var metadata *postgresTableMetadata
+	if metadataJSON != nil {
+		if err := json.Unmarshal(metadataJSON, &metadata); err != nil {
+			return fmt.Errorf("error parsing metadata JSON: %w", err)
+		}
+	}
+
 	s.tables.Lock()
 	s.tables.active[streamID] = struct{}{}
 	s.tables.keyColumns[streamID] = keyColumns
 	s.tables.discovery[streamID] = discovery
+	if metadata != nil {
+		s.tables.metadata[streamID] = metadata
+	}
 	s.tables.Unlock()
 	return nil
 }
 
+// handleRelation compares the columns of a relation message against the persisted
+// metadata of the corresponding table, if it's active. When columns have been added,
+// dropped, or altered the table's metadata and discovery info are updated and a
+// MetadataEvent is emitted to persist the new shape of the table.
+func (s *replicationStream) handleRelation(rel *pglogrepl.RelationMessage) error {
+	var streamID = sqlcapture.JoinStreamID(rel.Namespace, rel.RelationName)
+
+	s.tables.Lock()
+	defer s.tables.Unlock()
+	if _, ok := s.tables.active[streamID]; !ok {
+		return nil
+	}
+
+	var meta = s.tables.metadata[streamID]
+	var initializing = meta == nil || meta.Schema.Columns == nil
+	if meta == nil {
+		meta = &postgresTableMetadata{}
+	}
+	if meta.Schema.ColumnTypes == nil {
+		meta.Schema.ColumnTypes = make(map[string]*uint32)
+	}
+
+	var logEntry = logrus.WithField("stream", streamID)
+	var columns []string
+	var altered = make(map[string]bool)
+	for _, col := range rel.Columns {
+		var dataType = col.DataType
+		var prevType = meta.Schema.ColumnTypes[col.Name]
+		if !initializing {
+			if prevType == nil {
+				logEntry.WithFields(logrus.Fields{"column": col.Name, "type": dataType}).Info("column added")
+				altered[col.Name] = true
+			} else if *prevType != dataType {
+				logEntry.WithFields(logrus.Fields{"column": col.Name, "type": dataType, "prevType": *prevType}).Info("column type changed")
+				altered[col.Name] = true
+			}
+		}
+		columns = append(columns, col.Name)
+		meta.Schema.ColumnTypes[col.Name] = &dataType
+	}
+	var dropped bool
+	for _, name := range meta.Schema.Columns {
+		if slices.Contains(columns, name) {
+			continue
+		}
+		if slices.Contains(s.tables.keyColumns[streamID], name) {
+			return fmt.Errorf("key column %q of table %q was dropped", name, streamID)
+		}
+		logEntry.WithField("column", name).Info("column dropped")
+		meta.Schema.ColumnTypes[name] = nil
+		dropped = true
+	}
+	if !initializing && !dropped && len(altered) == 0 {
+		return nil
+	}
+
+	meta.Schema.Columns = columns
+	s.tables.metadata[streamID] = meta
+	if !initializing {
+		s.tables.discovery[streamID] = s.updatedDiscoveryInfo(s.tables.discovery[streamID], rel, altered)
+	}
+
+	var bs, err = json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("error serializing metadata JSON for %q: %w", streamID, err)
+	}
+	return s.queueEvent(&sqlcapture.MetadataEvent{
+		StreamID: streamID,
+		Metadata: json.RawMessage(bs),
+	}, nil)
+}
+
+// updatedDiscoveryInfo returns a copy of a table's discovery info with its columns
+// replaced by those of a relation message. Columns which were not altered retain
+// their discovered information, while new or altered columns are described using
+// the name of their current type so that their values are translated accordingly.
+func (s *replicationStream) updatedDiscoveryInfo(info *sqlcapture.DiscoveryInfo, rel *pglogrepl.RelationMessage, altered map[string]bool) *sqlcapture.DiscoveryInfo {
+	var updated = &sqlcapture.DiscoveryInfo{Schema: rel.Namespace, Name: rel.RelationName}
+	if info != nil {
+		*updated = *info
+	}
+	updated.Columns = make(map[string]sqlcapture.ColumnInfo)
+	updated.ColumnNames = nil
+	for idx, col := range rel.Columns {
+		var colInfo, ok = sqlcapture.ColumnInfo{}, false
+		if info != nil {
+			colInfo, ok = info.Columns[col.Name]
+		}
+		if !ok || altered[col.Name] {
+			var dataType interface{} = fmt.Sprintf("%d", col.DataType)
+			if dt, ok := s.connInfo.DataTypeForOID(col.DataType); ok {
+				dataType = dt.Name
+			}
+			colInfo = sqlcapture.ColumnInfo{
+				Name:        col.Name,
+				TableName:   rel.RelationName,
+				TableSchema: rel.Namespace,
+				IsNullable:  true,
+				DataType:    dataType,
+			}
+		}
+		colInfo.Index = idx + 1
+		updated.Columns[col.Name] = colInfo
+		updated.ColumnNames = append(updated.ColumnNames, col.Name)
+	}
+	return updated
+}
+
 // Acknowledge informs the ReplicationStream that all messages up to the specified
 // LSN [1] have been persisted, and that a future restart will never need to return
 // to older portions of the transaction log. This fact will be communicated to the
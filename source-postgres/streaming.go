@@ -0,0 +1,637 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/sirupsen/logrus"
+)
+
+// Message types of the logical replication protocol which aren't implemented by
+// the version of pglogrepl we use. These are the streamed (in-progress) transaction
+// messages of protocol version 2, and the two-phase commit messages of protocol
+// version 3. See:
+//
+//	https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+const (
+	msgTypeStreamStart      = pglogrepl.MessageType('S')
+	msgTypeStreamStop       = pglogrepl.MessageType('E')
+	msgTypeStreamCommit     = pglogrepl.MessageType('c')
+	msgTypeStreamAbort      = pglogrepl.MessageType('A')
+	msgTypeBeginPrepare     = pglogrepl.MessageType('b')
+	msgTypePrepare          = pglogrepl.MessageType('P')
+	msgTypeCommitPrepared   = pglogrepl.MessageType('K')
+	msgTypeRollbackPrepared = pglogrepl.MessageType('r')
+	msgTypeStreamPrepare    = pglogrepl.MessageType('p')
+)
+
+// streamingSpillThreshold controls how many bytes of messages from a single streamed
+// or prepared transaction will be buffered in memory before the buffer is spilled to
+// a temporary file on local disk. In normal use it's a constant, it's just a variable
+// so that tests can exercise the spilling logic.
+var streamingSpillThreshold = 64 * 1024 * 1024
+
+type streamStartMessage struct {
+	Xid          uint32 // XID of the streamed transaction.
+	FirstSegment bool   // True if this is the first stream block of the transaction.
+}
+
+type streamStopMessage struct{}
+
+type streamCommitMessage struct {
+	Xid               uint32
+	CommitLSN         pglogrepl.LSN
+	TransactionEndLSN pglogrepl.LSN
+	CommitTime        time.Time
+}
+
+type streamAbortMessage struct {
+	Xid    uint32 // XID of the streamed transaction.
+	SubXid uint32 // XID of the aborted subtransaction, which is the same as Xid if the whole transaction aborted.
+}
+
+type beginPrepareMessage struct {
+	PrepareLSN    pglogrepl.LSN
+	EndPrepareLSN pglogrepl.LSN
+	PrepareTime   time.Time
+	Xid           uint32
+	GID           string
+}
+
+// prepareMessage represents both Prepare and Stream Prepare messages, which
+// are encoded identically.
+type prepareMessage struct {
+	Streamed      bool
+	PrepareLSN    pglogrepl.LSN
+	EndPrepareLSN pglogrepl.LSN
+	PrepareTime   time.Time
+	Xid           uint32
+	GID           string
+}
+
+type commitPreparedMessage struct {
+	CommitLSN         pglogrepl.LSN
+	TransactionEndLSN pglogrepl.LSN
+	CommitTime        time.Time
+	Xid               uint32
+	GID               string
+}
+
+type rollbackPreparedMessage struct {
+	EndPrepareLSN  pglogrepl.LSN
+	EndRollbackLSN pglogrepl.LSN
+	Xid            uint32
+	GID            string
+}
+
+// bufferedMessage is a message which belongs to a streamed or prepared transaction,
+// and must be buffered until we learn whether that transaction commits. The message
+// data is left unparsed, and if it was part of a stream block the XID which precedes
+// the message contents has been removed so that it can later be parsed normally.
+type bufferedMessage struct {
+	Xid  uint32 // XID of the (sub)transaction to which the message belongs.
+	Data []byte // Message data, beginning with the message type byte.
+}
+
+func (m *streamStartMessage) Type() pglogrepl.MessageType      { return msgTypeStreamStart }
+func (m *streamStopMessage) Type() pglogrepl.MessageType       { return msgTypeStreamStop }
+func (m *streamCommitMessage) Type() pglogrepl.MessageType     { return msgTypeStreamCommit }
+func (m *streamAbortMessage) Type() pglogrepl.MessageType      { return msgTypeStreamAbort }
+func (m *beginPrepareMessage) Type() pglogrepl.MessageType     { return msgTypeBeginPrepare }
+func (m *commitPreparedMessage) Type() pglogrepl.MessageType   { return msgTypeCommitPrepared }
+func (m *rollbackPreparedMessage) Type() pglogrepl.MessageType { return msgTypeRollbackPrepared }
+func (m *bufferedMessage) Type() pglogrepl.MessageType         { return pglogrepl.MessageType(m.Data[0]) }
+
+func (m *prepareMessage) Type() pglogrepl.MessageType {
+	if m.Streamed {
+		return msgTypeStreamPrepare
+	}
+	return msgTypePrepare
+}
+
+// protocolDecoder is a helper for decoding the fixed-layout messages above.
+type protocolDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *protocolDecoder) take(n int) []byte {
+	if d.err != nil {
+		return make([]byte, n)
+	}
+	if len(d.buf) < n {
+		d.err = fmt.Errorf("message truncated")
+		return make([]byte, n)
+	}
+	var bs = d.buf[:n]
+	d.buf = d.buf[n:]
+	return bs
+}
+
+func (d *protocolDecoder) uint8() uint8   { return d.take(1)[0] }
+func (d *protocolDecoder) uint32() uint32 { return binary.BigEndian.Uint32(d.take(4)) }
+func (d *protocolDecoder) lsn() pglogrepl.LSN {
+	return pglogrepl.LSN(binary.BigEndian.Uint64(d.take(8)))
+}
+func (d *protocolDecoder) timestamp() time.Time {
+	return pgTimestamp(int64(binary.BigEndian.Uint64(d.take(8))))
+}
+
+func (d *protocolDecoder) string() string {
+	for idx, b := range d.buf {
+		if b == 0 {
+			var str = string(d.buf[:idx])
+			d.buf = d.buf[idx+1:]
+			return str
+		}
+	}
+	if d.err == nil {
+		d.err = fmt.Errorf("unterminated string")
+	}
+	return ""
+}
+
+// pgTimestamp converts a PostgreSQL timestamp (microseconds since 2000-01-01) into a time.Time.
+func pgTimestamp(microsSinceY2K int64) time.Time {
+	const microsFromUnixEpochToY2K = 946684800 * 1000000
+	return time.UnixMicro(microsFromUnixEpochToY2K + microsSinceY2K)
+}
+
+// parseProtocolMessage parses the messages which aren't implemented by pglogrepl,
+// returning a nil message if the data is of some other message type.
+func parseProtocolMessage(data []byte) (pglogrepl.Message, error) {
+	var d = &protocolDecoder{buf: data[1:]}
+	var msg pglogrepl.Message
+	switch pglogrepl.MessageType(data[0]) {
+	case msgTypeStreamStart:
+		msg = &streamStartMessage{Xid: d.uint32(), FirstSegment: d.uint8() == 1}
+	case msgTypeStreamStop:
+		msg = &streamStopMessage{}
+	case msgTypeStreamCommit:
+		var m = &streamCommitMessage{Xid: d.uint32()}
+		d.uint8() // Flags, currently unused
+		m.CommitLSN, m.TransactionEndLSN, m.CommitTime = d.lsn(), d.lsn(), d.timestamp()
+		msg = m
+	case msgTypeStreamAbort:
+		msg = &streamAbortMessage{Xid: d.uint32(), SubXid: d.uint32()}
+	case msgTypeBeginPrepare:
+		msg = &beginPrepareMessage{PrepareLSN: d.lsn(), EndPrepareLSN: d.lsn(), PrepareTime: d.timestamp(), Xid: d.uint32(), GID: d.string()}
+	case msgTypePrepare, msgTypeStreamPrepare:
+		d.uint8() // Flags, currently unused
+		msg = &prepareMessage{
+			Streamed:      pglogrepl.MessageType(data[0]) == msgTypeStreamPrepare,
+			PrepareLSN:    d.lsn(),
+			EndPrepareLSN: d.lsn(),
+			PrepareTime:   d.timestamp(),
+			Xid:           d.uint32(),
+			GID:           d.string(),
+		}
+	case msgTypeCommitPrepared:
+		d.uint8() // Flags, currently unused
+		msg = &commitPreparedMessage{CommitLSN: d.lsn(), TransactionEndLSN: d.lsn(), CommitTime: d.timestamp(), Xid: d.uint32(), GID: d.string()}
+	case msgTypeRollbackPrepared:
+		d.uint8() // Flags, currently unused
+		var m = &rollbackPreparedMessage{EndPrepareLSN: d.lsn(), EndRollbackLSN: d.lsn()}
+		d.timestamp() // Prepare timestamp
+		d.timestamp() // Rollback timestamp
+		m.Xid, m.GID = d.uint32(), d.string()
+		msg = m
+	default:
+		return nil, nil
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("error parsing message of type %q: %w", data[0], d.err)
+	}
+	return msg, nil
+}
+
+// parseMessage parses a logical replication message. While a stream block or a
+// prepared transaction is being received, the messages belonging to it are left
+// unparsed and returned as a bufferedMessage.
+func (s *replicationStream) parseMessage(data []byte) (pglogrepl.Message, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty logical replication message")
+	}
+	if msg, err := parseProtocolMessage(data); err != nil || msg != nil {
+		return msg, err
+	}
+
+	var msgType = pglogrepl.MessageType(data[0])
+	switch msgType {
+	case pglogrepl.MessageTypeRelation, pglogrepl.MessageTypeType, pglogrepl.MessageTypeInsert,
+		pglogrepl.MessageTypeUpdate, pglogrepl.MessageTypeDelete, pglogrepl.MessageTypeTruncate:
+		if s.streaming.current != nil {
+			// Within a stream block these messages are prefixed with the XID of the
+			// (sub)transaction to which they belong.
+			if len(data) < 5 {
+				return nil, fmt.Errorf("streamed message of type %q truncated", msgType)
+			}
+			var body = make([]byte, 0, len(data)-4)
+			body = append(append(body, data[0]), data[5:]...)
+			return &bufferedMessage{Xid: binary.BigEndian.Uint32(data[1:5]), Data: body}, nil
+		} else if s.preparing != nil {
+			return &bufferedMessage{Xid: s.preparing.xid, Data: append([]byte(nil), data...)}, nil
+		}
+	case pglogrepl.MessageTypeBegin, pglogrepl.MessageTypeCommit, pglogrepl.MessageTypeOrigin:
+	default:
+		return nil, fmt.Errorf("unhandled message type %q", msgType)
+	}
+
+	var msg, err = pglogrepl.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if msg, ok := msg.(*pglogrepl.RelationMessage); ok {
+		// Remember the raw relation description so that it can be written into the
+		// buffer of any subsequent prepared transaction which modifies the relation.
+		s.relationData[msg.RelationID] = append([]byte(nil), data...)
+	}
+	return msg, nil
+}
+
+// decodeTransactionMessage handles the messages of streamed and prepared transactions.
+func (s *replicationStream) decodeTransactionMessage(lsn pglogrepl.LSN, msg pglogrepl.Message) error {
+	switch msg := msg.(type) {
+	case *bufferedMessage:
+		return s.bufferMessage(lsn, msg)
+	case *streamStartMessage:
+		if s.streaming.current != nil {
+			return fmt.Errorf("got STREAM START message while another stream block in progress")
+		}
+		var txn, ok = s.streaming.txns[msg.Xid]
+		if !ok {
+			logrus.WithField("xid", msg.Xid).Debug("streamed transaction started")
+			txn = newTxnBuffer(msg.Xid)
+			s.streaming.txns[msg.Xid] = txn
+		}
+		s.streaming.current = txn
+		return nil
+	case *streamStopMessage:
+		if s.streaming.current == nil {
+			return fmt.Errorf("got STREAM STOP message without a stream block in progress")
+		}
+		s.streaming.current = nil
+		return nil
+	case *streamAbortMessage:
+		var txn, ok = s.streaming.txns[msg.Xid]
+		if !ok {
+			return fmt.Errorf("got STREAM ABORT message for unknown transaction %d", msg.Xid)
+		}
+		if msg.SubXid != 0 && msg.SubXid != msg.Xid {
+			logrus.WithFields(logrus.Fields{"xid": msg.Xid, "subxid": msg.SubXid}).Debug("streamed subtransaction aborted")
+			txn.aborted[msg.SubXid] = true
+			return nil
+		}
+		logrus.WithFields(logrus.Fields{"xid": msg.Xid, "messages": txn.count}).Debug("streamed transaction aborted")
+		delete(s.streaming.txns, msg.Xid)
+		return txn.discard()
+	case *streamCommitMessage:
+		var txn, ok = s.streaming.txns[msg.Xid]
+		if !ok {
+			return fmt.Errorf("got STREAM COMMIT message for unknown transaction %d", msg.Xid)
+		}
+		delete(s.streaming.txns, msg.Xid)
+		return s.startReplay(txn, msg.CommitLSN, msg.TransactionEndLSN, msg.CommitTime)
+	case *beginPrepareMessage:
+		if s.nextTxnFinalLSN != 0 || s.preparing != nil {
+			return fmt.Errorf("got BEGIN PREPARE message while another transaction in progress")
+		}
+		s.preparing = newTxnBuffer(msg.Xid)
+		s.preparing.gid = msg.GID
+		return nil
+	case *prepareMessage:
+		var txn *txnBuffer
+		if msg.Streamed {
+			txn = s.streaming.txns[msg.Xid]
+			delete(s.streaming.txns, msg.Xid)
+		} else if s.preparing != nil && s.preparing.xid == msg.Xid {
+			txn, s.preparing = s.preparing, nil
+		}
+		if txn == nil {
+			return fmt.Errorf("got PREPARE message for unknown transaction %d", msg.Xid)
+		}
+		// Once the replication cursor is acknowledged past the end of the PREPARE,
+		// the database will never send us the contents of this transaction again.
+		// So until it's resolved, the reported cursor is held back to the end of
+		// the last transaction preceding it.
+		txn.gid = msg.GID
+		txn.holdLSN = s.lastTxnEndLSN
+		logrus.WithFields(logrus.Fields{"xid": msg.Xid, "gid": msg.GID, "messages": txn.count}).Info("transaction prepared")
+		s.prepared[msg.Xid] = txn
+		return nil
+	case *commitPreparedMessage:
+		var txn, ok = s.prepared[msg.Xid]
+		if !ok {
+			// This can happen only if the transaction was prepared before the
+			// capture's initial replication cursor, in which case the changes
+			// it made will be observed by the backfill of the relevant tables.
+			logrus.WithFields(logrus.Fields{"xid": msg.Xid, "gid": msg.GID}).Warn("ignoring COMMIT PREPARED of a transaction prepared before replication started")
+			s.lastTxnEndLSN = msg.TransactionEndLSN
+			return s.queueEvent(s.flushEvent(), nil)
+		}
+		delete(s.prepared, msg.Xid)
+		return s.startReplay(txn, msg.CommitLSN, msg.TransactionEndLSN, msg.CommitTime)
+	case *rollbackPreparedMessage:
+		if txn, ok := s.prepared[msg.Xid]; ok {
+			logrus.WithFields(logrus.Fields{"xid": msg.Xid, "gid": msg.GID}).Info("prepared transaction rolled back")
+			delete(s.prepared, msg.Xid)
+			if err := txn.discard(); err != nil {
+				return err
+			}
+		}
+		s.lastTxnEndLSN = msg.EndRollbackLSN
+		return s.queueEvent(s.flushEvent(), nil)
+	}
+	return fmt.Errorf("unhandled message type %q: %v", msg.Type(), msg)
+}
+
+// bufferMessage appends a message to the buffer of the streamed or prepared
+// transaction which is currently being received.
+func (s *replicationStream) bufferMessage(lsn pglogrepl.LSN, msg *bufferedMessage) error {
+	var txn = s.streaming.current
+	if txn == nil {
+		txn = s.preparing
+	}
+	if txn == nil {
+		return fmt.Errorf("internal error: buffered message without a transaction in progress")
+	}
+
+	var parsed, err = pglogrepl.Parse(msg.Data)
+	if err != nil {
+		return err
+	}
+	var relIDs []uint32
+	switch parsed := parsed.(type) {
+	case *pglogrepl.RelationMessage:
+		// Relation messages within a stream block only describe the relation for that
+		// streamed transaction, while those of a prepared transaction also apply to
+		// every subsequent transaction.
+		if txn == s.preparing {
+			s.relations[parsed.RelationID] = parsed
+			s.relationData[parsed.RelationID] = msg.Data
+			if err := s.handleRelation(parsed); err != nil {
+				return err
+			}
+		}
+		txn.relations[parsed.RelationID] = true
+	case *pglogrepl.InsertMessage:
+		relIDs = []uint32{parsed.RelationID}
+	case *pglogrepl.UpdateMessage:
+		relIDs = []uint32{parsed.RelationID}
+	case *pglogrepl.DeleteMessage:
+		relIDs = []uint32{parsed.RelationID}
+	case *pglogrepl.TruncateMessage:
+		relIDs = parsed.RelationIDs
+	}
+
+	// Changes will be decoded when the transaction commits, by which point the
+	// relation may have been altered by some other transaction. So if the buffer
+	// doesn't already contain a description of the relation, write the current one.
+	for _, relID := range relIDs {
+		if txn.relations[relID] {
+			continue
+		}
+		var relData, ok = s.relationData[relID]
+		if !ok {
+			return fmt.Errorf("unknown relation ID %d", relID)
+		}
+		if err := txn.append(msg.Xid, lsn, relData); err != nil {
+			return err
+		}
+		txn.relations[relID] = true
+	}
+	return txn.append(msg.Xid, lsn, msg.Data)
+}
+
+// startReplay begins replaying the buffered messages of a committed transaction.
+func (s *replicationStream) startReplay(txn *txnBuffer, commitLSN, endLSN pglogrepl.LSN, commitTime time.Time) error {
+	if s.nextTxnFinalLSN != 0 {
+		return fmt.Errorf("got commit of transaction %d while another transaction in progress", txn.xid)
+	}
+	var replay, err = txn.replay()
+	if err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"xid":      txn.xid,
+		"messages": txn.count,
+		"spilled":  txn.spill != nil,
+	}).Debug("replaying committed transaction")
+	replay.endLSN = endLSN
+	s.replay = replay
+	s.nextTxnFinalLSN = commitLSN
+	s.nextTxnMillis = commitTime.UnixMilli()
+	s.nextTxnXID = txn.xid
+	s.nextTxnOrigin = txn.origin
+	return nil
+}
+
+// replayNext decodes the next buffered message of the transaction being replayed,
+// and commits the transaction once all of its messages have been decoded.
+func (s *replicationStream) replayNext() error {
+	var rec, err = s.replay.next()
+	if err != nil {
+		return fmt.Errorf("error reading buffered transaction %d: %w", s.replay.txn.xid, err)
+	} else if rec == nil {
+		var txn = s.replay.txn
+		s.lastTxnEndLSN = s.replay.endLSN
+		s.nextTxnFinalLSN = 0
+		s.nextTxnMillis = 0
+		s.nextTxnXID = 0
+		s.nextTxnOrigin = ""
+		s.replay = nil
+		if err := txn.discard(); err != nil {
+			return err
+		}
+		logrus.WithField("lsn", s.lastTxnEndLSN).Debug("commit event")
+		return s.queueEvent(s.flushEvent(), nil)
+	}
+
+	msg, err := pglogrepl.Parse(rec.data)
+	if err != nil {
+		return fmt.Errorf("error parsing buffered message: %w", err)
+	}
+	switch msg := msg.(type) {
+	case *pglogrepl.RelationMessage:
+		s.replay.relations[msg.RelationID] = msg
+		return s.handleRelation(msg)
+	case *pglogrepl.TypeMessage:
+		return nil
+	}
+	if s.replay.txn.aborted[rec.xid] {
+		return nil
+	}
+	return s.decodeMessage(rec.lsn, msg)
+}
+
+// discardTransactions releases the buffers of all streamed and prepared transactions.
+func (s *replicationStream) discardTransactions() {
+	var txns []*txnBuffer
+	for _, txn := range s.streaming.txns {
+		txns = append(txns, txn)
+	}
+	for _, txn := range s.prepared {
+		txns = append(txns, txn)
+	}
+	if s.preparing != nil {
+		txns = append(txns, s.preparing)
+	}
+	if s.replay != nil {
+		txns = append(txns, s.replay.txn)
+	}
+	for _, txn := range txns {
+		if err := txn.discard(); err != nil {
+			logrus.WithFields(logrus.Fields{"xid": txn.xid, "err": err}).Warn("error discarding transaction buffer")
+		}
+	}
+}
+
+// txnBuffer holds the messages of a streamed or prepared transaction until we learn
+// whether it commits. Messages are held in memory up to `streamingSpillThreshold`
+// bytes, after which they are all written to a temporary file.
+type txnBuffer struct {
+	xid     uint32
+	gid     string        // Global identifier of a prepared transaction.
+	origin  string        // Replication origin of the transaction, if any.
+	holdLSN pglogrepl.LSN // The cursor may not advance past this LSN while the transaction remains prepared.
+
+	relations map[uint32]bool // Relations whose descriptions have been written into the buffer.
+	aborted   map[uint32]bool // Subtransactions whose changes must be discarded.
+
+	count   int              // Number of buffered messages.
+	size    int              // Size in bytes of the messages buffered in memory.
+	records []bufferedRecord // In-memory messages, if not spilled.
+	spill   *os.File         // Temporary file holding the messages, once spilled.
+	writer  *bufio.Writer    // Writer for appending to the temporary file.
+}
+
+type bufferedRecord struct {
+	xid  uint32
+	lsn  pglogrepl.LSN
+	data []byte
+}
+
+// Each spilled record consists of this header followed by the message data.
+const bufferedRecordHeaderSize = 4 + 8 + 4 // XID, LSN, and data length.
+
+func newTxnBuffer(xid uint32) *txnBuffer {
+	return &txnBuffer{
+		xid:       xid,
+		relations: make(map[uint32]bool),
+		aborted:   make(map[uint32]bool),
+	}
+}
+
+func (b *txnBuffer) append(xid uint32, lsn pglogrepl.LSN, data []byte) error {
+	b.count++
+	if b.spill == nil {
+		b.records = append(b.records, bufferedRecord{xid, lsn, data})
+		b.size += len(data) + bufferedRecordHeaderSize
+		if b.size < streamingSpillThreshold {
+			return nil
+		}
+		var f, err = os.CreateTemp("", "txn-buffer-*")
+		if err != nil {
+			return fmt.Errorf("error creating spill file for transaction %d: %w", b.xid, err)
+		}
+		logrus.WithFields(logrus.Fields{"xid": b.xid, "path": f.Name(), "size": b.size}).Info("spilling transaction buffer to disk")
+		b.spill, b.writer = f, bufio.NewWriter(f)
+		var records = b.records
+		b.records, b.size = nil, 0
+		for _, rec := range records {
+			if err := b.write(rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return b.write(bufferedRecord{xid, lsn, data})
+}
+
+func (b *txnBuffer) write(rec bufferedRecord) error {
+	var header [bufferedRecordHeaderSize]byte
+	binary.BigEndian.PutUint32(header[0:4], rec.xid)
+	binary.BigEndian.PutUint64(header[4:12], uint64(rec.lsn))
+	binary.BigEndian.PutUint32(header[12:16], uint32(len(rec.data)))
+	if _, err := b.writer.Write(header[:]); err != nil {
+		return fmt.Errorf("error writing spill file for transaction %d: %w", b.xid, err)
+	}
+	if _, err := b.writer.Write(rec.data); err != nil {
+		return fmt.Errorf("error writing spill file for transaction %d: %w", b.xid, err)
+	}
+	return nil
+}
+
+// replay returns an iterator over the buffered messages.
+func (b *txnBuffer) replay() (*txnReplay, error) {
+	var r = &txnReplay{txn: b, relations: make(map[uint32]*pglogrepl.RelationMessage)}
+	if b.spill != nil {
+		if err := b.writer.Flush(); err != nil {
+			return nil, fmt.Errorf("error writing spill file for transaction %d: %w", b.xid, err)
+		}
+		if _, err := b.spill.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("error reading spill file for transaction %d: %w", b.xid, err)
+		}
+		r.reader = bufio.NewReader(b.spill)
+	}
+	return r, nil
+}
+
+// discard releases the buffered messages and removes the spill file, if any.
+func (b *txnBuffer) discard() error {
+	b.records = nil
+	if b.spill == nil {
+		return nil
+	}
+	var name = b.spill.Name()
+	b.spill.Close()
+	b.spill, b.writer = nil, nil
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("error removing spill file for transaction %d: %w", b.xid, err)
+	}
+	return nil
+}
+
+// txnReplay iterates over the messages of a committed transaction buffer.
+type txnReplay struct {
+	txn    *txnBuffer
+	endLSN pglogrepl.LSN // End LSN of the transaction's commit.
+	index  int           // Index of the next in-memory record.
+	reader *bufio.Reader // Reader over the spill file, if spilled.
+
+	// Relation descriptions from within the transaction, which take precedence
+	// over the stream-wide relations while the transaction is being decoded.
+	relations map[uint32]*pglogrepl.RelationMessage
+}
+
+// next returns the next buffered record, or nil once all have been returned.
+func (r *txnReplay) next() (*bufferedRecord, error) {
+	if r.reader == nil {
+		if r.index >= len(r.txn.records) {
+			return nil, nil
+		}
+		r.index++
+		return &r.txn.records[r.index-1], nil
+	}
+
+	var header [bufferedRecordHeaderSize]byte
+	if _, err := io.ReadFull(r.reader, header[:]); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var rec = &bufferedRecord{
+		xid:  binary.BigEndian.Uint32(header[0:4]),
+		lsn:  pglogrepl.LSN(binary.BigEndian.Uint64(header[4:12])),
+		data: make([]byte, binary.BigEndian.Uint32(header[12:16])),
+	}
+	if _, err := io.ReadFull(r.reader, rec.data); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
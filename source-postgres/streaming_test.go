@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/estuary/connectors/sqlcapture"
+	"github.com/jackc/pglogrepl"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamedTransactions(t *testing.T) {
+	for _, threshold := range []int{streamingSpillThreshold, 0} {
+		t.Run(fmt.Sprintf("threshold%d", threshold), func(t *testing.T) {
+			defer func(prev int) { streamingSpillThreshold = prev }(streamingSpillThreshold)
+			streamingSpillThreshold = threshold
+
+			var s = newTestReplicationStream()
+			feedMessages(t, s,
+				msgBytes('S', u32(100), []byte{1}),
+				streamed(100, relationMsg(1, "public", "foo")),
+				streamed(100, insertMsg(1, "1")),
+				streamed(101, insertMsg(1, "2")),
+				msgBytes('E'),
+				msgBytes('A', u32(100), u32(101)),
+				msgBytes('S', u32(100), []byte{0}),
+				streamed(100, insertMsg(1, "3")),
+				msgBytes('E'),
+			)
+			require.Empty(t, s.eventBuf)
+			feedMessages(t, s, msgBytes('c', u32(100), []byte{0}, u64(0x1000), u64(0x1010), u64(0)))
+			require.Equal(t, []string{"c:1", "c:3", "flush:0/1010"}, summarizeEvents(s.eventBuf))
+			require.Empty(t, s.streaming.txns)
+		})
+	}
+}
+
+func TestStreamedTransactionAbort(t *testing.T) {
+	var s = newTestReplicationStream()
+	feedMessages(t, s,
+		relationMsg(1, "public", "foo"),
+		msgBytes('S', u32(100), []byte{1}),
+		streamed(100, insertMsg(1, "1")),
+		msgBytes('E'),
+		msgBytes('A', u32(100), u32(100)),
+	)
+	require.Empty(t, s.eventBuf)
+	require.Empty(t, s.streaming.txns)
+}
+
+func TestPreparedTransactions(t *testing.T) {
+	var s = newTestReplicationStream()
+	s.lastTxnEndLSN = 0x100
+	feedMessages(t, s,
+		relationMsg(1, "public", "foo"),
+		msgBytes('b', u64(0x200), u64(0x210), u64(0), u32(100), cstr("gid-100")),
+		insertMsg(1, "1"),
+		msgBytes('P', []byte{0}, u64(0x200), u64(0x210), u64(0), u32(100), cstr("gid-100")),
+		msgBytes('B', u64(0x300), u64(0), u32(101)),
+		insertMsg(1, "2"),
+		msgBytes('C', []byte{0}, u64(0x300), u64(0x310), u64(0)),
+	)
+	// The cursor may not advance past a prepared transaction until it's resolved.
+	require.Equal(t, []string{"c:2", "flush:0/100"}, summarizeEvents(s.eventBuf))
+
+	s.eventBuf = nil
+	feedMessages(t, s,
+		msgBytes('K', []byte{0}, u64(0x400), u64(0x410), u64(0), u32(100), cstr("gid-100")),
+	)
+	require.Equal(t, []string{"c:1", "flush:0/410"}, summarizeEvents(s.eventBuf))
+	require.Empty(t, s.prepared)
+
+	s.eventBuf = nil
+	feedMessages(t, s,
+		msgBytes('b', u64(0x500), u64(0x510), u64(0), u32(102), cstr("gid-102")),
+		insertMsg(1, "3"),
+		msgBytes('P', []byte{0}, u64(0x500), u64(0x510), u64(0), u32(102), cstr("gid-102")),
+		msgBytes('r', []byte{0}, u64(0x510), u64(0x610), u64(0), u64(0), u32(102), cstr("gid-102")),
+	)
+	require.Equal(t, []string{"flush:0/610"}, summarizeEvents(s.eventBuf))
+}
+
+func TestReplicationOrigins(t *testing.T) {
+	var s = newTestReplicationStream()
+	s.db.config.Advanced.ExcludeOrigins = []string{"excluded"}
+	feedMessages(t, s,
+		relationMsg(1, "public", "foo"),
+		msgBytes('B', u64(0x100), u64(0), u32(100)),
+		msgBytes('O', u64(0x50), cstr("upstream")),
+		insertMsg(1, "1"),
+		msgBytes('C', []byte{0}, u64(0x100), u64(0x110), u64(0)),
+		msgBytes('B', u64(0x200), u64(0), u32(101)),
+		msgBytes('O', u64(0x60), cstr("excluded")),
+		insertMsg(1, "2"),
+		msgBytes('C', []byte{0}, u64(0x200), u64(0x210), u64(0)),
+		msgBytes('B', u64(0x300), u64(0), u32(102)),
+		insertMsg(1, "3"),
+		msgBytes('C', []byte{0}, u64(0x300), u64(0x310), u64(0)),
+	)
+	require.Equal(t, []string{"c:1", "flush:0/110", "flush:0/210", "c:3", "flush:0/310"}, summarizeEvents(s.eventBuf))
+	require.Equal(t, "upstream", s.eventBuf[0].(*sqlcapture.ChangeEvent).Source.(*postgresSource).Origin)
+	require.Equal(t, "", s.eventBuf[3].(*sqlcapture.ChangeEvent).Source.(*postgresSource).Origin)
+}
+
+func TestRelationSchemaChanges(t *testing.T) {
+	var s = newTestReplicationStream()
+	feedMessages(t, s,
+		relationMsg(1, "public", "foo"),
+		msgBytes('B', u64(0x100), u64(0), u32(100)),
+		insertMsg(1, "1"),
+		msgBytes('C', []byte{0}, u64(0x100), u64(0x110), u64(0)),
+		relationMsg(1, "public", "foo", testColumn{"ts", pgtype.TimestamptzOID}),
+		msgBytes('B', u64(0x200), u64(0), u32(101)),
+		insertMsg(1, "2", "2024-01-02 03:04:05+00"),
+		msgBytes('C', []byte{0}, u64(0x200), u64(0x210), u64(0)),
+		relationMsg(1, "public", "foo"),
+	)
+	require.Equal(t, []string{"c:1", "flush:0/110", "MetadataEvent", "c:2", "flush:0/210", "MetadataEvent"}, summarizeEvents(s.eventBuf))
+	require.JSONEq(t, `{"schema":{"columns":["id","ts"],"types":{"id":25,"ts":1184}}}`, string(s.eventBuf[2].(*sqlcapture.MetadataEvent).Metadata))
+	require.Equal(t, "2024-01-02T03:04:05Z", s.eventBuf[3].(*sqlcapture.ChangeEvent).After["ts"])
+	require.JSONEq(t, `{"schema":{"columns":["id"],"types":{"id":25,"ts":null}}}`, string(s.eventBuf[5].(*sqlcapture.MetadataEvent).Metadata))
+
+	// Dropping a key column is an error.
+	var msg, err = s.parseMessage(msgBytes('R', u32(1), cstr("public"), cstr("foo"), []byte{'d'}, u16(0)))
+	require.NoError(t, err)
+	require.ErrorContains(t, s.decodeMessage(0, msg), `key column "id" of table "public.foo" was dropped`)
+}
+
+func newTestReplicationStream() *replicationStream {
+	var s = &replicationStream{
+		db:           &postgresDatabase{config: &Config{}},
+		connInfo:     pgtype.NewConnInfo(),
+		relations:    make(map[uint32]*pglogrepl.RelationMessage),
+		relationData: make(map[uint32][]byte),
+		prepared:     make(map[uint32]*txnBuffer),
+	}
+	s.streaming.txns = make(map[uint32]*txnBuffer)
+	s.tables.active = map[string]struct{}{"public.foo": {}}
+	s.tables.keyColumns = map[string][]string{"public.foo": {"id"}}
+	s.tables.discovery = map[string]*sqlcapture.DiscoveryInfo{"public.foo": {}}
+	s.tables.metadata = map[string]*postgresTableMetadata{"public.foo": {Schema: postgresTableSchema{
+		Columns:     []string{"id"},
+		ColumnTypes: map[string]*uint32{"id": ptr(uint32(pgtype.TextOID))},
+	}}}
+	return s
+}
+
+// feedMessages parses and decodes each message as though it had just been
+// received, along with any buffered transaction replay it results in.
+func feedMessages(t *testing.T, s *replicationStream, msgs ...[]byte) {
+	t.Helper()
+	for idx, data := range msgs {
+		var msg, err = s.parseMessage(data)
+		require.NoError(t, err)
+		require.NoError(t, s.decodeMessage(pglogrepl.LSN(idx+1), msg))
+		for s.replay != nil {
+			require.NoError(t, s.replayNext())
+		}
+	}
+}
+
+func summarizeEvents(events []sqlcapture.DatabaseEvent) []string {
+	var summary []string
+	for _, event := range events {
+		switch event := event.(type) {
+		case *sqlcapture.ChangeEvent:
+			summary = append(summary, fmt.Sprintf("%s:%v", event.Operation, event.After["id"]))
+		case *sqlcapture.FlushEvent:
+			summary = append(summary, "flush:"+event.Cursor)
+		default:
+			summary = append(summary, event.String())
+		}
+	}
+	return summary
+}
+
+func msgBytes(msgType byte, parts ...[]byte) []byte {
+	var bs = []byte{msgType}
+	for _, part := range parts {
+		bs = append(bs, part...)
+	}
+	return bs
+}
+
+// streamed inserts the (sub)transaction XID which prefixes messages within a stream block.
+func streamed(xid uint32, msg []byte) []byte {
+	return msgBytes(msg[0], u32(xid), msg[1:])
+}
+
+type testColumn struct {
+	name string
+	oid  uint32
+}
+
+// relationMsg describes a relation with a text key column "id" followed by any other columns.
+func relationMsg(relID uint32, namespace, name string, columns ...testColumn) []byte {
+	columns = append([]testColumn{{"id", pgtype.TextOID}}, columns...)
+	var parts = [][]byte{u32(relID), cstr(namespace), cstr(name), []byte{'d'}, u16(uint16(len(columns)))}
+	for idx, col := range columns {
+		var flags = []byte{0}
+		if idx == 0 {
+			flags[0] = 1
+		}
+		parts = append(parts, flags, cstr(col.name), u32(col.oid), u32(0xFFFFFFFF))
+	}
+	return msgBytes('R', parts...)
+}
+
+func insertMsg(relID uint32, values ...string) []byte {
+	var parts = [][]byte{u32(relID), []byte{'N'}, u16(uint16(len(values)))}
+	for _, val := range values {
+		parts = append(parts, []byte{'t'}, u32(uint32(len(val))), []byte(val))
+	}
+	return msgBytes('I', parts...)
+}
+
+func ptr[T any](x T) *T { return &x }
+
+func u16(x uint16) []byte  { return binary.BigEndian.AppendUint16(nil, x) }
+func u32(x uint32) []byte  { return binary.BigEndian.AppendUint32(nil, x) }
+func u64(x uint64) []byte  { return binary.BigEndian.AppendUint64(nil, x) }
+func cstr(x string) []byte { return append([]byte(x), 0) }
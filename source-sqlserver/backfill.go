@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"encoding/binary"
 	"fmt"
 	"strings"
@@ -25,8 +26,34 @@ func (db *sqlserverDatabase) ShouldBackfill(streamID string) bool {
 	return true
 }
 
+// BackfillConcurrency returns the configured number of tables which may be backfilled at once.
+// Concurrent table scans are safe because each query obtains a connection from the pool.
+func (db *sqlserverDatabase) BackfillConcurrency() int {
+	return db.config.Advanced.BackfillConcurrency
+}
+
+// BackfillKeyRanges returns no boundaries, since splitting backfills into key
+// ranges isn't supported and tables are always scanned sequentially.
+func (db *sqlserverDatabase) BackfillKeyRanges(ctx context.Context, info *sqlcapture.DiscoveryInfo, keyColumns []string) ([][]byte, error) {
+	return nil, nil
+}
+
+// EstimatedRowCount returns the number of rows in a table according to the
+// partition metadata of its heap or clustered index.
+func (db *sqlserverDatabase) EstimatedRowCount(ctx context.Context, info *sqlcapture.DiscoveryInfo) (int, error) {
+	var estimate sql.NullInt64
+	const query = `SELECT SUM(p.rows) FROM sys.partitions p
+	                 JOIN sys.tables t ON t.object_id = p.object_id
+	                 JOIN sys.schemas s ON s.schema_id = t.schema_id
+	                 WHERE s.name = @p1 AND t.name = @p2 AND p.index_id IN (0, 1);`
+	if err := db.conn.QueryRowContext(ctx, query, info.Schema, info.Name).Scan(&estimate); err != nil {
+		return 0, fmt.Errorf("error querying row count estimate: %w", err)
+	}
+	return int(estimate.Int64), nil
+}
+
 // ScanTableChunk fetches a chunk of rows from the specified table, resuming from the `resumeAfter` row key if non-nil.
-func (db *sqlserverDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.DiscoveryInfo, state *sqlcapture.TableState, callback func(event *sqlcapture.ChangeEvent) error) error {
+func (db *sqlserverDatabase) ScanTableChunk(ctx context.Context, info *sqlcapture.DiscoveryInfo, state *sqlcapture.TableState, filter *sqlcapture.RowFilter, callback func(event *sqlcapture.ChangeEvent) error) error {
 	var keyColumns = state.KeyColumns
 	var resumeAfter = state.Scanned
 	var schema, table = info.Schema, info.Name
@@ -37,6 +64,12 @@ func (db *sqlserverDatabase) ScanTableChunk(ctx context.Context, info *sqlcaptur
 		columnTypes[name] = column.DataType
 	}
 
+	// Only rows matching the binding's row filter and any backfill-specific condition are scanned.
+	var where, err = sqlcapture.BackfillCondition(state, filter, &filterDialect)
+	if err != nil {
+		return err
+	}
+
 	// Compute backfill query and arguments list
 	var query string
 	var args []any
@@ -46,7 +79,7 @@ func (db *sqlserverDatabase) ScanTableChunk(ctx context.Context, info *sqlcaptur
 			"stream": streamID,
 			"offset": state.BackfilledCount,
 		}).Debug("scanning keyless table chunk")
-		query = db.keylessScanQuery(info, schema, table)
+		query = db.keylessScanQuery(info, schema, table, where)
 		args = []any{state.BackfilledCount}
 	case sqlcapture.TableModePreciseBackfill, sqlcapture.TableModeUnfilteredBackfill:
 		if resumeAfter != nil {
@@ -62,14 +95,14 @@ func (db *sqlserverDatabase) ScanTableChunk(ctx context.Context, info *sqlcaptur
 				"keyColumns": keyColumns,
 				"resumeKey":  resumeKey,
 			}).Debug("scanning subsequent table chunk")
-			query = db.buildScanQuery(false, keyColumns, columnTypes, schema, table)
+			query = db.buildScanQuery(false, keyColumns, columnTypes, schema, table, where)
 			args = resumeKey
 		} else {
 			log.WithFields(log.Fields{
 				"stream":     streamID,
 				"keyColumns": keyColumns,
 			}).Debug("scanning initial table chunk")
-			query = db.buildScanQuery(true, keyColumns, columnTypes, schema, table)
+			query = db.buildScanQuery(true, keyColumns, columnTypes, schema, table, where)
 		}
 	default:
 		return fmt.Errorf("invalid backfill mode %q", state.Mode)
@@ -143,15 +176,18 @@ func (db *sqlserverDatabase) ScanTableChunk(ctx context.Context, info *sqlcaptur
 	return nil
 }
 
-func (db *sqlserverDatabase) keylessScanQuery(info *sqlcapture.DiscoveryInfo, schemaName, tableName string) string {
+func (db *sqlserverDatabase) keylessScanQuery(info *sqlcapture.DiscoveryInfo, schemaName, tableName, where string) string {
 	var query = new(strings.Builder)
 	fmt.Fprintf(query, "SELECT * FROM [%s].[%s]", schemaName, tableName)
+	if where != "" {
+		fmt.Fprintf(query, " WHERE (%s)", where)
+	}
 	fmt.Fprintf(query, " ORDER BY %%%%physloc%%%%")
 	fmt.Fprintf(query, " OFFSET @p1 ROWS FETCH FIRST %d ROWS ONLY;", db.config.Advanced.BackfillChunkSize)
 	return query.String()
 }
 
-func (db *sqlserverDatabase) buildScanQuery(start bool, keyColumns []string, columnTypes map[string]interface{}, schemaName, tableName string) string {
+func (db *sqlserverDatabase) buildScanQuery(start bool, keyColumns []string, columnTypes map[string]interface{}, schemaName, tableName, where string) string {
 	var pkey []string
 	var args []string
 	for idx, colName := range keyColumns {
@@ -174,10 +210,16 @@ func (db *sqlserverDatabase) buildScanQuery(start bool, keyColumns []string, col
 		}
 	}
 	fmt.Fprintf(query, "SELECT * FROM [%s].[%s]", schemaName, tableName)
+	// An additional filter condition wraps the key predicate, since the latter
+	// is a disjunction of terms.
+	var filter, filterEnd string
+	if where != "" {
+		filter, filterEnd = fmt.Sprintf("(%s) AND (", where), ")"
+	}
 	if !start {
 		for i := range pkey {
 			if i == 0 {
-				fmt.Fprintf(query, " WHERE (")
+				fmt.Fprintf(query, " WHERE %s(", filter)
 			} else {
 				fmt.Fprintf(query, ") OR (")
 			}
@@ -187,7 +229,9 @@ func (db *sqlserverDatabase) buildScanQuery(start bool, keyColumns []string, col
 			}
 			fmt.Fprintf(query, "%s > %s", pkey[i], args[i])
 		}
-		fmt.Fprintf(query, ")")
+		fmt.Fprintf(query, ")%s", filterEnd)
+	} else if where != "" {
+		fmt.Fprintf(query, " WHERE (%s)", where)
 	}
 	fmt.Fprintf(query, " ORDER BY %s", strings.Join(pkey, ", "))
 	fmt.Fprintf(query, " OFFSET 0 ROWS FETCH FIRST %d ROWS ONLY;", db.config.Advanced.BackfillChunkSize)
@@ -195,6 +239,22 @@ func (db *sqlserverDatabase) buildScanQuery(start bool, keyColumns []string, col
 	return query.String()
 }
 
+// filterDialect renders row filters as SQL Server conditions. String literals are
+// Unicode so that they compare correctly with NVARCHAR columns, and booleans are BIT
+// values since there's no boolean type. Columns compared with strings use a binary
+// collation, since the default collations are case- and accent-insensitive.
+var filterDialect = sqlcapture.FilterDialect{
+	QuoteColumn: quoteColumnName,
+	QuoteString: func(str string) string {
+		return "N'" + strings.ReplaceAll(str, "'", "''") + "'"
+	},
+	BinaryString: func(column string) string {
+		return "CAST(" + column + " AS NVARCHAR(MAX)) COLLATE Latin1_General_BIN2"
+	},
+	True:  "1",
+	False: "0",
+}
+
 func quoteColumnName(name string) string {
 	// From https://learn.microsoft.com/en-us/sql/relational-databases/databases/database-identifiers
 	// it appears to always be valid to take an identifier for which quoting is optional and enclose
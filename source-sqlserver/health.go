@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	cerrors "github.com/estuary/connectors/go/connector-errors"
+	"github.com/estuary/connectors/sqlcapture"
+	log "github.com/sirupsen/logrus"
+)
+
+// The cursor age is computed on the server because 'tran_end_time' is in server-local time.
+// The pending LSN is that of the first transaction after the cursor which remains to be read.
+const cdcRetentionHealthQuery = `
+  SELECT (SELECT TOP 1 start_lsn FROM cdc.lsn_time_mapping ORDER BY start_lsn),
+         (SELECT TOP 1 start_lsn FROM cdc.lsn_time_mapping WHERE start_lsn > @p1 AND tran_id <> 0 ORDER BY start_lsn),
+         DATEDIFF(SECOND, sys.fn_cdc_map_lsn_to_time(@p1), GETDATE());`
+
+// cdcRetentionWarningFraction is the fraction of the CDC cleanup retention period
+// which the cursor may fall behind by before a warning is logged.
+const cdcRetentionWarningFraction = 0.8
+
+// ReplicationHealth reports how far the cursor is behind in time, and whether the CDC
+// cleanup job has removed or will soon remove changes which haven't been captured.
+func (db *sqlserverDatabase) ReplicationHealth(ctx context.Context, cursor string) (*sqlcapture.ReplicationHealth, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	cursorLSN, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding cursor: %w", err)
+	}
+
+	var oldestLSN, pendingLSN []byte
+	var cursorAge sql.NullInt64
+	if err := db.conn.QueryRowContext(ctx, cdcRetentionHealthQuery, cursorLSN).Scan(&oldestLSN, &pendingLSN, &cursorAge); err != nil {
+		return nil, fmt.Errorf("error querying CDC retention: %w", err)
+	}
+
+	var health = &sqlcapture.ReplicationHealth{
+		Details: map[string]any{"cursorLSN": formatDebeziumLSN(cursorLSN)},
+	}
+	if len(oldestLSN) > 0 {
+		health.Details["oldestLSN"] = formatDebeziumLSN(oldestLSN)
+	}
+	if cursorAge.Valid {
+		health.Details["cursorAge"] = (time.Duration(cursorAge.Int64) * time.Second).String()
+	}
+	var retention, retentionErr = cdcCleanupRetention(ctx, db.conn)
+	if retentionErr != nil {
+		// Reading the cleanup job configuration requires access to 'msdb', which
+		// the capture user isn't required to have.
+		log.WithField("err", retentionErr).Debug("unable to query CDC cleanup retention")
+	} else if retention > 0 {
+		health.Details["retention"] = retention.String()
+	}
+
+	// The cursor only advances to the LSNs of transactions while there are some to
+	// read, so when there are none left it may be old and behind the retained
+	// entries without any changes being at risk.
+	if len(pendingLSN) == 0 {
+		health.Details["caughtUp"] = true
+		return health, nil
+	}
+	health.Details["pendingLSN"] = formatDebeziumLSN(pendingLSN)
+
+	if len(oldestLSN) > 0 && bytes.Compare(cursorLSN, oldestLSN) < 0 {
+		var msg = fmt.Sprintf("CDC cleanup has removed changes up to LSN %s which is past the capture's position at LSN %s, so changes have been lost and the capture must be backfilled again", formatDebeziumLSN(oldestLSN), formatDebeziumLSN(cursorLSN))
+		if db.config.Advanced.FailOnRetentionLoss {
+			return nil, cerrors.NewUserError(nil, msg)
+		}
+		health.Warnings = append(health.Warnings, msg)
+		return health, nil
+	}
+
+	if retention > 0 && cursorAge.Valid {
+		var age = time.Duration(cursorAge.Int64) * time.Second
+		if age > time.Duration(float64(retention)*cdcRetentionWarningFraction) {
+			health.Warnings = append(health.Warnings, fmt.Sprintf("the capture's position is %s behind, which is close to the CDC cleanup retention period of %s, and changes will be lost unless the capture catches up", age, retention))
+		}
+	}
+	return health, nil
+}
+
+// cdcCleanupRetention returns the retention period of the CDC cleanup job of the
+// current database, or zero if there's no cleanup job.
+func cdcCleanupRetention(ctx context.Context, conn *sql.DB) (time.Duration, error) {
+	const query = `SELECT retention FROM msdb.dbo.cdc_jobs WHERE database_id = DB_ID() AND job_type = 'cleanup';`
+	var minutes int64
+	if err := conn.QueryRowContext(ctx, query).Scan(&minutes); errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("error querying CDC cleanup job: %w", err)
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
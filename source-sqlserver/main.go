@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,6 +23,11 @@ import (
 	mssqldb "github.com/microsoft/go-mssqldb"
 )
 
+// connectorVersion is the version of this connector, as reported in Debezium change event envelopes.
+//
+//go:embed VERSION
+var connectorVersion string
+
 func main() {
 	boilerplate.RunMain(sqlserverDriver)
 }
@@ -46,9 +52,19 @@ type Config struct {
 }
 
 type advancedConfig struct {
-	WatermarksTable   string `json:"watermarksTable,omitempty" jsonschema:"default=dbo.flow_watermarks,description=The name of the table used for watermark writes during backfills. Must be fully-qualified in '<schema>.<table>' form."`
-	SkipBackfills     string `json:"skip_backfills,omitempty" jsonschema:"title=Skip Backfills,description=A comma-separated list of fully-qualified table names which should not be backfilled."`
-	BackfillChunkSize int    `json:"backfill_chunk_size,omitempty" jsonschema:"title=Backfill Chunk Size,default=50000,description=The number of rows which should be fetched from the database in a single backfill query."`
+	WatermarksTable     string `json:"watermarksTable,omitempty" jsonschema:"default=dbo.flow_watermarks,description=The name of the table used for watermark writes during backfills. Must be fully-qualified in '<schema>.<table>' form."`
+	ReadOnlyWatermarks  bool   `json:"read_only_watermarks,omitempty" jsonschema:"title=Read-Only Watermarks,description=Use the maximum CDC LSN read at backfill chunk boundaries as the watermark instead of writing into the watermarks table. This permits capturing without write access to the database."`
+	SignalsTable        string `json:"signals_table,omitempty" jsonschema:"title=Signals Table,description=The name of an optional table from which backfill signals are read. Must be fully-qualified in '<schema>.<table>' form and have CDC enabled. Each row inserted into it must have a 'signal' column holding a JSON object with an 'action' of 'backfill' and the fully-qualified 'table' to backfill plus an optional 'where' row filter expression limiting the backfilled rows."`
+	SkipBackfills       string `json:"skip_backfills,omitempty" jsonschema:"title=Skip Backfills,description=A comma-separated list of fully-qualified table names which should not be backfilled."`
+	BackfillChunkSize   int    `json:"backfill_chunk_size,omitempty" jsonschema:"title=Backfill Chunk Size,default=50000,description=The number of rows which should be fetched from the database in a single backfill query."`
+	BackfillConcurrency int    `json:"backfill_concurrency,omitempty" jsonschema:"title=Backfill Concurrency,default=1,description=The maximum number of tables which may be backfilled at the same time. Each concurrently backfilled table uses its own database connection."`
+	PollingInterval     string `json:"polling_interval,omitempty" jsonschema:"title=Polling Interval,default=500ms,description=How often the change tables are polled for new changes. Must be a valid Go duration string."`
+	PollTransactions    int    `json:"poll_transactions_limit,omitempty" jsonschema:"title=Transactions Per Poll,default=1024,description=The maximum number of transactions which will be read from the change tables in a single polling cycle. Polls are always limited and a value of zero uses the default."`
+	AdaptivePolling     bool   `json:"adaptive_polling,omitempty" jsonschema:"title=Adaptive Polling,default=false,description=When enabled the polling interval is shortened while there are more transactions available than a single poll can read and lengthened (up to the maximum polling interval) while there are no changes."`
+	DebeziumEnvelope    bool   `json:"debezium_envelope,omitempty" jsonschema:"title=Debezium Envelope,description=Emit the '_meta' property of each captured document as a complete Debezium change event envelope with 'before' and 'after' states and Debezium source properties. Collections must be re-discovered after changing this setting."`
+	TransactionMetadata bool   `json:"transaction_metadata,omitempty" jsonschema:"title=Transaction Metadata,description=Include the ID and sequence number of the source transaction in the '_meta' property of each replicated change and suggest an additional binding which receives one BEGIN and one END document per transaction with the number of changes to each table. Collections must be re-discovered after changing this setting."`
+	FailOnRetentionLoss bool   `json:"fail_on_retention_loss,omitempty" jsonschema:"title=Fail on Retention Loss,description=Fail the capture with an error when the periodic replication health check finds that changes were removed from the database before they could be captured. By default this is only logged as a warning."`
+	MaxPollInterval     string `json:"max_polling_interval,omitempty" jsonschema:"title=Maximum Polling Interval,default=10s,description=The longest interval between polls when adaptive polling is enabled. Must be a valid Go duration string."`
 }
 
 type tunnelConfig struct {
@@ -83,6 +99,9 @@ func (c *Config) Validate() error {
 	if c.Advanced.WatermarksTable != "" && !strings.Contains(c.Advanced.WatermarksTable, ".") {
 		return fmt.Errorf("invalid 'watermarksTable' configuration: table name %q must be fully-qualified as \"<schema>.<table>\"", c.Advanced.WatermarksTable)
 	}
+	if c.Advanced.SignalsTable != "" && !strings.Contains(c.Advanced.SignalsTable, ".") {
+		return fmt.Errorf("invalid 'signals_table' configuration: table name %q must be fully-qualified as \"<schema>.<table>\"", c.Advanced.SignalsTable)
+	}
 	if c.Advanced.SkipBackfills != "" {
 		for _, skipStreamID := range strings.Split(c.Advanced.SkipBackfills, ",") {
 			if !strings.Contains(skipStreamID, ".") {
@@ -90,6 +109,25 @@ func (c *Config) Validate() error {
 			}
 		}
 	}
+	for _, interval := range [][]string{
+		{"polling_interval", c.Advanced.PollingInterval},
+		{"max_polling_interval", c.Advanced.MaxPollInterval},
+	} {
+		if interval[1] == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(interval[1]); err != nil {
+			return fmt.Errorf("invalid '%s' configuration: %w", interval[0], err)
+		} else if d <= 0 {
+			return fmt.Errorf("invalid '%s' configuration: interval %q must be positive", interval[0], interval[1])
+		}
+	}
+	if c.Advanced.BackfillConcurrency < 0 {
+		return fmt.Errorf("invalid 'backfill_concurrency' configuration: concurrency %d must not be negative", c.Advanced.BackfillConcurrency)
+	}
+	if c.Advanced.PollTransactions < 0 {
+		return fmt.Errorf("invalid 'poll_transactions_limit' configuration: limit %d must not be negative", c.Advanced.PollTransactions)
+	}
 	return nil
 }
 
@@ -103,6 +141,19 @@ func (c *Config) SetDefaults() {
 	if c.Advanced.BackfillChunkSize <= 0 {
 		c.Advanced.BackfillChunkSize = 50000
 	}
+	if c.Advanced.BackfillConcurrency <= 0 {
+		c.Advanced.BackfillConcurrency = 1
+	}
+	if c.Advanced.PollingInterval == "" {
+		// A polling interval of 500ms is the default value that Debezium SQL Server uses.
+		c.Advanced.PollingInterval = "500ms"
+	}
+	if c.Advanced.PollTransactions <= 0 {
+		c.Advanced.PollTransactions = 1024
+	}
+	if c.Advanced.MaxPollInterval == "" {
+		c.Advanced.MaxPollInterval = "10s"
+	}
 	if c.Timezone == "" {
 		c.Timezone = "UTC"
 	}
@@ -241,6 +292,21 @@ func (db *sqlserverDatabase) EmptySourceMetadata() sqlcapture.SourceMetadata {
 	return &sqlserverSourceInfo{}
 }
 
+func (db *sqlserverDatabase) DebeziumInfo() *sqlcapture.DebeziumInfo {
+	if !db.config.Advanced.DebeziumEnvelope {
+		return nil
+	}
+	return &sqlcapture.DebeziumInfo{
+		Connector: "sqlserver",
+		Version:   strings.TrimSpace(connectorVersion),
+		Database:  db.config.Database,
+	}
+}
+
+func (db *sqlserverDatabase) TransactionMetadata() bool {
+	return db.config.Advanced.TransactionMetadata
+}
+
 func (db *sqlserverDatabase) FallbackCollectionKey() []string {
 	return []string{"/_meta/source/lsn", "/_meta/source/seqval"}
 }
@@ -132,6 +132,9 @@ func isCDCEnabled(ctx context.Context, conn *sql.DB, dbName string) (bool, error
 }
 
 func (db *sqlserverDatabase) prerequisiteWatermarksTable(ctx context.Context) error {
+	if db.config.Advanced.ReadOnlyWatermarks {
+		return nil // The watermarks table isn't used at all
+	}
 	var table = db.config.Advanced.WatermarksTable
 	var logEntry = log.WithField("table", table)
 
@@ -158,6 +161,9 @@ func (db *sqlserverDatabase) prerequisiteWatermarksTable(ctx context.Context) er
 }
 
 func (db *sqlserverDatabase) prerequisiteWatermarksCaptureInstance(ctx context.Context) error {
+	if db.config.Advanced.ReadOnlyWatermarks {
+		return nil
+	}
 	var schema, table = splitStreamID(db.config.Advanced.WatermarksTable)
 	return db.prerequisiteTableCaptureInstance(ctx, schema, table)
 }
@@ -167,7 +173,9 @@ func (db *sqlserverDatabase) prerequisiteMaximumLSN(ctx context.Context) error {
 	// the agent process to observe, and thus the "get max LSN" query should eventually
 	// yield a non-empty result. We ignore errors here, because the watermarks table
 	// prerequisite is responsible for reporting those.
-	_ = db.WriteWatermark(ctx, "dummy-value")
+	if !db.config.Advanced.ReadOnlyWatermarks {
+		_ = db.WriteWatermark(ctx, "dummy-value")
+	}
 
 	var maxLSN []byte
 	// Retry loop with a 1s delay between retries, in case the watermarks table
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +35,34 @@ func (si *sqlserverSourceInfo) Common() sqlcapture.SourceCommon {
 	return si.SourceCommon
 }
 
+// DebeziumSource returns the SQL Server-specific properties of a Debezium source block.
+func (si *sqlserverSourceInfo) DebeziumSource() map[string]any {
+	if si.LSN == nil {
+		return nil // Backfilled rows have no LSN
+	}
+	return map[string]any{
+		"commit_lsn": formatDebeziumLSN(si.LSN),
+		"change_lsn": formatDebeziumLSN(si.SeqVal),
+	}
+}
+
+// TransactionID identifies the transaction of a CDC event by its commit LSN,
+// which is shared by all changes of the transaction.
+func (si *sqlserverSourceInfo) TransactionID() string {
+	if si.LSN == nil {
+		return "" // Backfilled rows have no LSN
+	}
+	return formatDebeziumLSN(si.LSN)
+}
+
+// formatDebeziumLSN formats a 10-byte LSN in the "00000027:00000758:0005" form used by Debezium.
+func formatDebeziumLSN(lsn []byte) string {
+	if len(lsn) != 10 {
+		return fmt.Sprintf("%x", lsn)
+	}
+	return fmt.Sprintf("%x:%x:%x", lsn[0:4], lsn[4:8], lsn[8:10])
+}
+
 // ReplicationStream constructs a new ReplicationStream object, from which
 // a neverending sequence of change events can be read.
 func (db *sqlserverDatabase) ReplicationStream(ctx context.Context, startCursor string) (sqlcapture.ReplicationStream, error) {
@@ -70,6 +99,8 @@ type sqlserverReplicationStream struct {
 
 	fromLSN []byte // The LSN from which we will request changes on the next polling cycle
 
+	instancesRefreshed time.Time // The last time capture instances were checked for newer instances of active tables
+
 	tables struct {
 		sync.RWMutex
 		info map[string]*tableReplicationInfo
@@ -80,8 +111,30 @@ type tableReplicationInfo struct {
 	CaptureInstance string
 	KeyColumns      []string
 	ColumnTypes     map[string]any
+	NextInstance    *captureInstanceInfo // A newer capture instance which will be switched to once replication reaches its start LSN
+}
+
+// sqlserverTableMetadata is the persistent metadata of a captured table, which
+// records the capture instance currently in use once a table has switched to a
+// newer one. The types of the columns it captures are derived again when the table
+// is activated, since they can't be faithfully round-tripped through JSON.
+type sqlserverTableMetadata struct {
+	CaptureInstance string `json:"capture_instance"`
 }
 
+// captureInstanceInfo describes one of the (up to two) capture instances of a table.
+type captureInstanceInfo struct {
+	Name     string
+	StartLSN []byte // The low endpoint of the capture instance's change table, or nil if not yet established
+}
+
+// How often to check for newly created capture instances of active tables.
+const captureInstanceRefreshInterval = 60 * time.Second
+
+// The maximum number of transactions whose changes are held in memory at once when
+// polling tables in LSN order.
+const orderedPollTransactions = 64
+
 func (rs *sqlserverReplicationStream) open(ctx context.Context) error {
 	var (
 		dbName     string
@@ -106,18 +159,16 @@ func (rs *sqlserverReplicationStream) ActivateTable(ctx context.Context, streamI
 
 	// TODO(wgd): It's rather inefficient to redo the 'list instances' work every time a
 	// table gets activated.
-	var captureInstances, err = listCaptureInstances(ctx, rs.conn)
+	var captureInstances, err = listCaptureInstanceInfo(ctx, rs.conn)
 	if err != nil {
 		return err
 	}
 
-	var instanceNames = captureInstances[streamID]
-	var instanceName string
-	if len(instanceNames) > 0 {
-		// TODO(wgd): We should support using whichever capture instance is newer, here
-		// to enable schema migrations in the recommended SQL Server way.
-		instanceName = instanceNames[0]
-	} else {
+	// A table may have two capture instances while a schema change is being rolled
+	// out, in which case we use the older one until replication reaches the point
+	// where the newer one starts.
+	var current, next = selectCaptureInstance(captureInstances[streamID], rs.fromLSN)
+	if current == nil {
 		return fmt.Errorf("no capture instance for table %q", streamID)
 	}
 
@@ -126,17 +177,69 @@ func (rs *sqlserverReplicationStream) ActivateTable(ctx context.Context, streamI
 		columnTypes[columnName] = columnInfo.DataType
 	}
 
+	// A table which previously switched to a newer capture instance continues
+	// reading from that instance, and its columns are the ones it captures.
+	if len(metadataJSON) > 0 {
+		var metadata sqlserverTableMetadata
+		if err := json.Unmarshal(metadataJSON, &metadata); err != nil {
+			return fmt.Errorf("error parsing metadata for table %q: %w", streamID, err)
+		}
+		if restored, restoredNext := restoreCaptureInstance(captureInstances[streamID], metadata.CaptureInstance); restored == nil {
+			log.WithFields(log.Fields{"stream": streamID, "instance": metadata.CaptureInstance, "selected": current.Name}).Warn("persisted capture instance no longer exists")
+		} else {
+			current, next = restored, restoredNext
+			if columnTypes, err = capturedColumnTypes(ctx, rs.conn, current.Name, columnTypes); err != nil {
+				return err
+			}
+		}
+	}
+	var instanceName = current.Name
+
 	rs.tables.Lock()
 	rs.tables.info[streamID] = &tableReplicationInfo{
 		CaptureInstance: instanceName,
 		KeyColumns:      keyColumns,
 		ColumnTypes:     columnTypes,
+		NextInstance:    next,
 	}
 	rs.tables.Unlock()
 	log.WithFields(log.Fields{"stream": streamID, "instance": instanceName}).Debug("activated table")
+	if next != nil {
+		log.WithFields(log.Fields{"stream": streamID, "instance": instanceName, "next": next.Name}).Info("table has a newer capture instance which will be used once replication reaches it")
+	}
 	return nil
 }
 
+// restoreCaptureInstance returns the named capture instance of a table along with
+// a newer instance to switch to later, if there is one, or nil if the named instance
+// no longer exists. The instances must be ordered by start LSN.
+func restoreCaptureInstance(instances []*captureInstanceInfo, name string) (current, next *captureInstanceInfo) {
+	var idx = slices.IndexFunc(instances, func(instance *captureInstanceInfo) bool { return instance.Name == name })
+	if idx < 0 {
+		return nil, nil
+	}
+	for _, instance := range instances[idx+1:] {
+		if instance.StartLSN != nil {
+			return instances[idx], instance
+		}
+	}
+	return instances[idx], nil
+}
+
+// selectCaptureInstance returns the capture instance from which changes after the
+// specified LSN should be read, along with a newer instance to switch to later, if
+// there is one. The instances must be ordered by start LSN.
+func selectCaptureInstance(instances []*captureInstanceInfo, fromLSN []byte) (current, next *captureInstanceInfo) {
+	for _, instance := range instances {
+		if current == nil || (instance.StartLSN != nil && bytes.Compare(instance.StartLSN, fromLSN) <= 0) {
+			current = instance
+		} else if next == nil && instance.StartLSN != nil {
+			next = instance
+		}
+	}
+	return current, next
+}
+
 func (rs *sqlserverReplicationStream) StartReplication(ctx context.Context) error {
 	var streamCtx, streamCancel = context.WithCancel(ctx)
 	rs.events = make(chan sqlcapture.DatabaseEvent, replicationBufferSize)
@@ -168,46 +271,98 @@ func (rs *sqlserverReplicationStream) Close(ctx context.Context) error {
 	return <-rs.errCh
 }
 
+// The shortest interval between polls when adaptive polling is enabled.
+const minAdaptivePollInterval = 10 * time.Millisecond
+
 func (rs *sqlserverReplicationStream) run(ctx context.Context) error {
-	// TODO(wgd): Make the polling interval part of advanced connector config.
-	// A polling interval of 500ms is the default value that Debezium SQL Server uses.
-	const pollInterval = 500 * time.Millisecond
-	var poll = time.NewTicker(pollInterval)
+	var pollInterval, err = time.ParseDuration(rs.cfg.Advanced.PollingInterval)
+	if err != nil {
+		return fmt.Errorf("invalid polling interval: %w", err)
+	}
+	maxPollInterval, err := time.ParseDuration(rs.cfg.Advanced.MaxPollInterval)
+	if err != nil {
+		return fmt.Errorf("invalid maximum polling interval: %w", err)
+	}
+
+	var interval = pollInterval
+	var poll = time.NewTimer(interval)
 	defer poll.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-poll.C:
-			if err := rs.pollChanges(ctx); err != nil {
+			var result, err = rs.pollChanges(ctx)
+			if err != nil {
 				log.WithField("err", err).Error("error polling change tables")
 				return fmt.Errorf("error requesting CDC events: %w", err)
 			}
+			if rs.cfg.Advanced.AdaptivePolling {
+				var next = adaptPollInterval(interval, pollInterval, maxPollInterval, result)
+				if next != interval {
+					log.WithFields(log.Fields{"interval": next.String(), "result": result}).Trace("adjusted polling interval")
+				}
+				interval = next
+			}
+			poll.Reset(interval)
 		}
 	}
 }
 
-func (rs *sqlserverReplicationStream) pollChanges(ctx context.Context) error {
-	// TODO(wgd): Make the number of transactions per polling interval configurable.
-	var pollTransactionsLimit = 1024
-	var toLSN []byte
-	if pollTransactionsLimit == 0 {
-		var maxLSN, err = cdcGetMaxLSN(ctx, rs.conn)
-		if err != nil {
-			return err
-		}
-		toLSN = maxLSN
-	} else {
-		var nextLSN, err = cdcGetNextLSN(ctx, rs.conn, rs.fromLSN, pollTransactionsLimit)
-		if err != nil {
-			return err
-		}
-		toLSN = nextLSN
+// pollResult describes how much work a polling cycle found to do.
+type pollResult int
+
+const (
+	pollIdle    pollResult = iota // The LSN didn't advance
+	pollPartial                   // All available transactions were read
+	pollFull                      // More transactions remain than could be read in one poll
+)
+
+func (r pollResult) String() string {
+	switch r {
+	case pollIdle:
+		return "idle"
+	case pollPartial:
+		return "partial"
+	case pollFull:
+		return "full"
+	}
+	return fmt.Sprintf("pollResult(%d)", int(r))
+}
+
+// adaptPollInterval halves the polling interval (down to a minimum) while each
+// poll is full, doubles it (up to the maximum) while the capture is idle, and
+// otherwise returns to the configured interval.
+func adaptPollInterval(interval, pollInterval, maxPollInterval time.Duration, result pollResult) time.Duration {
+	switch result {
+	case pollFull:
+		return max(interval/2, min(minAdaptivePollInterval, pollInterval))
+	case pollIdle:
+		return min(interval*2, max(maxPollInterval, pollInterval))
+	}
+	return pollInterval
+}
+
+func (rs *sqlserverReplicationStream) pollChanges(ctx context.Context) (pollResult, error) {
+	// Polls are always limited, since SetDefaults replaces a limit of zero with the default.
+	var toLSN, more, err = cdcGetNextLSN(ctx, rs.conn, rs.fromLSN, rs.cfg.Advanced.PollTransactions)
+	if err != nil {
+		return pollIdle, err
+	}
+	var result = pollPartial
+	if more {
+		result = pollFull
 	}
 
 	if bytes.Equal(rs.fromLSN, toLSN) {
 		log.Trace("lsn hasn't advanced, not polling tables")
-		return nil
+		return pollIdle, rs.skipIdleLSNs(ctx)
+	}
+
+	if time.Since(rs.instancesRefreshed) >= captureInstanceRefreshInterval {
+		if err := rs.refreshCaptureInstances(ctx); err != nil {
+			return result, err
+		}
 	}
 
 	// Make a list of tables we intend to poll. The copying is necessary
@@ -223,14 +378,34 @@ func (rs *sqlserverReplicationStream) pollChanges(ctx context.Context) error {
 			InstanceName: info.CaptureInstance,
 			KeyColumns:   info.KeyColumns,
 			ColumnTypes:  info.ColumnTypes,
+			NextInstance: info.NextInstance,
 		})
 	}
 	rs.tables.RUnlock()
 
-	for _, item := range queue {
-		log.WithField("table", item.StreamID).Trace("polling table")
-		if err := rs.pollTable(ctx, rs.fromLSN, toLSN, item); err != nil {
-			return fmt.Errorf("table %q: %w", sqlcapture.JoinStreamID(item.SchemaName, item.TableName), err)
+	if rs.cfg.Advanced.TransactionMetadata {
+		if err := rs.pollTablesInOrder(ctx, toLSN, queue); err != nil {
+			return result, err
+		}
+	} else {
+		for _, item := range queue {
+			log.WithField("table", item.StreamID).Trace("polling table")
+			if item.NextInstance != nil && bytes.Compare(toLSN, item.NextInstance.StartLSN) >= 0 {
+				// Changes prior to the start of the newer capture instance are read from
+				// the current one, and subsequent changes are read from the newer one.
+				if bytes.Compare(item.NextInstance.StartLSN, rs.fromLSN) > 0 {
+					item.UntilLSN = item.NextInstance.StartLSN
+					if err := rs.pollTable(ctx, rs.fromLSN, toLSN, item); err != nil {
+						return result, fmt.Errorf("table %q: %w", sqlcapture.JoinStreamID(item.SchemaName, item.TableName), err)
+					}
+				}
+				if err := rs.switchCaptureInstance(ctx, item); err != nil {
+					return result, fmt.Errorf("table %q: %w", sqlcapture.JoinStreamID(item.SchemaName, item.TableName), err)
+				}
+			}
+			if err := rs.pollTable(ctx, rs.fromLSN, toLSN, item); err != nil {
+				return result, fmt.Errorf("table %q: %w", sqlcapture.JoinStreamID(item.SchemaName, item.TableName), err)
+			}
 		}
 	}
 
@@ -240,6 +415,25 @@ func (rs *sqlserverReplicationStream) pollChanges(ctx context.Context) error {
 	}
 	rs.fromLSN = toLSN
 
+	return result, nil
+}
+
+// skipIdleLSNs advances the current LSN past the entries which CDC records while
+// there are no transactions to capture, since polls only advance to the LSNs of
+// transactions. Otherwise the cursor of an idle capture would fall behind the
+// entries retained by CDC cleanup, which would look like lost changes.
+func (rs *sqlserverReplicationStream) skipIdleLSNs(ctx context.Context) error {
+	var idleLSN, err = cdcGetIdleLSN(ctx, rs.conn, rs.fromLSN)
+	if err != nil {
+		return err
+	} else if len(idleLSN) == 0 || bytes.Compare(idleLSN, rs.fromLSN) <= 0 {
+		return nil
+	}
+	log.WithField("lsn", idleLSN).Trace("skipped idle LSNs")
+	rs.events <- &sqlcapture.FlushEvent{
+		Cursor: base64.StdEncoding.EncodeToString(idleLSN),
+	}
+	rs.fromLSN = idleLSN
 	return nil
 }
 
@@ -250,9 +444,188 @@ type tablePollInfo struct {
 	InstanceName string
 	KeyColumns   []string
 	ColumnTypes  map[string]any
+	NextInstance *captureInstanceInfo
+	StartLSN     []byte // If set, changes are requested starting from this LSN instead of the polling cycle's starting LSN
+	UntilLSN     []byte // If set, changes at or after this LSN are ignored
+}
+
+// refreshCaptureInstances checks whether newer capture instances have been
+// created for any active tables.
+func (rs *sqlserverReplicationStream) refreshCaptureInstances(ctx context.Context) error {
+	var captureInstances, err = listCaptureInstanceInfo(ctx, rs.conn)
+	if err != nil {
+		return err
+	}
+	rs.instancesRefreshed = time.Now()
+	rs.updateNextInstances(captureInstances)
+	return nil
+}
+
+// updateNextInstances sets the next capture instance of each active table which
+// doesn't already have one to the instance ordered after its current one, if any.
+// The newer instance may start before the current LSN, since the refresh runs much
+// less often than polling. Changes are then read from it starting at the current
+// LSN, which the older instance has captured up to.
+func (rs *sqlserverReplicationStream) updateNextInstances(captureInstances map[string][]*captureInstanceInfo) {
+	rs.tables.Lock()
+	defer rs.tables.Unlock()
+	for streamID, info := range rs.tables.info {
+		if info.NextInstance != nil {
+			continue
+		}
+		if _, next := restoreCaptureInstance(captureInstances[streamID], info.CaptureInstance); next != nil {
+			log.WithFields(log.Fields{"stream": streamID, "instance": info.CaptureInstance, "next": next.Name}).Info("found newer capture instance")
+			info.NextInstance = next
+		}
+	}
+}
+
+// switchCaptureInstance starts reading changes to a table from its newer
+// capture instance, and emits updated table metadata describing the columns
+// captured by that instance.
+func (rs *sqlserverReplicationStream) switchCaptureInstance(ctx context.Context, item *tablePollInfo) error {
+	var next = item.NextInstance
+	var columnTypes, err = capturedColumnTypes(ctx, rs.conn, next.Name, item.ColumnTypes)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"stream":   item.StreamID,
+		"instance": item.InstanceName,
+		"next":     next.Name,
+		"startLSN": next.StartLSN,
+	}).Info("switching to newer capture instance")
+
+	rs.tables.Lock()
+	if info, ok := rs.tables.info[item.StreamID]; ok {
+		info.CaptureInstance = next.Name
+		info.ColumnTypes = columnTypes
+		info.NextInstance = nil
+	}
+	rs.tables.Unlock()
+
+	item.InstanceName = next.Name
+	item.ColumnTypes = columnTypes
+	item.NextInstance = nil
+	item.StartLSN = next.StartLSN
+	item.UntilLSN = nil
+
+	metadataJSON, err := json.Marshal(&sqlserverTableMetadata{
+		CaptureInstance: next.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing metadata JSON: %w", err)
+	}
+	rs.events <- &sqlcapture.MetadataEvent{
+		StreamID: item.StreamID,
+		Metadata: metadataJSON,
+	}
+	return nil
 }
 
 func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, toLSN []byte, info *tablePollInfo) error {
+	var changes, err = rs.queryChanges(ctx, fromLSN, toLSN, info)
+	if err != nil {
+		return err
+	}
+	defer changes.Close()
+
+	for {
+		var event, err = changes.Next()
+		if err != nil {
+			return err
+		} else if event == nil {
+			return nil
+		}
+		rs.events <- event
+	}
+}
+
+// pollTablesInOrder emits the changes of all the tables in LSN order, so that the
+// changes of each transaction are contiguous. The polling cycle is split into windows
+// of at most orderedPollTransactions transactions, and the changes of each window are
+// read from one table at a time and sorted in memory, so that neither the memory used
+// nor the number of connections held open grows with the number of tables.
+func (rs *sqlserverReplicationStream) pollTablesInOrder(ctx context.Context, toLSN []byte, queue []*tablePollInfo) error {
+	for fromLSN := rs.fromLSN; bytes.Compare(fromLSN, toLSN) < 0; {
+		var windowLSN, _, err = cdcGetNextLSN(ctx, rs.conn, fromLSN, orderedPollTransactions)
+		if err != nil {
+			return err
+		}
+		if bytes.Compare(windowLSN, fromLSN) <= 0 || bytes.Compare(windowLSN, toLSN) > 0 {
+			windowLSN = toLSN
+		}
+		if err := rs.pollWindowInOrder(ctx, fromLSN, windowLSN, queue); err != nil {
+			return err
+		}
+		fromLSN = windowLSN
+	}
+	return nil
+}
+
+// pollWindowInOrder reads the changes of all the tables after fromLSN up to toLSN,
+// and emits them in LSN order.
+func (rs *sqlserverReplicationStream) pollWindowInOrder(ctx context.Context, fromLSN, toLSN []byte, queue []*tablePollInfo) error {
+	var changes []*sqlcapture.ChangeEvent
+	var read = func(info *tablePollInfo) error {
+		var cursor, err = rs.queryChanges(ctx, fromLSN, toLSN, info)
+		if err != nil {
+			return fmt.Errorf("table %q: %w", sqlcapture.JoinStreamID(info.SchemaName, info.TableName), err)
+		}
+		defer cursor.Close()
+		for {
+			var event, err = cursor.Next()
+			if err != nil {
+				return fmt.Errorf("table %q: %w", sqlcapture.JoinStreamID(info.SchemaName, info.TableName), err)
+			} else if event == nil {
+				return nil
+			}
+			changes = append(changes, event)
+		}
+	}
+
+	for _, item := range queue {
+		log.WithField("table", item.StreamID).Trace("polling table")
+		if item.NextInstance != nil && bytes.Compare(toLSN, item.NextInstance.StartLSN) >= 0 {
+			// Changes prior to the start of the newer capture instance are read from
+			// the current one, and subsequent changes are read from the newer one.
+			// The table metadata is updated before any of the changes are emitted.
+			if bytes.Compare(item.NextInstance.StartLSN, fromLSN) > 0 {
+				var current = *item
+				current.UntilLSN = item.NextInstance.StartLSN
+				if err := read(&current); err != nil {
+					return err
+				}
+			}
+			if err := rs.switchCaptureInstance(ctx, item); err != nil {
+				return fmt.Errorf("table %q: %w", sqlcapture.JoinStreamID(item.SchemaName, item.TableName), err)
+			}
+		}
+		if err := read(item); err != nil {
+			return err
+		}
+	}
+
+	slices.SortStableFunc(changes, compareChanges)
+	for _, event := range changes {
+		rs.events <- event
+	}
+	return nil
+}
+
+// changeCursor reads the change events of a table from its capture instance.
+type changeCursor struct {
+	rs      *sqlserverReplicationStream
+	info    *tablePollInfo
+	fromLSN []byte
+	rows    *sql.Rows
+	cnames  []string
+	vals    []any
+	vptrs   []any
+}
+
+// queryChanges starts reading the changes to a table after fromLSN up to toLSN.
+func (rs *sqlserverReplicationStream) queryChanges(ctx context.Context, fromLSN, toLSN []byte, info *tablePollInfo) (*changeCursor, error) {
 	log.WithFields(log.Fields{
 		"stream":   info.StreamID,
 		"instance": info.InstanceName,
@@ -260,16 +633,20 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 		"toLSN":    toLSN,
 	}).Trace("polling stream")
 
+	var queryFromLSN = fromLSN
+	if info.StartLSN != nil && bytes.Compare(info.StartLSN, fromLSN) > 0 {
+		queryFromLSN = info.StartLSN
+	}
 	var query = fmt.Sprintf(`SELECT * FROM cdc.fn_cdc_get_all_changes_%s(@p1, @p2, N'all');`, info.InstanceName)
-	rows, err := rs.conn.QueryContext(ctx, query, fromLSN, toLSN)
+	rows, err := rs.conn.QueryContext(ctx, query, queryFromLSN, toLSN)
 	if err != nil {
-		return fmt.Errorf("error requesting changes: %w", err)
+		return nil, fmt.Errorf("error requesting changes: %w", err)
 	}
-	defer rows.Close()
 
 	cnames, err := rows.Columns()
 	if err != nil {
-		return err
+		rows.Close()
+		return nil, err
 	}
 
 	var vals = make([]any, len(cnames))
@@ -277,14 +654,19 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 	for idx := range vals {
 		vptrs[idx] = &vals[idx]
 	}
+	return &changeCursor{rs: rs, info: info, fromLSN: fromLSN, rows: rows, cnames: cnames, vals: vals, vptrs: vptrs}, nil
+}
 
-	for rows.Next() {
-		if err := rows.Scan(vptrs...); err != nil {
-			return fmt.Errorf("error scanning result row: %w", err)
+// Next returns the next change event of the table, or nil once there are no more.
+func (c *changeCursor) Next() (*sqlcapture.ChangeEvent, error) {
+	var info, fromLSN = c.info, c.fromLSN
+	for c.rows.Next() {
+		if err := c.rows.Scan(c.vptrs...); err != nil {
+			return nil, fmt.Errorf("error scanning result row: %w", err)
 		}
 		var fields = make(map[string]interface{})
-		for idx, name := range cnames {
-			fields[name] = vals[idx]
+		for idx, name := range c.cnames {
+			fields[name] = c.vals[idx]
 		}
 
 		log.WithFields(log.Fields{"stream": info.StreamID, "data": fields}).Trace("got change")
@@ -293,7 +675,7 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 		// it will be included in the source metadata already.
 		var changeLSN, ok = fields["__$start_lsn"].([]byte)
 		if !ok || changeLSN == nil {
-			return fmt.Errorf("invalid '__$start_lsn' value (capture user may not have correct permissions): %v", changeLSN)
+			return nil, fmt.Errorf("invalid '__$start_lsn' value (capture user may not have correct permissions): %v", changeLSN)
 		}
 		if bytes.Compare(changeLSN, fromLSN) <= 0 {
 			log.WithFields(log.Fields{
@@ -303,17 +685,25 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 			}).Trace("filtered change <= fromLSN")
 			continue
 		}
+		if info.UntilLSN != nil && bytes.Compare(changeLSN, info.UntilLSN) >= 0 {
+			log.WithFields(log.Fields{
+				"stream":    info.StreamID,
+				"changeLSN": changeLSN,
+				"untilLSN":  info.UntilLSN,
+			}).Trace("filtered change >= untilLSN")
+			continue
+		}
 		delete(fields, "__$start_lsn")
 
 		// Extract the change type (Insert/Update/Delete) and remove it from the record data.
 		var operation sqlcapture.ChangeOp
 		opval, ok := fields["__$operation"]
 		if !ok {
-			return fmt.Errorf("internal error: cdc operation unspecified ('__$operation' unset)")
+			return nil, fmt.Errorf("internal error: cdc operation unspecified ('__$operation' unset)")
 		}
 		opcode, ok := opval.(int64)
 		if !ok {
-			return fmt.Errorf("internal error: cdc operation code %T(%#v) isn't an int64", opval, opval)
+			return nil, fmt.Errorf("internal error: cdc operation code %T(%#v) isn't an int64", opval, opval)
 		}
 		switch opcode {
 		case 1:
@@ -323,14 +713,14 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 		case 4:
 			operation = sqlcapture.UpdateOp
 		default:
-			return fmt.Errorf("invalid change operation: %d", opcode)
+			return nil, fmt.Errorf("invalid change operation: %d", opcode)
 		}
 		delete(fields, "__$operation")
 
 		// Move the '__$seqval' and '__$update_mask' columns into metadata as well.
 		seqval, ok := fields["__$seqval"].([]byte)
 		if !ok || seqval == nil {
-			return fmt.Errorf("invalid '__$seqval' value: %v", seqval)
+			return nil, fmt.Errorf("invalid '__$seqval' value: %v", seqval)
 		}
 		delete(fields, "__$seqval")
 
@@ -339,10 +729,10 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 
 		var rowKey, err = sqlcapture.EncodeRowKey(info.KeyColumns, fields, info.ColumnTypes, encodeKeyFDB)
 		if err != nil {
-			return fmt.Errorf("error encoding stream %q row key: %w", info.StreamID, err)
+			return nil, fmt.Errorf("error encoding stream %q row key: %w", info.StreamID, err)
 		}
-		if err := rs.db.translateRecordFields(info.ColumnTypes, fields); err != nil {
-			return fmt.Errorf("error translating stream %q change event: %w", info.StreamID, err)
+		if err := c.rs.db.translateRecordFields(info.ColumnTypes, fields); err != nil {
+			return nil, fmt.Errorf("error translating stream %q change event: %w", info.StreamID, err)
 		}
 
 		// For inserts and updates the change event records the state after the
@@ -355,7 +745,7 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 			before = fields
 		}
 
-		rs.events <- &sqlcapture.ChangeEvent{
+		var event = &sqlcapture.ChangeEvent{
 			Operation: operation,
 			RowKey:    rowKey,
 			Source: &sqlserverSourceInfo{
@@ -370,9 +760,22 @@ func (rs *sqlserverReplicationStream) pollTable(ctx context.Context, fromLSN, to
 			Before: before,
 			After:  after,
 		}
+		return event, nil
 	}
+	return nil, c.rows.Err()
+}
 
-	return nil
+func (c *changeCursor) Close() error {
+	return c.rows.Close()
+}
+
+// compareChanges orders change events by their LSN and sequence value.
+func compareChanges(a, b *sqlcapture.ChangeEvent) int {
+	var x, y = a.Source.(*sqlserverSourceInfo), b.Source.(*sqlserverSourceInfo)
+	if c := bytes.Compare(x.LSN, y.LSN); c != 0 {
+		return c
+	}
+	return bytes.Compare(x.SeqVal, y.SeqVal)
 }
 
 func cdcGetMinLSN(ctx context.Context, conn *sql.DB, instanceName string) ([]byte, error) {
@@ -396,24 +799,130 @@ func cdcGetMaxLSN(ctx context.Context, conn *sql.DB) ([]byte, error) {
 	return maxLSN, nil
 }
 
-func cdcGetNextLSN(ctx context.Context, conn *sql.DB, fromLSN []byte, pollTransactionsLimit int) ([]byte, error) {
+// cdcGetMaxTransactionLSN returns the LSN of the latest transaction recorded in
+// 'cdc.lsn_time_mapping', which like the LSNs polled up to by cdcGetNextLSN excludes
+// entries without a transaction. If there are no transactions the zero LSN is
+// returned, which every replication cursor is at or past.
+func cdcGetMaxTransactionLSN(ctx context.Context, conn *sql.DB) ([]byte, error) {
+	var maxLSN []byte
+	const query = `SELECT MAX(start_lsn) FROM cdc.lsn_time_mapping WHERE tran_id <> 0;`
+	if err := conn.QueryRowContext(ctx, query).Scan(&maxLSN); err != nil {
+		return nil, fmt.Errorf("error querying database maximum transaction LSN: %w", err)
+	}
+	if len(maxLSN) == 0 {
+		return make([]byte, 10), nil
+	}
+	return maxLSN, nil
+}
+
+// cdcGetIdleLSN returns the latest LSN after fromLSN which precedes every
+// transaction after fromLSN, or nil if there's no such LSN.
+func cdcGetIdleLSN(ctx context.Context, conn *sql.DB, fromLSN []byte) ([]byte, error) {
+	var idleLSN []byte
+	const query = `SELECT MAX(idle.start_lsn) FROM cdc.lsn_time_mapping AS idle
+	                 WHERE idle.start_lsn > @p1 AND NOT EXISTS (
+					   SELECT 1 FROM cdc.lsn_time_mapping AS txn
+					     WHERE txn.start_lsn > @p1 AND txn.start_lsn <= idle.start_lsn AND txn.tran_id <> 0
+					 );`
+	if err := conn.QueryRowContext(ctx, query, fromLSN).Scan(&idleLSN); err != nil {
+		return nil, fmt.Errorf("error querying database for idle LSNs: %w", err)
+	}
+	return idleLSN, nil
+}
+
+// cdcGetNextLSN returns the LSN up to which the next poll should read in order to
+// read at most pollTransactionsLimit transactions, and whether there are further
+// transactions beyond that point.
+func cdcGetNextLSN(ctx context.Context, conn *sql.DB, fromLSN []byte, pollTransactionsLimit int) ([]byte, bool, error) {
 	var nextLSN []byte
-	const query = `SELECT MAX(start_lsn) FROM (
+	var count int
+	const query = `SELECT MAX(start_lsn), COUNT(*) FROM (
 		             SELECT start_lsn FROM cdc.lsn_time_mapping
 					   WHERE start_lsn >= @p2 AND tran_id <> 0
 					   ORDER BY start_lsn
 					   OFFSET 0 ROWS FETCH FIRST (@p1 + 1) ROWS ONLY
 				   ) AS lsns;`
-	if err := conn.QueryRowContext(ctx, query, pollTransactionsLimit, fromLSN).Scan(&nextLSN); err != nil {
-		return nil, fmt.Errorf("error querying database for next poll-to LSN: %w", err)
+	if err := conn.QueryRowContext(ctx, query, pollTransactionsLimit, fromLSN).Scan(&nextLSN, &count); err != nil {
+		return nil, false, fmt.Errorf("error querying database for next poll-to LSN: %w", err)
 	}
 	if len(nextLSN) == 0 {
 		// Sometimes the above query will return a null result. This is not a fatal error,
 		// and will be corrected if/when more transactions get committed on the DB side.
 		log.Trace("minor failure querying 'cdc.lsn_time_mapping' for the next target LSN")
-		return fromLSN, nil
+		return fromLSN, false, nil
+	}
+	// The query reads one more row than the limit because the first one is usually
+	// the transaction at `fromLSN` which was already processed by the previous poll.
+	return nextLSN, count > pollTransactionsLimit, nil
+}
+
+// listCaptureInstanceInfo returns the capture instances of every table, ordered
+// by start LSN with any instances lacking a start LSN last.
+func listCaptureInstanceInfo(ctx context.Context, conn *sql.DB) (map[string][]*captureInstanceInfo, error) {
+	const query = `SELECT sch.name, tbl.name, ct.capture_instance, ct.start_lsn
+	                 FROM cdc.change_tables AS ct
+					 JOIN sys.tables AS tbl ON ct.source_object_id = tbl.object_id
+					 JOIN sys.schemas AS sch ON tbl.schema_id = sch.schema_id
+					 ORDER BY CASE WHEN ct.start_lsn IS NULL THEN 1 ELSE 0 END, ct.start_lsn, ct.create_date;`
+	var rows, err = conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing CDC instances: %w", err)
+	}
+	defer rows.Close()
+
+	var captureInstances = make(map[string][]*captureInstanceInfo)
+	for rows.Next() {
+		var schemaName, tableName string
+		var instance captureInstanceInfo
+		if err := rows.Scan(&schemaName, &tableName, &instance.Name, &instance.StartLSN); err != nil {
+			return nil, fmt.Errorf("error scanning result row: %w", err)
+		}
+		var streamID = sqlcapture.JoinStreamID(schemaName, tableName)
+		captureInstances[streamID] = append(captureInstances[streamID], &instance)
+	}
+	return captureInstances, rows.Err()
+}
+
+// listCapturedColumnTypes returns the types of the columns captured by a capture instance.
+func listCapturedColumnTypes(ctx context.Context, conn *sql.DB, instanceName string) (map[string]any, error) {
+	const query = `SELECT cc.column_name, cc.column_type
+	                 FROM cdc.captured_columns AS cc
+					 JOIN cdc.change_tables AS ct ON cc.object_id = ct.object_id
+					 WHERE ct.capture_instance = @p1;`
+	var rows, err = conn.QueryContext(ctx, query, instanceName)
+	if err != nil {
+		return nil, fmt.Errorf("error listing columns of capture instance %q: %w", instanceName, err)
+	}
+	defer rows.Close()
+
+	var columnTypes = make(map[string]any)
+	for rows.Next() {
+		var columnName, columnType string
+		if err := rows.Scan(&columnName, &columnType); err != nil {
+			return nil, fmt.Errorf("error scanning result row: %w", err)
+		}
+		columnTypes[columnName] = columnType
+	}
+	return columnTypes, rows.Err()
+}
+
+// capturedColumnTypes returns the types of the columns captured by a capture
+// instance. The provided types are retained for preexisting columns since types
+// from discovery include details like text collations which the captured column
+// list lacks.
+func capturedColumnTypes(ctx context.Context, conn *sql.DB, instanceName string, knownTypes map[string]any) (map[string]any, error) {
+	var capturedTypes, err = listCapturedColumnTypes(ctx, conn, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	var columnTypes = make(map[string]any)
+	for column, columnType := range capturedTypes {
+		columnTypes[column] = columnType
+		if knownType, ok := knownTypes[column]; ok {
+			columnTypes[column] = knownType
+		}
 	}
-	return nextLSN, nil
+	return columnTypes, nil
 }
 
 func splitStreamID(streamID string) (string, string) {
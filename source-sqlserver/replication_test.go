@@ -0,0 +1,132 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/estuary/connectors/sqlcapture"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptPollInterval(t *testing.T) {
+	const base, maximum = 500 * time.Millisecond, 4 * time.Second
+
+	var interval = base
+	var intervals []time.Duration
+	for _, result := range []pollResult{pollFull, pollFull, pollFull, pollFull, pollFull, pollFull, pollPartial, pollIdle, pollIdle, pollIdle, pollIdle, pollIdle, pollPartial} {
+		interval = adaptPollInterval(interval, base, maximum, result)
+		intervals = append(intervals, interval)
+	}
+	require.Equal(t, []time.Duration{
+		250 * time.Millisecond, 125 * time.Millisecond, 62500 * time.Microsecond, 31250 * time.Microsecond, 15625 * time.Microsecond, 10 * time.Millisecond,
+		500 * time.Millisecond,
+		1 * time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second,
+		500 * time.Millisecond,
+	}, intervals)
+
+	// The configured interval is never exceeded in either direction when it lies outside the adaptive range.
+	require.Equal(t, 5*time.Millisecond, adaptPollInterval(5*time.Millisecond, 5*time.Millisecond, maximum, pollFull))
+	require.Equal(t, 30*time.Second, adaptPollInterval(30*time.Second, 30*time.Second, maximum, pollIdle))
+}
+
+func TestSelectCaptureInstance(t *testing.T) {
+	var lsn = func(x byte) []byte { return []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, x} }
+	var older = &captureInstanceInfo{Name: "dbo_foo", StartLSN: lsn(10)}
+	var newer = &captureInstanceInfo{Name: "dbo_foo_v2", StartLSN: lsn(20)}
+	var pending = &captureInstanceInfo{Name: "dbo_foo_v3"}
+
+	for _, tc := range []struct {
+		name          string
+		instances     []*captureInstanceInfo
+		fromLSN       []byte
+		current, next *captureInstanceInfo
+	}{
+		{"none", nil, lsn(15), nil, nil},
+		{"single", []*captureInstanceInfo{older}, lsn(15), older, nil},
+		{"single not yet started", []*captureInstanceInfo{newer}, lsn(15), newer, nil},
+		{"before newer", []*captureInstanceInfo{older, newer}, lsn(15), older, newer},
+		{"at newer", []*captureInstanceInfo{older, newer}, lsn(20), newer, nil},
+		{"after newer", []*captureInstanceInfo{older, newer}, lsn(25), newer, nil},
+		{"newer without start LSN", []*captureInstanceInfo{older, pending}, lsn(15), older, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var current, next = selectCaptureInstance(tc.instances, tc.fromLSN)
+			require.Equal(t, tc.current, current)
+			require.Equal(t, tc.next, next)
+		})
+	}
+}
+
+func TestRestoreCaptureInstance(t *testing.T) {
+	var lsn = func(x byte) []byte { return []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, x} }
+	var older = &captureInstanceInfo{Name: "dbo_foo", StartLSN: lsn(10)}
+	var newer = &captureInstanceInfo{Name: "dbo_foo_v2", StartLSN: lsn(20)}
+	var pending = &captureInstanceInfo{Name: "dbo_foo_v3"}
+
+	for _, tc := range []struct {
+		name          string
+		instances     []*captureInstanceInfo
+		restore       string
+		current, next *captureInstanceInfo
+	}{
+		{"missing", []*captureInstanceInfo{newer}, "dbo_foo", nil, nil},
+		{"older", []*captureInstanceInfo{older, newer}, "dbo_foo", older, newer},
+		{"newer", []*captureInstanceInfo{older, newer}, "dbo_foo_v2", newer, nil},
+		{"newer without start LSN", []*captureInstanceInfo{older, pending}, "dbo_foo", older, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var current, next = restoreCaptureInstance(tc.instances, tc.restore)
+			require.Equal(t, tc.current, current)
+			require.Equal(t, tc.next, next)
+		})
+	}
+}
+
+func TestRefreshCaptureInstances(t *testing.T) {
+	var lsn = func(x byte) []byte { return []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, x} }
+	var older = &captureInstanceInfo{Name: "dbo_foo", StartLSN: lsn(10)}
+	var newer = &captureInstanceInfo{Name: "dbo_foo_v2", StartLSN: lsn(20)}
+	var pending = &captureInstanceInfo{Name: "dbo_foo_v3"}
+
+	for _, tc := range []struct {
+		name      string
+		instances []*captureInstanceInfo
+		current   string
+		fromLSN   []byte
+		next      *captureInstanceInfo
+	}{
+		{"newer ahead of position", []*captureInstanceInfo{older, newer}, "dbo_foo", lsn(15), newer},
+		{"newer behind position", []*captureInstanceInfo{older, newer}, "dbo_foo", lsn(25), newer},
+		{"already newest", []*captureInstanceInfo{older, newer}, "dbo_foo_v2", lsn(25), nil},
+		{"newer without start LSN", []*captureInstanceInfo{older, pending}, "dbo_foo", lsn(15), nil},
+		{"current dropped", []*captureInstanceInfo{newer}, "dbo_foo", lsn(25), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var rs = &sqlserverReplicationStream{fromLSN: tc.fromLSN}
+			rs.tables.info = map[string]*tableReplicationInfo{
+				"dbo.foo": {CaptureInstance: tc.current},
+			}
+			rs.updateNextInstances(map[string][]*captureInstanceInfo{"dbo.foo": tc.instances})
+			require.Equal(t, tc.next, rs.tables.info["dbo.foo"].NextInstance)
+		})
+	}
+}
+
+func TestCompareChanges(t *testing.T) {
+	var change = func(table string, lsn, seqval byte) *sqlcapture.ChangeEvent {
+		return &sqlcapture.ChangeEvent{Source: &sqlserverSourceInfo{
+			SourceCommon: sqlcapture.SourceCommon{Schema: "dbo", Table: table},
+			LSN:          []byte{0, lsn},
+			SeqVal:       []byte{0, seqval},
+		}}
+	}
+	var changes = []*sqlcapture.ChangeEvent{change("a", 2, 1), change("b", 1, 2), change("c", 2, 0), change("d", 1, 1)}
+	slices.SortStableFunc(changes, compareChanges)
+
+	var order []string
+	for _, change := range changes {
+		order = append(order, change.Source.Common().Table)
+	}
+	require.Equal(t, []string{"d", "b", "c", "a"}, order)
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -13,6 +15,42 @@ func (db *sqlserverDatabase) WatermarksTable() string {
 	return db.config.Advanced.WatermarksTable
 }
 
+// PositionalWatermarks returns true if the capture is configured to use CDC LSNs
+// as watermarks instead of writing into the watermarks table.
+func (db *sqlserverDatabase) PositionalWatermarks() bool {
+	return db.config.Advanced.ReadOnlyWatermarks
+}
+
+// CurrentPosition returns the LSN of the latest transaction recorded by CDC, encoded
+// in the same way as replication cursors. The maximum LSN of the CDC change tables
+// isn't used, since it may belong to a CDC bookkeeping entry which replication
+// doesn't advance to until some later transaction.
+func (db *sqlserverDatabase) CurrentPosition(ctx context.Context) (string, error) {
+	var maxLSN, err = cdcGetMaxTransactionLSN(ctx, db.conn)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(maxLSN), nil
+}
+
+// PositionReached returns true if the cursor LSN is at or past the position LSN.
+func (db *sqlserverDatabase) PositionReached(cursor, position string) (bool, error) {
+	cursorLSN, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return false, fmt.Errorf("error decoding cursor: %w", err)
+	}
+	positionLSN, err := base64.StdEncoding.DecodeString(position)
+	if err != nil {
+		return false, fmt.Errorf("error decoding position: %w", err)
+	}
+	return bytes.Compare(cursorLSN, positionLSN) >= 0, nil
+}
+
+// SignalsTable returns the name of the table from which signals are read.
+func (db *sqlserverDatabase) SignalsTable() string {
+	return db.config.Advanced.SignalsTable
+}
+
 func (db *sqlserverDatabase) createWatermarksTable(ctx context.Context) error {
 	var tableName = db.config.Advanced.WatermarksTable
 	rows, err := db.conn.QueryContext(ctx, fmt.Sprintf(`CREATE TABLE %s(slot INTEGER PRIMARY KEY, watermark TEXT);`, tableName))
@@ -1,9 +1,12 @@
 package sqlcapture
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"math"
 	"math/rand"
 	"slices"
 	"strings"
@@ -49,6 +52,9 @@ func migrateState(state *PersistentState, bindings []*pf.CaptureSpec_Binding) (b
 		if err := pf.UnmarshalStrict(b.ResourceConfigJson, &res); err != nil {
 			return false, fmt.Errorf("parsing resource config: %w", err)
 		}
+		if res.Transactions {
+			continue // The transaction metadata binding has no state
+		}
 
 		var streamID = JoinStreamID(res.Namespace, res.Stream)
 
@@ -123,11 +129,52 @@ type TableState struct {
 	Metadata json.RawMessage `json:"metadata,omitempty"`
 	// BackfilledCount is a counter of the number of rows backfilled.
 	BackfilledCount int `json:"backfilled"`
+	// EstimatedRows is an estimate of the total number of rows to be backfilled,
+	// obtained from database statistics when the backfill began. It is zero when
+	// no estimate is available.
+	EstimatedRows int `json:"estimated_rows,omitempty"`
+	// BackfillWhere is an additional condition which limits the rows scanned by
+	// the current backfill, when it was requested via the signals table.
+	BackfillWhere string `json:"backfill_where,omitempty"`
+	// RenamedTo is the stream ID under which the table is being captured, if
+	// it was renamed after the binding was created.
+	RenamedTo string `json:"renamed_to,omitempty"`
+	// Ranges holds the progress of each key range of the table when its precise
+	// backfill has been split into key ranges which are scanned concurrently. The
+	// Scanned property of the table is unused while it's set.
+	Ranges []*BackfillRange `json:"ranges,omitempty"`
+	// ScanEnd is the inclusive upper bound on the row keys which ScanTableChunk
+	// should scan, or nil if the scan is unbounded. It's only set on the copies of
+	// the table state which are passed to ScanTableChunk for a key range.
+	ScanEnd []byte `json:"-"`
 	// dirty is set whenever the table state changes, and cleared whenever
 	// a state update is emitted. It should never be serialized itself.
 	dirty bool
 }
 
+// BackfillRange holds the backfill progress of one range of the key space of a table,
+// which consists of the row keys greater than the End of the previous range and no
+// greater than its own End.
+type BackfillRange struct {
+	Scanned []byte `json:"scanned"`        // Row key of the last row backfilled from the range, initially the End of the previous range
+	End     []byte `json:"end,omitempty"`  // Inclusive upper bound of the range, or nil for the last range
+	Done    bool   `json:"done,omitempty"` // Set once the range has been backfilled completely
+}
+
+// keyBackfilled returns true if the row with the provided key lies within the
+// portion of the table which has already been backfilled precisely.
+func (s *TableState) keyBackfilled(rowKey []byte) bool {
+	if s.Ranges == nil {
+		return compareTuples(rowKey, s.Scanned) <= 0
+	}
+	for _, keyRange := range s.Ranges {
+		if keyRange.End == nil || compareTuples(rowKey, keyRange.End) <= 0 {
+			return keyRange.Done || compareTuples(rowKey, keyRange.Scanned) <= 0
+		}
+	}
+	return false
+}
+
 // The table's mode can be one of:
 //
 //	Ignore: The table is being deliberately ignored.
@@ -154,7 +201,17 @@ type Capture struct {
 	Output   *boilerplate.PullOutput // The encoder to which records and state updates are written
 	Database Database                // The database-specific interface which is operated by the generic Capture logic
 
-	discovery map[string]*DiscoveryInfo // Cached result of the most recent table discovery request
+	discovery  map[string]*DiscoveryInfo // Cached result of the most recent table discovery request
+	debezium   *DebeziumInfo             // Set when change metadata should be emitted as Debezium envelopes
+	signals    string                    // Stream ID of the signals table, or empty if signals are disabled
+	positional bool                      // Set when watermarks are replication log positions rather than written UUIDs
+
+	progress map[string]*backfillProgress // Per-stream backfill progress reporting information
+
+	healthChecked time.Time // The last time the replication health of the database was checked
+
+	transactions        *transactionTracker // Set when replicated changes should carry transaction metadata
+	transactionsBinding *Binding            // The binding which receives transaction BEGIN/END documents, if any
 
 	// A mutex-guarded list of checkpoint cursor values. Values are appended by
 	// emitState() whenever it outputs a checkpoint and removed whenever the
@@ -169,6 +226,8 @@ const (
 	heartbeatWatermarkInterval = 60 * time.Second // When streaming indefinitely, write a heartbeat watermark this often
 	streamIdleWarning          = 60 * time.Second // After `streamIdleWarning` has elapsed since the last replication event, we log a warning.
 	streamProgressInterval     = 60 * time.Second // After `streamProgressInterval` the replication streaming code may log a progress report.
+	backfillProgressInterval   = 60 * time.Second // After `backfillProgressInterval` the progress of each backfilling table is logged.
+	replicationHealthInterval  = 5 * time.Minute  // The replication health of the database is checked at most this often.
 )
 
 var (
@@ -209,12 +268,22 @@ func (c *Capture) Run(ctx context.Context) (err error) {
 			return fmt.Errorf("error activating table %q: %w", streamID, err)
 		}
 	}
-	var watermarks = c.Database.WatermarksTable()
-	if c.discovery[watermarks] == nil {
-		return fmt.Errorf("watermarks table %q does not exist", watermarks)
+	if c.positional = c.Database.PositionalWatermarks(); !c.positional {
+		var watermarks = c.Database.WatermarksTable()
+		if c.discovery[watermarks] == nil {
+			return fmt.Errorf("watermarks table %q does not exist", watermarks)
+		}
+		if err := replStream.ActivateTable(ctx, watermarks, c.discovery[watermarks].PrimaryKey, c.discovery[watermarks], nil); err != nil {
+			return fmt.Errorf("error activating table %q: %w", watermarks, err)
+		}
 	}
-	if err := replStream.ActivateTable(ctx, watermarks, c.discovery[watermarks].PrimaryKey, c.discovery[watermarks], nil); err != nil {
-		return fmt.Errorf("error activating table %q: %w", watermarks, err)
+	if c.signals = strings.ToLower(c.Database.SignalsTable()); c.signals != "" {
+		if c.discovery[c.signals] == nil {
+			return fmt.Errorf("signals table %q does not exist", c.signals)
+		}
+		if err := replStream.ActivateTable(ctx, c.signals, c.discovery[c.signals].PrimaryKey, c.discovery[c.signals], nil); err != nil {
+			return fmt.Errorf("error activating table %q: %w", c.signals, err)
+		}
 	}
 	if err := replStream.StartReplication(ctx); err != nil {
 		return fmt.Errorf("error starting replication: %w", err)
@@ -237,9 +306,9 @@ func (c *Capture) Run(ctx context.Context) (err error) {
 	// any "Pending" streams into the "Backfill" state. This helps ensure that
 	// a given stream only ever observes replication events which occur *after*
 	// the connector was started.
-	var watermark = uuid.New().String()
-	if err := c.Database.WriteWatermark(ctx, watermark); err != nil {
-		return fmt.Errorf("error writing next watermark: %w", err)
+	watermark, err := c.nextWatermark(ctx)
+	if err != nil {
+		return err
 	}
 	if err := c.streamCatchup(ctx, replStream, watermark); err != nil {
 		return fmt.Errorf("error streaming until watermark: %w", err)
@@ -251,34 +320,11 @@ func (c *Capture) Run(ctx context.Context) (err error) {
 		logrus.WithFields(logrus.Fields{"stream": streamID, "mode": binding.Resource.Mode}).Info("activating replication for stream")
 
 		var state = c.State.Streams[stateKey]
-		var discoveryInfo = c.discovery[streamID]
-		switch binding.Resource.Mode {
-		case BackfillModeAutomatic:
-			if len(state.KeyColumns) == 0 {
-				logrus.WithField("stream", streamID).Info("autoselected keyless backfill mode (table has no primary key)")
-				state.Mode = TableModeKeylessBackfill
-			} else if discoveryInfo != nil && discoveryInfo.UnpredictableKeyOrdering {
-				logrus.WithField("stream", streamID).Info("autoselected unfiltered (normal) backfill mode (database key ordering is unpredictable)")
-				state.Mode = TableModeUnfilteredBackfill
-			} else {
-				logrus.WithField("stream", streamID).Info("autoselected precise backfill mode")
-				state.Mode = TableModePreciseBackfill
-			}
-		case BackfillModePrecise:
-			logrus.WithField("stream", streamID).Info("user selected precise backfill mode")
-			state.Mode = TableModePreciseBackfill
-		case BackfillModeNormal:
-			logrus.WithField("stream", streamID).Info("user selected unfiltered (normal) backfill mode")
-			state.Mode = TableModeUnfilteredBackfill
-		case BackfillModeWithoutKey:
-			logrus.WithField("stream", streamID).Info("user selected keyless backfill mode")
-			state.Mode = TableModeKeylessBackfill
-		case BackfillModeOnlyChanges:
-			logrus.WithField("stream", streamID).Info("user selected only changes, skipping backfill")
-			state.Mode = TableModeActive
-		default:
-			return fmt.Errorf("invalid backfill mode %q for stream %q", binding.Resource.Mode, streamID)
+		var mode, err = c.selectBackfillMode(binding, state)
+		if err != nil {
+			return err
 		}
+		state.Mode = mode
 		state.dirty = true
 		c.State.Streams[stateKey] = state
 
@@ -306,7 +352,7 @@ func (c *Capture) Run(ctx context.Context) (err error) {
 		var stateKey = binding.StateKey
 		if !c.Database.ShouldBackfill(streamID) {
 			var state = c.State.Streams[stateKey]
-			if state.Scanned == nil {
+			if state.Scanned == nil && state.Ranges == nil {
 				logrus.WithField("stream", streamID).Info("skipping backfill for stream")
 			} else {
 				logrus.WithFields(logrus.Fields{
@@ -316,40 +362,79 @@ func (c *Capture) Run(ctx context.Context) (err error) {
 			}
 			state.Mode = TableModeActive
 			state.Scanned = nil
+			state.Ranges = nil
+			state.EstimatedRows = 0
+			state.BackfillWhere = ""
 			state.dirty = true
 			c.State.Streams[stateKey] = state
 		}
 	}
 
-	// Backfill any tables which require it
-	for c.BindingsCurrentlyBackfilling() != nil {
-		var watermark = uuid.New().String()
-		if err := c.Database.WriteWatermark(ctx, watermark); err != nil {
-			return fmt.Errorf("error writing next watermark: %w", err)
-		} else if err := c.streamToWatermark(ctx, replStream, watermark); err != nil {
-			return fmt.Errorf("error streaming until watermark: %w", err)
-		} else if err := c.emitState(); err != nil {
+	for {
+		// Backfill any tables which require it
+		for c.BindingsCurrentlyBackfilling() != nil {
+			if err := c.checkReplicationHealth(ctx); err != nil {
+				return err
+			} else if watermark, err := c.nextWatermark(ctx); err != nil {
+				return err
+			} else if err := c.streamToWatermark(ctx, replStream, watermark); err != nil {
+				return fmt.Errorf("error streaming until watermark: %w", err)
+			} else if err := c.emitState(); err != nil {
+				return err
+			} else if err := c.backfillStreams(ctx); err != nil {
+				return fmt.Errorf("error performing backfill: %w", err)
+			}
+		}
+
+		// Once all backfills are complete, make sure an up-to-date state checkpoint
+		// gets emitted. This ensures that streams reliably transition into the Active
+		// state on the first connector run even if there are no replication events
+		// occurring.
+		logrus.Info("no tables currently require backfilling")
+		if err := c.emitState(); err != nil {
 			return err
-		} else if err := c.backfillStreams(ctx); err != nil {
-			return fmt.Errorf("error performing backfill: %w", err)
 		}
-	}
 
-	// Once all backfills are complete, make sure an up-to-date state checkpoint
-	// gets emitted. This ensures that streams reliably transition into the Active
-	// state on the first connector run even if there are no replication events
-	// occurring.
-	logrus.Info("no tables currently require backfilling")
-	if err := c.emitState(); err != nil {
-		return err
+		// Once there is no more backfilling to do, just stream changes and emit state
+		// updates on every transaction commit. This only returns without an error if
+		// some replication event (such as a TRUNCATE) has put a table back into one of
+		// the backfill modes, in which case we loop around and backfill it again.
+		if err := c.streamForever(ctx, replStream); err != nil {
+			return err
+		}
 	}
+}
 
-	// Once there is no more backfilling to do, just stream changes forever and emit
-	// state updates on every transaction commit.
-	if err := c.streamForever(ctx, replStream); err != nil {
-		return err
+// selectBackfillMode determines which table mode a binding should enter when its
+// backfill begins, based on the backfill mode selected in the resource config.
+func (c *Capture) selectBackfillMode(binding *Binding, state *TableState) (string, error) {
+	var streamID = binding.StreamID
+	var discoveryInfo = c.discovery[streamID]
+	switch binding.Resource.Mode {
+	case BackfillModeAutomatic:
+		if len(state.KeyColumns) == 0 {
+			logrus.WithField("stream", streamID).Info("autoselected keyless backfill mode (table has no primary key)")
+			return TableModeKeylessBackfill, nil
+		} else if discoveryInfo != nil && discoveryInfo.UnpredictableKeyOrdering {
+			logrus.WithField("stream", streamID).Info("autoselected unfiltered (normal) backfill mode (database key ordering is unpredictable)")
+			return TableModeUnfilteredBackfill, nil
+		}
+		logrus.WithField("stream", streamID).Info("autoselected precise backfill mode")
+		return TableModePreciseBackfill, nil
+	case BackfillModePrecise:
+		logrus.WithField("stream", streamID).Info("user selected precise backfill mode")
+		return TableModePreciseBackfill, nil
+	case BackfillModeNormal:
+		logrus.WithField("stream", streamID).Info("user selected unfiltered (normal) backfill mode")
+		return TableModeUnfilteredBackfill, nil
+	case BackfillModeWithoutKey:
+		logrus.WithField("stream", streamID).Info("user selected keyless backfill mode")
+		return TableModeKeylessBackfill, nil
+	case BackfillModeOnlyChanges:
+		logrus.WithField("stream", streamID).Info("user selected only changes, skipping backfill")
+		return TableModeActive, nil
 	}
-	return nil
+	return "", fmt.Errorf("invalid backfill mode %q for stream %q", binding.Resource.Mode, streamID)
 }
 
 func (c *Capture) updateState(ctx context.Context) error {
@@ -358,6 +443,26 @@ func (c *Capture) updateState(ctx context.Context) error {
 		c.State.Streams = make(map[boilerplate.StateKey]*TableState)
 	}
 
+	// Bindings whose tables were renamed while being captured continue to
+	// be captured under the new name.
+	var bindings []*Binding
+	for _, binding := range c.Bindings {
+		bindings = append(bindings, binding)
+	}
+	for _, binding := range bindings {
+		var state, ok = c.State.Streams[binding.StateKey]
+		if !ok || state.RenamedTo == "" || state.RenamedTo == binding.StreamID || state.Mode == TableModeIgnore {
+			continue
+		}
+		if other, ok := c.Bindings[state.RenamedTo]; ok && other != binding {
+			return fmt.Errorf("table %q was renamed to %q, which is also a configured binding of this capture", binding.StreamID, state.RenamedTo)
+		}
+		logrus.WithFields(logrus.Fields{"stream": binding.StreamID, "renamedTo": state.RenamedTo}).Info("capturing renamed table under its new name")
+		delete(c.Bindings, binding.StreamID)
+		binding.StreamID = state.RenamedTo
+		c.Bindings[binding.StreamID] = binding
+	}
+
 	// Streams may be added to the catalog at various times. We need to
 	// initialize new state entries for these streams, and while we're at
 	// it this is a good time to sanity-check the primary key configuration.
@@ -366,6 +471,12 @@ func (c *Capture) updateState(ctx context.Context) error {
 		var stateKey = binding.StateKey
 		var discoveryInfo = c.discovery[streamID]
 		if discoveryInfo == nil {
+			// A table which was dropped while being captured remains ignored
+			// until a table of the same name exists again.
+			if state, ok := c.State.Streams[stateKey]; ok && state.Mode == TableModeIgnore {
+				logrus.WithField("stream", streamID).Warn("ignoring binding for table which no longer exists")
+				continue
+			}
 			return fmt.Errorf("table %q is a configured binding of this capture, but doesn't exist or isn't visible with current permissions", streamID)
 		}
 
@@ -486,18 +597,40 @@ func (c *Capture) updateState(ctx context.Context) error {
 }
 
 func (c *Capture) streamCatchup(ctx context.Context, replStream ReplicationStream, watermark string) error {
-	return c.streamToWatermarkWithOptions(ctx, replStream, watermark, true)
+	return c.streamToWatermarkWithOptions(ctx, replStream, watermark, nil, true)
 }
 
 func (c *Capture) streamToWatermark(ctx context.Context, replStream ReplicationStream, watermark string) error {
-	return c.streamToWatermarkWithOptions(ctx, replStream, watermark, false)
+	return c.streamToWatermarkWithOptions(ctx, replStream, watermark, nil, false)
 }
 
+// nextWatermark establishes a new watermark, either by writing a random UUID into the
+// watermarks table or, when positional watermarks are in use, by reading the current
+// position of the replication log.
+func (c *Capture) nextWatermark(ctx context.Context) (string, error) {
+	if c.positional {
+		var position, err = c.Database.CurrentPosition(ctx)
+		if err != nil {
+			return "", fmt.Errorf("error reading replication position: %w", err)
+		}
+		return position, nil
+	}
+	var watermark = uuid.New().String()
+	if err := c.Database.WriteWatermark(ctx, watermark); err != nil {
+		return "", fmt.Errorf("error writing next watermark: %w", err)
+	}
+	return watermark, nil
+}
+
+// streamForever streams replication events indefinitely, writing a heartbeat
+// watermark periodically. It returns nil once some table requires backfilling.
 func (c *Capture) streamForever(ctx context.Context, replStream ReplicationStream) error {
 	logrus.Info("streaming replication events indefinitely")
 	for ctx.Err() == nil {
 		// Spawn a worker goroutine which will write the watermark value after the appropriate interval.
+		// Positional watermarks can't be known in advance, so they're instead read after the interval.
 		var watermark = uuid.New().String()
+		var positions = make(chan string, 1)
 		var group, workerCtx = errgroup.WithContext(ctx)
 		group.Go(func() error {
 			select {
@@ -505,6 +638,15 @@ func (c *Capture) streamForever(ctx context.Context, replStream ReplicationStrea
 				logrus.WithField("watermark", watermark).Warn("not writing watermark due to context cancellation")
 				return workerCtx.Err()
 			case <-time.After(heartbeatWatermarkInterval):
+				if c.positional {
+					var position, err = c.Database.CurrentPosition(workerCtx)
+					if err != nil {
+						return fmt.Errorf("error reading replication position: %w", err)
+					}
+					logrus.WithField("position", position).Debug("watermark position read")
+					positions <- position
+					return nil
+				}
 				if err := c.Database.WriteWatermark(workerCtx, watermark); err != nil {
 					logrus.WithField("watermark", watermark).Error("failed to write watermark")
 					return fmt.Errorf("error writing next watermark: %w", err)
@@ -517,7 +659,11 @@ func (c *Capture) streamForever(ctx context.Context, replStream ReplicationStrea
 		// Perform replication streaming until the watermark is reached (or the
 		// watermark-writing thread returns an error which cancels the context).
 		group.Go(func() error {
-			if err := c.streamCatchup(workerCtx, replStream, watermark); err != nil {
+			var watermark = watermark
+			if c.positional {
+				watermark = "" // Not known until received from the worker goroutine
+			}
+			if err := c.streamToWatermarkWithOptions(workerCtx, replStream, watermark, positions, true); err != nil {
 				return fmt.Errorf("error streaming until watermark: %w", err)
 			}
 			return nil
@@ -526,6 +672,14 @@ func (c *Capture) streamForever(ctx context.Context, replStream ReplicationStrea
 		if err := group.Wait(); err != nil {
 			return err
 		}
+		if err := c.checkReplicationHealth(ctx); err != nil {
+			return err
+		}
+
+		if c.BindingsCurrentlyBackfilling() != nil {
+			logrus.Info("some tables require backfilling, suspending indefinite streaming")
+			return nil
+		}
 	}
 	return ctx.Err()
 }
@@ -542,11 +696,23 @@ func (c *Capture) streamForever(ctx context.Context, replStream ReplicationStrea
 //
 // Both operations are implemented as a single function here with a parameter
 // controlling checkpoint behavior because they're so similar.
-func (c *Capture) streamToWatermarkWithOptions(ctx context.Context, replStream ReplicationStream, watermark string, reportFlush bool) error {
+//
+// When positional watermarks are in use the watermark is a replication log position,
+// and streaming stops on the first flush at or past that position. A position which
+// isn't known yet may instead be delivered via the positions channel later on.
+func (c *Capture) streamToWatermarkWithOptions(ctx context.Context, replStream ReplicationStream, watermark string, positions <-chan string, reportFlush bool) error {
 	logrus.WithField("watermark", watermark).Info("streaming to watermark")
 	var watermarksTable = c.Database.WatermarksTable()
 	var watermarkReached = false
 
+	// A position which has already been flushed won't be reached by any future
+	// flush event, so it has to be checked up front.
+	if c.positional && watermark != "" {
+		if reached, err := c.positionReached(watermark); err != nil || reached {
+			return err
+		}
+	}
+
 	// Log a warning and perform replication diagnostics if we don't observe the watermark within a few minutes
 	var diagnosticsTimeout = time.AfterFunc(2*heartbeatWatermarkInterval, func() {
 		logrus.Warn("replication streaming has been ongoing for an unexpectedly long amount of time, running replication diagnostics")
@@ -561,18 +727,52 @@ func (c *Capture) streamToWatermarkWithOptions(ctx context.Context, replStream R
 	var eventCount int
 	defer func() { logrus.WithField("events", eventCount).Info("processed replication events") }()
 
-	for event := range replStream.Events() {
+	var events = replStream.Events()
+	for {
+		var event DatabaseEvent
+		select {
+		case position := <-positions:
+			logrus.WithField("watermark", position).Info("streaming to watermark")
+			if reached, err := c.positionReached(position); err != nil || reached {
+				return err
+			}
+			watermark = position
+			continue
+		case next, ok := <-events:
+			if !ok {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return errWatermarkNotReached
+			}
+			event = next
+		}
 		eventCount++
 
 		// Flush events update the checkpointed LSN and trigger a state update.
 		// If this is the commit after the target watermark, it also ends the loop.
 		if event, ok := event.(*FlushEvent); ok {
+			if err := c.endTransaction(); err != nil {
+				return err
+			}
 			c.State.Cursor = event.Cursor
 			if reportFlush {
 				if err := c.emitState(); err != nil {
 					return fmt.Errorf("error emitting state update: %w", err)
 				}
 			}
+			// A capture which is far behind may stream for a long time before reaching
+			// the watermark, and that's when its position is most at risk.
+			if err := c.checkReplicationHealth(ctx); err != nil {
+				return err
+			}
+			if c.positional && watermark != "" {
+				var reached, err = c.positionReached(watermark)
+				if err != nil {
+					return err
+				}
+				watermarkReached = reached
+			}
 			if watermarkReached {
 				return nil
 			}
@@ -581,7 +781,7 @@ func (c *Capture) streamToWatermarkWithOptions(ctx context.Context, replStream R
 
 		// Check for watermark change events and set a flag when the target watermark is reached.
 		// The subsequent FlushEvent will exit the loop.
-		if event, ok := event.(*ChangeEvent); ok {
+		if event, ok := event.(*ChangeEvent); ok && !c.positional {
 			if event.Operation != DeleteOp && event.Source.Common().StreamID() == watermarksTable {
 				var actual = event.After["watermark"]
 				logrus.WithFields(logrus.Fields{"expected": watermark, "actual": actual}).Debug("watermark change")
@@ -595,10 +795,19 @@ func (c *Capture) streamToWatermarkWithOptions(ctx context.Context, replStream R
 			return err
 		}
 	}
-	if err := ctx.Err(); err != nil {
-		return err
+}
+
+// positionReached returns true if the most recently flushed cursor is at or
+// past the provided replication log position.
+func (c *Capture) positionReached(position string) (bool, error) {
+	if c.State.Cursor == "" {
+		return false, nil
+	}
+	var reached, err = c.Database.PositionReached(c.State.Cursor, position)
+	if err != nil {
+		return false, fmt.Errorf("error comparing cursor %q to watermark position %q: %w", c.State.Cursor, position, err)
 	}
-	return errWatermarkNotReached
+	return reached, nil
 }
 
 func (c *Capture) handleReplicationEvent(event DatabaseEvent) error {
@@ -622,12 +831,32 @@ func (c *Capture) handleReplicationEvent(event DatabaseEvent) error {
 		return nil
 	}
 
+	if event, ok := event.(*TruncateEvent); ok {
+		return c.handleTruncate(event)
+	}
+	if event, ok := event.(*TableDropEvent); ok {
+		return c.handleTableDrop(event)
+	}
+	if event, ok := event.(*TableRenameEvent); ok {
+		return c.handleTableRename(event)
+	}
+	if event, ok := event.(*TableReplaceEvent); ok {
+		return c.handleTableReplace(event)
+	}
+
 	// Any other events processed here must be ChangeEvents.
 	if _, ok := event.(*ChangeEvent); !ok {
 		return fmt.Errorf("unhandled replication event %q", event.String())
 	}
 	var change = event.(*ChangeEvent)
 	var streamID = change.Source.Common().StreamID()
+	observeReplicationEvent(change)
+	if c.signals != "" && streamID == c.signals {
+		if change.Operation != InsertOp {
+			return nil
+		}
+		return c.handleSignal(change)
+	}
 	var binding = c.Bindings[streamID]
 	var tableState *TableState
 	if binding != nil {
@@ -642,6 +871,15 @@ func (c *Capture) handleReplicationEvent(event DatabaseEvent) error {
 		}).Debug("ignoring stream")
 		return nil
 	}
+
+	// Apply the row filter of the binding, if there is one.
+	var filtered, err = filterChange(binding.Filter, change)
+	if err != nil {
+		return fmt.Errorf("error applying row filter to replication event for %q: %w", streamID, err)
+	} else if filtered == nil {
+		return nil
+	}
+	change = filtered
 	if tableState.Mode == TableModeActive || tableState.Mode == TableModeKeylessBackfill || tableState.Mode == TableModeUnfilteredBackfill {
 		if err := c.emitChange(change); err != nil {
 			return fmt.Errorf("error handling replication event for %q: %w", streamID, err)
@@ -652,7 +890,7 @@ func (c *Capture) handleReplicationEvent(event DatabaseEvent) error {
 		// When a table is being backfilled precisely, replication events lying within the
 		// already-backfilled portion of the table should be emitted, while replication events
 		// beyond that point should be ignored (since we'll reach that row later in the backfill).
-		if compareTuples(change.RowKey, tableState.Scanned) <= 0 {
+		if tableState.keyBackfilled(change.RowKey) {
 			if err := c.emitChange(change); err != nil {
 				return fmt.Errorf("error handling replication event for %q: %w", streamID, err)
 			}
@@ -663,31 +901,434 @@ func (c *Capture) handleReplicationEvent(event DatabaseEvent) error {
 	return fmt.Errorf("table %q in invalid mode %q", streamID, tableState.Mode)
 }
 
+// filterChange applies the row filter of a binding to a replicated change, returning
+// the change which should be emitted in its place or nil if it should be omitted. An
+// update which moves a row out of the filter becomes a deletion. Row images lacking
+// some filtered columns are assumed to match, so that deletions are never lost.
+func filterChange(filter *RowFilter, event *ChangeEvent) (*ChangeEvent, error) {
+	if filter == nil {
+		return event, nil
+	}
+	var matches = func(row map[string]interface{}) (bool, error) {
+		if row == nil || !filter.HasColumns(row) {
+			return true, nil
+		}
+		return filter.Matches(row)
+	}
+
+	switch event.Operation {
+	case InsertOp:
+		if ok, err := matches(event.After); err != nil || !ok {
+			return nil, err
+		}
+	case DeleteOp:
+		if ok, err := matches(event.Before); err != nil || !ok {
+			return nil, err
+		}
+	case UpdateOp:
+		if ok, err := matches(event.After); err != nil {
+			return nil, err
+		} else if ok {
+			return event, nil
+		}
+		if ok, err := matches(event.Before); err != nil || !ok {
+			return nil, err
+		}
+		var deleted = event.Before
+		if deleted == nil {
+			deleted = event.After
+		}
+		return &ChangeEvent{
+			Operation: DeleteOp,
+			RowKey:    event.RowKey,
+			Source:    event.Source,
+			Before:    deleted,
+		}, nil
+	}
+	return event, nil
+}
+
+// handleTruncate applies the configured truncate handling of a binding when
+// a TRUNCATE of the corresponding table is observed in the replication stream.
+func (c *Capture) handleTruncate(event *TruncateEvent) error {
+	var streamID = event.Source.Common().StreamID()
+	var binding = c.Bindings[streamID]
+	if binding == nil {
+		return nil
+	}
+	var state = c.State.Streams[binding.StateKey]
+	if state == nil || state.Mode == "" || state.Mode == TableModeIgnore || state.Mode == TableModePending {
+		return nil
+	}
+
+	var logEntry = logrus.WithFields(logrus.Fields{"stream": streamID, "mode": state.Mode})
+	switch binding.Resource.OnTruncate {
+	case TruncateModeBackfill:
+		var restarted, err = c.restartBackfill(binding, state)
+		if err != nil {
+			return err
+		} else if !restarted {
+			logEntry.Warn("ignoring TRUNCATE on active table (backfills are disabled for this table)")
+			return nil
+		}
+		logEntry.WithField("newMode", state.Mode).Info("table truncated, restarting backfill")
+		return nil
+	case TruncateModeMarker:
+		logEntry.Info("table truncated, emitting truncate marker")
+		if err := c.emitChange(&ChangeEvent{Operation: TruncateOp, Source: event.Source}); err != nil {
+			return fmt.Errorf("error emitting truncate marker for %q: %w", streamID, err)
+		}
+		return nil
+	}
+	logEntry.Warn("ignoring TRUNCATE on active table")
+	return nil
+}
+
+// A signal is a request inserted into the signals table, which is stored as a
+// JSON object in the 'signal' column.
+type signal struct {
+	Action string `json:"action"`
+	Table  string `json:"table"`           // The fully-qualified name of the table the signal applies to.
+	Where  string `json:"where,omitempty"` // An optional row filter expression limiting the rows which are backfilled.
+}
+
+const (
+	signalActionBackfill = "backfill" // Re-backfill the table, or just the rows matching `where`
+)
+
+// handleSignal performs the action requested by a row inserted into the signals
+// table. Malformed signals and signals for tables which aren't being captured are
+// logged and ignored, so that a mistake can't bring the capture to a halt.
+func (c *Capture) handleSignal(event *ChangeEvent) error {
+	var bs []byte
+	var logEntry = logrus.WithField("signal", event.After["signal"])
+	switch raw := event.After["signal"].(type) {
+	case string:
+		bs = []byte(raw)
+	case []byte:
+		bs = raw
+	case json.RawMessage:
+		bs = raw
+	default:
+		logEntry.Warn("ignoring signal without a 'signal' column value")
+		return nil
+	}
+	var sig signal
+	if err := json.Unmarshal(bs, &sig); err != nil {
+		logEntry.WithField("err", err).Warn("ignoring malformed signal")
+		return nil
+	}
+	logEntry = logrus.WithFields(logrus.Fields{"action": sig.Action, "table": sig.Table, "where": sig.Where})
+
+	if sig.Action != signalActionBackfill {
+		logEntry.Warn("ignoring signal with unknown action")
+		return nil
+	}
+	if sig.Where != "" {
+		if _, err := ParseRowFilter(sig.Where); err != nil {
+			logEntry.WithField("err", err).Warn("ignoring backfill signal: invalid 'where' condition")
+			return nil
+		}
+	}
+	var schema, table, ok = strings.Cut(sig.Table, ".")
+	if !ok {
+		logEntry.Warn("ignoring backfill signal: table name must be fully-qualified as \"<schema>.<table>\"")
+		return nil
+	}
+	var binding = c.Bindings[JoinStreamID(schema, table)]
+	if binding == nil {
+		logEntry.Warn("ignoring backfill signal: table is not captured")
+		return nil
+	}
+	var state = c.State.Streams[binding.StateKey]
+	if state == nil || state.Mode == "" || state.Mode == TableModeIgnore || state.Mode == TableModePending {
+		logEntry.Warn("ignoring backfill signal: table is not active")
+		return nil
+	}
+
+	var restarted, err = c.restartBackfill(binding, state)
+	if err != nil {
+		return err
+	} else if !restarted {
+		logEntry.Warn("ignoring backfill signal: backfills are disabled for this table")
+		return nil
+	}
+	if sig.Where != "" {
+		// A precise backfill only emits replication events for rows it has already
+		// scanned, so changes to rows outside the requested range would be lost.
+		if state.Mode == TableModePreciseBackfill {
+			state.Mode = TableModeUnfilteredBackfill
+		}
+		state.BackfillWhere = sig.Where
+	}
+	logEntry.WithField("newMode", state.Mode).Info("backfill requested by signal, restarting backfill")
+	return nil
+}
+
+// restartBackfill puts a binding back into the appropriate backfill mode and
+// discards any backfill progress, returning false if the table shouldn't be
+// backfilled again.
+func (c *Capture) restartBackfill(binding *Binding, state *TableState) (bool, error) {
+	var mode, err = c.selectBackfillMode(binding, state)
+	if err != nil {
+		return false, err
+	}
+	if mode == TableModeActive || !c.Database.ShouldBackfill(binding.StreamID) {
+		return false, nil
+	}
+	state.Mode = mode
+	state.Scanned = nil
+	state.Ranges = nil
+	state.BackfilledCount = 0
+	state.EstimatedRows = 0
+	state.BackfillWhere = ""
+	state.dirty = true
+	delete(c.progress, binding.StreamID)
+	return true, nil
+}
+
+// handleTableDrop stops capturing a table which was dropped.
+func (c *Capture) handleTableDrop(event *TableDropEvent) error {
+	var binding = c.Bindings[event.StreamID]
+	if binding == nil {
+		return nil
+	}
+	var state = c.State.Streams[binding.StateKey]
+	if state == nil || state.Mode == "" || state.Mode == TableModeIgnore {
+		return nil
+	}
+	logrus.WithFields(logrus.Fields{"stream": event.StreamID, "mode": state.Mode}).Warn("table dropped, no longer capturing it")
+	state.Mode = TableModeIgnore
+	state.Scanned = nil
+	state.Ranges = nil
+	state.dirty = true
+	return nil
+}
+
+// handleTableRename moves the binding of a renamed table to its new name, so
+// that subsequent changes and backfill queries are associated with it.
+func (c *Capture) handleTableRename(event *TableRenameEvent) error {
+	var binding = c.Bindings[event.StreamID]
+	if binding == nil {
+		return nil
+	}
+	var newStreamID = JoinStreamID(event.NewSchema, event.NewTable)
+	if other, ok := c.Bindings[newStreamID]; ok && other != binding {
+		return fmt.Errorf("table %q was renamed to %q, which is also a configured binding of this capture", event.StreamID, newStreamID)
+	}
+	logrus.WithFields(logrus.Fields{"stream": event.StreamID, "renamedTo": newStreamID}).Info("table renamed, capturing it under its new name")
+
+	delete(c.Bindings, event.StreamID)
+	binding.StreamID = newStreamID
+	c.Bindings[newStreamID] = binding
+	if discoveryInfo, ok := c.discovery[event.StreamID]; ok {
+		var renamed = *discoveryInfo
+		renamed.Schema = event.NewSchema
+		renamed.Name = event.NewTable
+		delete(c.discovery, event.StreamID)
+		c.discovery[newStreamID] = &renamed
+	}
+	if state, ok := c.State.Streams[binding.StateKey]; ok {
+		state.RenamedTo = newStreamID
+		state.dirty = true
+	}
+	return nil
+}
+
+// handleTableReplace restarts the backfill of a binding when a different
+// table is moved into place under its name.
+func (c *Capture) handleTableReplace(event *TableReplaceEvent) error {
+	if event.Discovery != nil {
+		c.discovery[event.StreamID] = event.Discovery
+	}
+	var binding = c.Bindings[event.StreamID]
+	if binding == nil {
+		return nil
+	}
+	var state = c.State.Streams[binding.StateKey]
+	if state == nil || state.Mode == "" || state.Mode == TableModeIgnore || state.Mode == TableModePending {
+		return nil
+	}
+
+	var logEntry = logrus.WithFields(logrus.Fields{"stream": event.StreamID, "mode": state.Mode})
+	var restarted, err = c.restartBackfill(binding, state)
+	if err != nil {
+		return err
+	} else if !restarted {
+		logEntry.Warn("table replaced, but backfills are disabled for this table")
+		return nil
+	}
+	logEntry.WithField("newMode", state.Mode).Info("table replaced, restarting backfill")
+	return nil
+}
+
 func (c *Capture) backfillStreams(ctx context.Context) error {
 	var bindings = c.BindingsCurrentlyBackfilling()
 	var streams = make([]string, 0, len(bindings))
 	for _, b := range bindings {
 		streams = append(streams, b.StreamID)
 	}
+	if len(streams) == 0 {
+		return nil
+	}
 
-	// Select one binding at random to backfill at a time. On average this works
-	// as well as any other policy, and just doing one at a time means that we
-	// can size the relevant constants without worrying about how many tables
-	// might be concurrently backfilling.
-	if len(streams) != 0 {
-		var streamID = streams[rand.Intn(len(streams))]
-		logrus.WithFields(logrus.Fields{
-			"streams":  streams,
-			"selected": streamID,
-		}).Info("backfilling streams")
-		if err := c.backfillStream(ctx, streamID); err != nil {
-			return err
+	// Select bindings at random to backfill, up to the configured concurrency.
+	// On average this works as well as any other policy, and limiting the number
+	// of chunks per watermark means that we can size the relevant constants
+	// without worrying about how many tables might be backfilling. A table which
+	// has been split into key ranges has a chunk of each unfinished range scanned,
+	// so it may take up several slots of the concurrency limit by itself.
+	var concurrency = max(c.Database.BackfillConcurrency(), 1)
+	rand.Shuffle(len(streams), func(i, j int) { streams[i], streams[j] = streams[j], streams[i] })
+	var selected []string
+	var chunks []*backfillChunk
+	for _, streamID := range streams {
+		if len(chunks) >= concurrency {
+			break
 		}
+		c.estimateRows(ctx, streamID)
+		c.splitKeyRanges(ctx, streamID)
+		selected = append(selected, streamID)
+		chunks = append(chunks, c.pendingChunks(streamID)...)
 	}
+	chunks = chunks[:min(concurrency, len(chunks))]
+	slices.Sort(selected)
+	slices.SortFunc(chunks, func(a, b *backfillChunk) int {
+		if a.streamID != b.streamID {
+			return strings.Compare(a.streamID, b.streamID)
+		}
+		return a.keyRange - b.keyRange
+	})
+	logrus.WithFields(logrus.Fields{
+		"streams":  streams,
+		"selected": selected,
+	}).Info("backfilling streams")
+
+	if len(chunks) == 1 {
+		return c.backfillStream(ctx, chunks[0])
+	}
+	return c.backfillStreamsConcurrently(ctx, chunks)
+}
+
+// pendingChunks returns the chunks of a stream which should be scanned next, which
+// is one chunk per unfinished key range when the stream has been split into ranges.
+func (c *Capture) pendingChunks(streamID string) []*backfillChunk {
+	var state = c.State.Streams[c.Bindings[streamID].StateKey]
+	if state.Ranges == nil {
+		return []*backfillChunk{{streamID: streamID}}
+	}
+	var chunks []*backfillChunk
+	for idx, keyRange := range state.Ranges {
+		if !keyRange.Done {
+			chunks = append(chunks, &backfillChunk{streamID: streamID, keyRange: idx})
+		}
+	}
+	return chunks
+}
+
+// splitKeyRanges splits the key space of a table into key ranges, if it's about to
+// begin a precise backfill and the database suggests range boundaries for it. Since
+// ranges are only an optimization, failures are logged and the table is backfilled
+// sequentially instead.
+func (c *Capture) splitKeyRanges(ctx context.Context, streamID string) {
+	var state = c.State.Streams[c.Bindings[streamID].StateKey]
+	if state.Mode != TableModePreciseBackfill || state.Ranges != nil || state.Scanned != nil || state.BackfilledCount > 0 {
+		return
+	}
+	var progress = c.backfillProgress(streamID)
+	if progress.split {
+		return
+	}
+	progress.split = true
+
+	var info = c.discovery[streamID]
+	if info == nil {
+		return
+	}
+	var boundaries, err = c.Database.BackfillKeyRanges(ctx, info, state.KeyColumns)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"stream": streamID, "err": err}).Warn("unable to split table into key ranges")
+		return
+	}
+	boundaries = slices.Clone(boundaries)
+	slices.SortFunc(boundaries, compareTuples)
+	boundaries = slices.CompactFunc(boundaries, bytes.Equal)
+	if len(boundaries) == 0 {
+		return
+	}
+
+	var ranges = make([]*BackfillRange, 0, len(boundaries)+1)
+	var prev []byte
+	for _, boundary := range boundaries {
+		ranges = append(ranges, &BackfillRange{Scanned: prev, End: boundary})
+		prev = boundary
+	}
+	ranges = append(ranges, &BackfillRange{Scanned: prev})
+	logrus.WithFields(logrus.Fields{"stream": streamID, "ranges": len(ranges)}).Info("split backfill into key ranges")
+	state.Ranges = ranges
+	state.dirty = true
+}
+
+// backfillStreamsConcurrently scans the specified chunks in parallel. The scanned rows
+// are buffered until every scan has completed and are then emitted one chunk at a time
+// in the order given, so the output of a backfill cycle is deterministic. Since every
+// scan takes place between the same pair of watermarks, the precise backfill guarantees
+// hold for each stream and key range exactly as they would if it had been scanned alone.
+func (c *Capture) backfillStreamsConcurrently(ctx context.Context, chunks []*backfillChunk) error {
+	var group, groupCtx = errgroup.WithContext(ctx)
+	for _, chunk := range chunks {
+		var chunk = chunk
+		group.Go(func() error {
+			return c.scanChunk(groupCtx, chunk, func(event *ChangeEvent) error {
+				chunk.events = append(chunk.events, event)
+				return nil
+			})
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	for _, chunk := range chunks {
+		for _, event := range chunk.events {
+			if err := c.emitChange(event); err != nil {
+				return fmt.Errorf("error emitting %q backfill row: %w", chunk.streamID, err)
+			}
+		}
+		c.finishChunk(chunk)
+	}
+	return nil
+}
+
+// backfillChunk holds the results of scanning one chunk of a table, or of one key
+// range of a table which has been split into key ranges.
+type backfillChunk struct {
+	streamID   string         // The stream being scanned
+	keyRange   int            // Index of the key range being scanned, when the stream has been split into key ranges
+	events     []*ChangeEvent // Buffered rows, when the chunk isn't emitted as it's scanned
+	lastRowKey []byte         // Row key of the last row scanned
+	eventCount int            // Number of rows scanned
+}
+
+func (c *Capture) backfillStream(ctx context.Context, chunk *backfillChunk) error {
+	if err := c.scanChunk(ctx, chunk, func(event *ChangeEvent) error {
+		if err := c.emitChange(event); err != nil {
+			return fmt.Errorf("error emitting %q backfill row: %w", chunk.streamID, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	c.finishChunk(chunk)
 	return nil
 }
 
-func (c *Capture) backfillStream(ctx context.Context, streamID string) error {
+// scanChunk scans the next chunk of the stream (or key range) of the provided chunk,
+// invoking the callback for every row and recording the scan progress in the chunk.
+// It doesn't modify any capture state, so it may be called concurrently for different
+// streams and key ranges.
+func (c *Capture) scanChunk(ctx context.Context, chunk *backfillChunk, callback func(event *ChangeEvent) error) error {
+	var streamID = chunk.streamID
 	var stateKey = c.Bindings[streamID].StateKey
 	var streamState = c.State.Streams[stateKey]
 
@@ -696,11 +1337,22 @@ func (c *Capture) backfillStream(ctx context.Context, streamID string) error {
 		return fmt.Errorf("unknown table %q", streamID)
 	}
 
+	// A key range is scanned as though it were the whole table, resuming from the
+	// last row scanned within the range and stopping at the end of the range.
+	var scanState = streamState
+	if streamState.Ranges != nil {
+		var keyRange = streamState.Ranges[chunk.keyRange]
+		var rangeState = *streamState
+		rangeState.Scanned = keyRange.Scanned
+		rangeState.ScanEnd = keyRange.End
+		rangeState.Ranges = nil
+		scanState = &rangeState
+	}
+
 	// Process backfill query results as a callback-driven stream.
-	var lastRowKey = streamState.Scanned
-	var eventCount int
-	var err = c.Database.ScanTableChunk(ctx, discoveryInfo, streamState, func(event *ChangeEvent) error {
-		if streamState.Mode == TableModePreciseBackfill && compareTuples(lastRowKey, event.RowKey) > 0 {
+	chunk.lastRowKey = scanState.Scanned
+	var err = c.Database.ScanTableChunk(ctx, discoveryInfo, scanState, c.Bindings[streamID].Filter, func(event *ChangeEvent) error {
+		if streamState.Mode == TableModePreciseBackfill && compareTuples(chunk.lastRowKey, event.RowKey) > 0 {
 			// Sanity check that when performing a "precise" backfill the DB's ordering of
 			// result rows must match our own bytewise lexicographic ordering of serialized
 			// row keys.
@@ -715,44 +1367,188 @@ func (c *Capture) backfillStream(ctx context.Context, streamID string) error {
 			// should have set `UnpredictableKeyOrdering` to true, which should have resulted in a
 			// backfill using the "UnfilteredBackfill" mode instead, which would not perform that
 			// filtering or this sanity check.
-			return fmt.Errorf("scan key ordering failure: last=%q, next=%q", lastRowKey, event.RowKey)
+			return fmt.Errorf("scan key ordering failure: last=%q, next=%q", chunk.lastRowKey, event.RowKey)
 		}
-		lastRowKey = event.RowKey
+		if scanState.ScanEnd != nil && compareTuples(event.RowKey, scanState.ScanEnd) > 0 {
+			// Rows beyond the end of a key range belong to the following range, and
+			// emitting them here would break the filtering of that range.
+			return fmt.Errorf("scan key range failure: end=%q, next=%q", scanState.ScanEnd, event.RowKey)
+		}
+		chunk.lastRowKey = event.RowKey
 
-		if err := c.emitChange(event); err != nil {
-			return fmt.Errorf("error emitting %q backfill row: %w", streamID, err)
+		if err := callback(event); err != nil {
+			return err
 		}
-		eventCount++
+		chunk.eventCount++
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("error scanning table %q: %w", streamID, err)
 	}
+	return nil
+}
 
-	// Update stream state to reflect backfill results
+// finishChunk updates the stream state to reflect the results of a backfill chunk.
+func (c *Capture) finishChunk(chunk *backfillChunk) {
+	var streamID = chunk.streamID
+	var stateKey = c.Bindings[streamID].StateKey
 	var state = c.State.Streams[stateKey]
-	if eventCount == 0 {
+	var complete = chunk.eventCount == 0
+	if state.Ranges != nil {
+		var keyRange = state.Ranges[chunk.keyRange]
+		if chunk.eventCount == 0 {
+			logrus.WithFields(logrus.Fields{"stream": streamID, "range": chunk.keyRange}).Debug("key range backfill complete")
+			keyRange.Done = true
+		} else {
+			keyRange.Scanned = chunk.lastRowKey
+		}
+		complete = !slices.ContainsFunc(state.Ranges, func(r *BackfillRange) bool { return !r.Done })
+	}
+
+	if complete {
+		logrus.WithFields(logrus.Fields{
+			"stream":     streamID,
+			"backfilled": state.BackfilledCount,
+		}).Info("backfill complete")
 		state.Mode = TableModeActive
 		state.Scanned = nil
-	} else {
-		state.Scanned = lastRowKey
-		state.BackfilledCount += eventCount
+		state.Ranges = nil
+		state.EstimatedRows = 0
+		state.BackfillWhere = ""
+		delete(c.progress, streamID)
+	} else if chunk.eventCount > 0 {
+		if state.Ranges == nil {
+			state.Scanned = chunk.lastRowKey
+		}
+		state.BackfilledCount += chunk.eventCount
+		c.reportBackfillProgress(streamID, state)
 	}
+	observeBackfill(streamID, state)
 	state.dirty = true
 	c.State.Streams[stateKey] = state
-	return nil
+}
+
+// backfillProgress tracks the progress of a backfill over the current run of the
+// capture, so that its rate can be reported.
+type backfillProgress struct {
+	estimated bool      // Set once a row count estimate has been requested during this run
+	split     bool      // Set once key range boundaries have been requested during this run
+	reported  time.Time // The time at which progress was last reported
+	count     int       // The number of rows backfilled when progress was last reported
+}
+
+func (c *Capture) backfillProgress(streamID string) *backfillProgress {
+	if c.progress == nil {
+		c.progress = make(map[string]*backfillProgress)
+	}
+	var progress, ok = c.progress[streamID]
+	if !ok {
+		progress = &backfillProgress{}
+		c.progress[streamID] = progress
+	}
+	return progress
+}
+
+// estimateRows requests an estimate of the number of rows in a table, if the
+// backfill state doesn't already hold one and none was requested yet during
+// this run. Estimates are just informational, so failures are only logged.
+func (c *Capture) estimateRows(ctx context.Context, streamID string) {
+	var state = c.State.Streams[c.Bindings[streamID].StateKey]
+	var progress = c.backfillProgress(streamID)
+	if state.EstimatedRows > 0 || progress.estimated {
+		return
+	}
+	progress.estimated = true
+
+	var info = c.discovery[streamID]
+	if info == nil {
+		return
+	}
+	var estimate, err = c.Database.EstimatedRowCount(ctx, info)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"stream": streamID, "err": err}).Warn("unable to estimate table row count")
+		return
+	}
+	logrus.WithFields(logrus.Fields{"stream": streamID, "estimate": estimate}).Debug("estimated table row count")
+	state.EstimatedRows = estimate
+	state.dirty = true
+}
+
+// reportBackfillProgress logs the progress of a table backfill, at most once per
+// backfillProgressInterval. The first call during a run only begins measurement.
+func (c *Capture) reportBackfillProgress(streamID string, state *TableState) {
+	var progress = c.backfillProgress(streamID)
+	var now = time.Now()
+	if progress.reported.IsZero() {
+		progress.reported, progress.count = now, state.BackfilledCount
+		return
+	}
+	var elapsed = now.Sub(progress.reported)
+	if elapsed < backfillProgressInterval {
+		return
+	}
+	var rate = float64(state.BackfilledCount-progress.count) / elapsed.Seconds()
+	var fields = backfillProgressFields(state.BackfilledCount, state.EstimatedRows, rate)
+	fields["stream"] = streamID
+	logrus.WithFields(fields).Info("backfill progress")
+	progress.reported, progress.count = now, state.BackfilledCount
+}
+
+// backfillProgressFields describes the progress of a backfill of `count` rows out of
+// an estimated total, at the provided rate in rows per second. The percentage and
+// ETA are omitted when there's no estimate, and they stop at 100% and zero when the
+// estimate turns out to be too low.
+func backfillProgressFields(count, estimate int, rate float64) logrus.Fields {
+	var fields = logrus.Fields{
+		"backfilled": count,
+		"rowsPerSec": math.Round(rate*10) / 10,
+	}
+	if estimate > 0 {
+		var remaining = max(estimate-count, 0)
+		fields["estimated"] = estimate
+		fields["percent"] = math.Round(1000*float64(min(count, estimate))/float64(estimate)) / 10
+		if rate > 0 {
+			fields["eta"] = time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second).String()
+		}
+	}
+	return fields
 }
 
 func (c *Capture) emitChange(event *ChangeEvent) error {
+	// Column policies are applied to copies of the row images, so that the values
+	// withheld from the output document are still available to the caller.
+	var sourceCommon = event.Source.Common()
+	if binding, ok := c.Bindings[JoinStreamID(sourceCommon.Schema, sourceCommon.Table)]; ok && len(binding.Resource.ColumnPolicies) > 0 {
+		var masked = *event
+		var err error
+		if masked.Before, err = binding.Resource.applyColumnPolicies(event.Before); err != nil {
+			return err
+		}
+		if masked.After, err = binding.Resource.applyColumnPolicies(event.After); err != nil {
+			return err
+		}
+		event = &masked
+	}
+
+	var txn, err = c.transactionInfo(event)
+	if err != nil {
+		return err
+	}
+	if c.debezium != nil {
+		return c.emitDebeziumChange(event, txn)
+	}
+
 	var record map[string]interface{}
 	var meta = struct {
-		Operation ChangeOp               `json:"op"`
-		Source    SourceMetadata         `json:"source"`
-		Before    map[string]interface{} `json:"before,omitempty"`
+		Operation   ChangeOp               `json:"op"`
+		Source      SourceMetadata         `json:"source"`
+		Before      map[string]interface{} `json:"before,omitempty"`
+		Transaction *TransactionInfo       `json:"transaction,omitempty"`
 	}{
-		Operation: event.Operation,
-		Source:    event.Source,
-		Before:    nil,
+		Operation:   event.Operation,
+		Source:      event.Source,
+		Before:      nil,
+		Transaction: txn,
 	}
 	switch event.Operation {
 	case InsertOp:
@@ -761,13 +1557,98 @@ func (c *Capture) emitChange(event *ChangeEvent) error {
 		meta.Before, record = event.Before, event.After
 	case DeleteOp:
 		record = event.Before // After is never used.
+	case TruncateOp:
+		record = make(map[string]interface{}) // Neither Before nor After are used.
 	}
 	if record == nil {
 		logrus.WithField("op", event.Operation).Warn("change event data map is nil")
 		record = make(map[string]interface{})
 	}
 	record["_meta"] = &meta
+	return c.emitRecord(event, record)
+}
+
+// debeziumEnvelope is the document metadata of a change event when the capture
+// emits Debezium change event envelopes.
+type debeziumEnvelope struct {
+	Operation ChangeOp               `json:"op"`
+	Source    json.RawMessage        `json:"source"`
+	Before    map[string]interface{} `json:"before"`
+	After     map[string]interface{} `json:"after"`
+	Millis    int64                  `json:"ts_ms"`
+
+	Transaction *TransactionInfo `json:"transaction,omitempty"`
+}
+
+// debeziumReadOp is the Debezium operation of rows read by a snapshot (backfill).
+const debeziumReadOp ChangeOp = "r"
+
+// emitDebeziumChange emits a change event whose metadata is a complete Debezium
+// change event envelope. The row values remain at the top level of the document
+// so that collection keys continue to work as usual.
+func (c *Capture) emitDebeziumChange(event *ChangeEvent, txn *TransactionInfo) error {
+	var source, err = c.debeziumSource(event.Source)
+	if err != nil {
+		return err
+	}
+	var op = event.Operation
+	if event.Operation == InsertOp && event.Source.Common().Snapshot {
+		op = debeziumReadOp
+	}
+	var meta = &debeziumEnvelope{
+		Operation:   op,
+		Source:      source,
+		Millis:      time.Now().UnixMilli(),
+		Transaction: txn,
+	}
+
+	// The before and after states are copied, since one of them is also the
+	// document itself and mustn't end up containing its own metadata.
+	var record map[string]interface{}
+	switch event.Operation {
+	case InsertOp:
+		record, meta.After = event.After, maps.Clone(event.After)
+	case UpdateOp:
+		record, meta.Before, meta.After = event.After, event.Before, maps.Clone(event.After)
+	case DeleteOp:
+		record, meta.Before = event.Before, maps.Clone(event.Before)
+	}
+	if record == nil {
+		record = make(map[string]interface{})
+	}
+	record["_meta"] = meta
+	return c.emitRecord(event, record)
+}
+
+// debeziumSource serializes source metadata as a Debezium source block, by
+// adding the Debezium-specific properties to its usual representation.
+func (c *Capture) debeziumSource(source SourceMetadata) (json.RawMessage, error) {
+	var extra = map[string]any{
+		"version":   c.debezium.Version,
+		"connector": c.debezium.Connector,
+		"name":      c.debezium.Name,
+	}
+	if c.debezium.Database != "" {
+		extra["db"] = c.debezium.Database
+	}
+	if source, ok := source.(DebeziumSourceMetadata); ok {
+		maps.Copy(extra, source.DebeziumSource())
+	}
+
+	var bs, err = json.Marshal(source)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing source metadata: %w", err)
+	}
+	extraJSON, err := json.Marshal(extra)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing Debezium source metadata: %w", err)
+	}
+	// Splice the two JSON objects together. The source metadata always has at
+	// least the schema and table properties, so it's never empty.
+	return append(append(bs[:len(bs)-1], ','), extraJSON[1:]...), nil
+}
 
+func (c *Capture) emitRecord(event *ChangeEvent, record map[string]interface{}) error {
 	var sourceCommon = event.Source.Common()
 	var streamID = JoinStreamID(sourceCommon.Schema, sourceCommon.Table)
 	var binding, ok = c.Bindings[streamID]
@@ -1,13 +1,25 @@
 package sqlcapture
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	cerrors "github.com/estuary/connectors/go/connector-errors"
 	boilerplate "github.com/estuary/connectors/source-boilerplate"
+	pc "github.com/estuary/flow/go/protocols/capture"
 	pf "github.com/estuary/flow/go/protocols/flow"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
 
@@ -175,3 +187,804 @@ func TestMigrateState(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// testDatabase is a stub implementation of the Database interface for unit tests
+// which only exercise a handful of its methods.
+type testDatabase struct {
+	Database
+	skipBackfills []string
+}
+
+func (db *testDatabase) ShouldBackfill(streamID string) bool {
+	return !slices.Contains(db.skipBackfills, streamID)
+}
+
+type testSource struct{ SourceCommon }
+
+func (s *testSource) Common() SourceCommon { return s.SourceCommon }
+
+// A testBinding is a table captured by a test capture. Its state may be nil if
+// the test never consults it.
+type testBinding struct {
+	Resource Resource
+	State    *TableState
+	Filter   *RowFilter
+}
+
+// newTestCapture returns a Capture of the provided bindings, whose output is
+// recorded by the returned server. Bindings are indexed in the order provided,
+// their states are keyed by stream ID, and every table is discovered as a base
+// table whose primary key is "id".
+func newTestCapture(db Database, bindings ...testBinding) (*Capture, *testCaptureServer) {
+	var server = &testCaptureServer{}
+	var c = &Capture{
+		Bindings:  make(map[string]*Binding),
+		State:     &PersistentState{Streams: make(map[boilerplate.StateKey]*TableState)},
+		Output:    &boilerplate.PullOutput{Connector_CaptureServer: server},
+		Database:  db,
+		discovery: make(map[string]*DiscoveryInfo),
+	}
+	for idx, binding := range bindings {
+		var streamID = JoinStreamID(binding.Resource.Namespace, binding.Resource.Stream)
+		var stateKey = boilerplate.StateKey(streamID)
+		c.Bindings[streamID] = &Binding{
+			Index:    uint32(idx),
+			StreamID: streamID,
+			StateKey: stateKey,
+			Resource: binding.Resource,
+			Filter:   binding.Filter,
+		}
+		if binding.State != nil {
+			c.State.Streams[stateKey] = binding.State
+		}
+		c.discovery[streamID] = &DiscoveryInfo{
+			Schema:     binding.Resource.Namespace,
+			Name:       binding.Resource.Stream,
+			PrimaryKey: []string{"id"},
+			BaseTable:  true,
+		}
+	}
+	return c, server
+}
+
+// newTestTableState returns the state of a table keyed on "id" in the specified
+// mode, with some backfill progress for tests to observe being discarded.
+func newTestTableState(mode string) *TableState {
+	return &TableState{
+		Mode:            mode,
+		KeyColumns:      []string{"id"},
+		Scanned:         []byte("scanned"),
+		BackfilledCount: 100,
+	}
+}
+
+func TestHandleTruncate(t *testing.T) {
+	var db = &testDatabase{skipBackfills: []string{"test.skipped"}}
+	var truncate = func(schema, table string) *TruncateEvent {
+		return &TruncateEvent{Source: &testSource{SourceCommon{Schema: schema, Table: table}}}
+	}
+
+	t.Run("ignore", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(db, testBinding{Resource: Resource{Namespace: "test", Stream: "foo"}, State: state})
+		require.NoError(t, c.handleReplicationEvent(truncate("test", "foo")))
+		require.Equal(t, TableModeActive, state.Mode)
+		require.False(t, state.dirty)
+	})
+
+	t.Run("backfill", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(db, testBinding{Resource: Resource{Namespace: "test", Stream: "foo", OnTruncate: TruncateModeBackfill}, State: state})
+		require.NoError(t, c.handleReplicationEvent(truncate("test", "foo")))
+		require.Equal(t, TableModePreciseBackfill, state.Mode)
+		require.Nil(t, state.Scanned)
+		require.Equal(t, 0, state.BackfilledCount)
+		require.True(t, state.dirty)
+		require.NotNil(t, c.BindingsCurrentlyBackfilling())
+	})
+
+	t.Run("backfill restarts partial backfill", func(t *testing.T) {
+		var state = newTestTableState(TableModeUnfilteredBackfill)
+		var c, _ = newTestCapture(db, testBinding{Resource: Resource{Namespace: "test", Stream: "foo", Mode: BackfillModeNormal, OnTruncate: TruncateModeBackfill}, State: state})
+		require.NoError(t, c.handleReplicationEvent(truncate("test", "foo")))
+		require.Equal(t, TableModeUnfilteredBackfill, state.Mode)
+		require.Nil(t, state.Scanned)
+	})
+
+	t.Run("backfill of only changes binding", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(db, testBinding{Resource: Resource{Namespace: "test", Stream: "foo", Mode: BackfillModeOnlyChanges, OnTruncate: TruncateModeBackfill}, State: state})
+		require.NoError(t, c.handleReplicationEvent(truncate("test", "foo")))
+		require.Equal(t, TableModeActive, state.Mode)
+		require.Nil(t, c.BindingsCurrentlyBackfilling())
+	})
+
+	t.Run("backfill of skipped table", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(db, testBinding{Resource: Resource{Namespace: "test", Stream: "skipped", OnTruncate: TruncateModeBackfill}, State: state})
+		require.NoError(t, c.handleReplicationEvent(truncate("test", "skipped")))
+		require.Equal(t, TableModeActive, state.Mode)
+	})
+
+	t.Run("unrelated table", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(db, testBinding{Resource: Resource{Namespace: "test", Stream: "foo", OnTruncate: TruncateModeBackfill}, State: state})
+		require.NoError(t, c.handleReplicationEvent(truncate("test", "bar")))
+		require.Equal(t, TableModeActive, state.Mode)
+	})
+}
+
+// testCaptureServer records all responses sent to it.
+type testCaptureServer struct {
+	pc.Connector_CaptureServer
+	sent []*pc.Response
+}
+
+func (s *testCaptureServer) Send(msg *pc.Response) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestHandleTableDDL(t *testing.T) {
+	var foo = Resource{Namespace: "test", Stream: "foo"}
+
+	t.Run("drop", func(t *testing.T) {
+		var state = newTestTableState(TableModePreciseBackfill)
+		var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: foo, State: state})
+		require.NoError(t, c.handleReplicationEvent(&TableDropEvent{StreamID: "test.foo"}))
+		require.Equal(t, TableModeIgnore, state.Mode)
+		require.True(t, state.dirty)
+		require.Nil(t, c.BindingsCurrentlyBackfilling())
+
+		// The dropped table remains ignored across restarts.
+		delete(c.discovery, "test.foo")
+		require.NoError(t, c.updateState(context.Background()))
+		require.Equal(t, TableModeIgnore, state.Mode)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: foo, State: state})
+		require.NoError(t, c.handleReplicationEvent(&TableRenameEvent{StreamID: "test.foo", NewSchema: "test", NewTable: "bar"}))
+		require.Equal(t, TableModeActive, state.Mode)
+		require.Equal(t, "test.bar", state.RenamedTo)
+		require.Contains(t, c.Bindings, "test.bar")
+		require.NotContains(t, c.Bindings, "test.foo")
+		require.Equal(t, "bar", c.discovery["test.bar"].Name)
+
+		// Bindings follow the table to its new name across restarts.
+		c.Bindings = map[string]*Binding{
+			"test.foo": {StreamID: "test.foo", StateKey: "test.foo", Resource: foo},
+		}
+		require.NoError(t, c.updateState(context.Background()))
+		require.Equal(t, "test.bar", c.Bindings["test.bar"].StreamID)
+		require.Equal(t, TableModeActive, state.Mode)
+	})
+
+	t.Run("rename onto another binding", func(t *testing.T) {
+		var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: foo, State: newTestTableState(TableModeActive)})
+		c.Bindings["test.bar"] = &Binding{StreamID: "test.bar", StateKey: "other"}
+		require.ErrorContains(t, c.handleReplicationEvent(&TableRenameEvent{StreamID: "test.foo", NewSchema: "test", NewTable: "bar"}), "also a configured binding")
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: foo, State: state})
+		var discovery = &DiscoveryInfo{Schema: "test", Name: "foo", PrimaryKey: []string{"id"}, BaseTable: true, UnpredictableKeyOrdering: true}
+		require.NoError(t, c.handleReplicationEvent(&TableReplaceEvent{StreamID: "test.foo", Discovery: discovery}))
+		require.Equal(t, TableModeUnfilteredBackfill, state.Mode)
+		require.Nil(t, state.Scanned)
+		require.Equal(t, 0, state.BackfilledCount)
+		require.Same(t, discovery, c.discovery["test.foo"])
+	})
+}
+
+func TestDebeziumEnvelope(t *testing.T) {
+	var c, server = newTestCapture(nil, testBinding{Resource: Resource{Namespace: "test", Stream: "foo"}})
+	c.debezium = &DebeziumInfo{Connector: "test", Version: "v1", Name: "acmeCo/capture", Database: "db"}
+	var source = &testSource{SourceCommon{Schema: "test", Table: "foo", Millis: 1000}}
+	require.NoError(t, c.emitChange(&ChangeEvent{
+		Operation: UpdateOp,
+		Source:    source,
+		Before:    map[string]interface{}{"id": 1, "val": "a"},
+		After:     map[string]interface{}{"id": 1, "val": "b"},
+	}))
+	require.NoError(t, c.emitChange(&ChangeEvent{
+		Operation: DeleteOp,
+		Source:    source,
+		Before:    map[string]interface{}{"id": 2, "val": "c"},
+	}))
+	require.NoError(t, c.emitChange(&ChangeEvent{
+		Operation: InsertOp,
+		Source:    &testSource{SourceCommon{Schema: "test", Table: "foo", Millis: 1000, Snapshot: true}},
+		After:     map[string]interface{}{"id": 3, "val": "d"},
+	}))
+	require.Len(t, server.sent, 3)
+
+	var docs []map[string]any
+	for _, msg := range server.sent {
+		require.Equal(t, uint32(0), msg.Captured.Binding)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(msg.Captured.DocJson, &doc))
+		var meta = doc["_meta"].(map[string]any)
+		require.NotZero(t, meta["ts_ms"])
+		delete(meta, "ts_ms")
+		docs = append(docs, doc)
+	}
+	var expectSource = map[string]any{"schema": "test", "table": "foo", "ts_ms": 1000.0, "version": "v1", "connector": "test", "name": "acmeCo/capture", "db": "db"}
+	require.Equal(t, map[string]any{"id": 1.0, "val": "b", "_meta": map[string]any{
+		"op":     "u",
+		"source": expectSource,
+		"before": map[string]any{"id": 1.0, "val": "a"},
+		"after":  map[string]any{"id": 1.0, "val": "b"},
+	}}, docs[0])
+	require.Equal(t, map[string]any{"id": 2.0, "val": "c", "_meta": map[string]any{
+		"op":     "d",
+		"source": expectSource,
+		"before": map[string]any{"id": 2.0, "val": "c"},
+		"after":  nil,
+	}}, docs[1])
+
+	// Backfilled rows are reads of a snapshot.
+	require.Equal(t, "r", docs[2]["_meta"].(map[string]any)["op"])
+	require.Equal(t, map[string]any{"id": 3.0, "val": "d"}, docs[2]["_meta"].(map[string]any)["after"])
+}
+
+// testScanDatabase serves backfill chunks of up to two rows from tables with
+// the specified row counts, with each scan blocking until `concurrency` scans
+// are in progress at once.
+type testScanDatabase struct {
+	Database
+	concurrency int
+	rowCounts   map[string]int
+	inProgress  sync.WaitGroup
+}
+
+func (db *testScanDatabase) BackfillConcurrency() int { return db.concurrency }
+
+func (db *testScanDatabase) BackfillKeyRanges(ctx context.Context, info *DiscoveryInfo, keyColumns []string) ([][]byte, error) {
+	return nil, nil
+}
+
+func (db *testScanDatabase) EstimatedRowCount(ctx context.Context, info *DiscoveryInfo) (int, error) {
+	return db.rowCounts[JoinStreamID(info.Schema, info.Name)], nil
+}
+
+func (db *testScanDatabase) ScanTableChunk(ctx context.Context, info *DiscoveryInfo, state *TableState, filter *RowFilter, callback func(event *ChangeEvent) error) error {
+	db.inProgress.Done()
+	db.inProgress.Wait()
+
+	var rowCount = db.rowCounts[JoinStreamID(info.Schema, info.Name)]
+	for idx := state.BackfilledCount; idx < min(state.BackfilledCount+2, rowCount); idx++ {
+		if err := callback(&ChangeEvent{
+			Operation: InsertOp,
+			RowKey:    []byte(fmt.Sprintf("%04d", idx)),
+			Source:    &testSource{SourceCommon{Schema: info.Schema, Table: info.Name, Snapshot: true}},
+			After:     map[string]interface{}{"id": idx},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestConcurrentBackfill(t *testing.T) {
+	var db = &testScanDatabase{concurrency: 2, rowCounts: map[string]int{"test.a": 3, "test.b": 1}}
+	var a = &TableState{Mode: TableModePreciseBackfill, KeyColumns: []string{"id"}}
+	var b = &TableState{Mode: TableModePreciseBackfill, KeyColumns: []string{"id"}}
+	var c, server = newTestCapture(db,
+		testBinding{Resource: Resource{Namespace: "test", Stream: "a"}, State: a},
+		testBinding{Resource: Resource{Namespace: "test", Stream: "b"}, State: b},
+	)
+
+	// Both tables are scanned at once, and the buffered rows are emitted in stream order.
+	db.inProgress.Add(2)
+	require.NoError(t, c.backfillStreams(context.Background()))
+	var bindings []uint32
+	for _, msg := range server.sent {
+		bindings = append(bindings, msg.Captured.Binding)
+	}
+	require.Equal(t, []uint32{0, 0, 1}, bindings)
+	require.Equal(t, []byte("0001"), a.Scanned)
+	require.Equal(t, 2, a.BackfilledCount)
+	require.Equal(t, 3, a.EstimatedRows)
+	require.Equal(t, []byte("0000"), b.Scanned)
+
+	// The next chunk of table B is empty and completes its backfill.
+	db.inProgress.Add(2)
+	require.NoError(t, c.backfillStreams(context.Background()))
+	require.Len(t, server.sent, 4)
+	require.Equal(t, 3, a.BackfilledCount)
+	require.Equal(t, TableModeActive, b.Mode)
+	require.Zero(t, b.EstimatedRows)
+
+	// Once only one table remains it's backfilled on its own.
+	db.inProgress.Add(1)
+	require.NoError(t, c.backfillStreams(context.Background()))
+	require.Equal(t, TableModeActive, a.Mode)
+	require.Nil(t, c.BindingsCurrentlyBackfilling())
+}
+
+func TestHandleSignal(t *testing.T) {
+	var signal = func(op ChangeOp, value any) *ChangeEvent {
+		return &ChangeEvent{
+			Operation: op,
+			Source:    &testSource{SourceCommon{Schema: "public", Table: "flow_signals"}},
+			After:     map[string]interface{}{"id": 1, "signal": value},
+		}
+	}
+	var automatic = Resource{Namespace: "public", Stream: "orders", Mode: BackfillModeAutomatic}
+
+	t.Run("backfill", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: automatic, State: state})
+		c.signals = "public.flow_signals"
+		require.NoError(t, c.handleReplicationEvent(signal(InsertOp, `{"action":"backfill","table":"public.orders"}`)))
+		require.Equal(t, TableModePreciseBackfill, state.Mode)
+		require.Equal(t, 0, state.BackfilledCount)
+		require.Equal(t, "", state.BackfillWhere)
+		require.True(t, state.dirty)
+	})
+
+	t.Run("backfill with condition", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: automatic, State: state})
+		c.signals = "public.flow_signals"
+		require.NoError(t, c.handleReplicationEvent(signal(InsertOp, []byte(`{"action":"backfill","table":"Public.Orders","where":"id > 1000"}`))))
+		require.Equal(t, TableModeUnfilteredBackfill, state.Mode)
+		require.Equal(t, "id > 1000", state.BackfillWhere)
+
+		// The condition is discarded once the backfill completes.
+		c.finishChunk(&backfillChunk{streamID: "public.orders"})
+		require.Equal(t, TableModeActive, state.Mode)
+		require.Equal(t, "", state.BackfillWhere)
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		for _, event := range []*ChangeEvent{
+			signal(UpdateOp, `{"action":"backfill","table":"public.orders"}`),
+			signal(InsertOp, `not json`),
+			signal(InsertOp, nil),
+			signal(InsertOp, `{"action":"explode","table":"public.orders"}`),
+			signal(InsertOp, `{"action":"backfill","table":"orders"}`),
+			signal(InsertOp, `{"action":"backfill","table":"public.other"}`),
+			signal(InsertOp, `{"action":"backfill","table":"public.orders","where":"1=1; DROP TABLE orders"}`),
+		} {
+			var state = newTestTableState(TableModeActive)
+			var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: automatic, State: state})
+			c.signals = "public.flow_signals"
+			require.NoError(t, c.handleReplicationEvent(event))
+			require.Equal(t, TableModeActive, state.Mode)
+			require.False(t, state.dirty)
+		}
+	})
+
+	t.Run("backfills disabled", func(t *testing.T) {
+		var state = newTestTableState(TableModeActive)
+		var c, _ = newTestCapture(&testDatabase{}, testBinding{Resource: Resource{Namespace: "public", Stream: "orders", Mode: BackfillModeOnlyChanges}, State: state})
+		c.signals = "public.flow_signals"
+		require.NoError(t, c.handleReplicationEvent(signal(InsertOp, `{"action":"backfill","table":"public.orders"}`)))
+		require.Equal(t, TableModeActive, state.Mode)
+	})
+}
+
+func TestRowFilterReplication(t *testing.T) {
+	var filter, err = ParseRowFilter("tenant_id IN (1, 2)")
+	require.NoError(t, err)
+	var c, server = newTestCapture(nil, testBinding{
+		Resource: Resource{Namespace: "test", Stream: "foo"},
+		State:    &TableState{Mode: TableModeActive, KeyColumns: []string{"id"}},
+		Filter:   filter,
+	})
+	var change = func(op ChangeOp, before, after map[string]any) *ChangeEvent {
+		return &ChangeEvent{
+			Operation: op,
+			Source:    &testSource{SourceCommon{Schema: "test", Table: "foo"}},
+			Before:    before,
+			After:     after,
+		}
+	}
+	for _, event := range []*ChangeEvent{
+		change(InsertOp, nil, map[string]any{"id": 1, "tenant_id": 1}),
+		change(InsertOp, nil, map[string]any{"id": 2, "tenant_id": 3}),
+		change(UpdateOp, map[string]any{"id": 3, "tenant_id": 3}, map[string]any{"id": 3, "tenant_id": 2}),
+		change(UpdateOp, map[string]any{"id": 4, "tenant_id": 2}, map[string]any{"id": 4, "tenant_id": 3}),
+		change(UpdateOp, map[string]any{"id": 5, "tenant_id": 3}, map[string]any{"id": 5, "tenant_id": 4}),
+		change(UpdateOp, nil, map[string]any{"id": 6, "tenant_id": 4}),
+		change(DeleteOp, map[string]any{"id": 7, "tenant_id": 3}, nil),
+		change(DeleteOp, map[string]any{"id": 8}, nil),
+	} {
+		require.NoError(t, c.handleReplicationEvent(event))
+	}
+
+	var summary []string
+	for _, msg := range server.sent {
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(msg.Captured.DocJson, &doc))
+		summary = append(summary, fmt.Sprintf("%s:%v", doc["_meta"].(map[string]any)["op"], doc["id"]))
+	}
+	// Updates moving a row out of the filter are deletions, as are updates and
+	// deletions whose prior row image doesn't contain the filtered column.
+	require.Equal(t, []string{"c:1", "u:3", "d:4", "d:6", "d:8"}, summary)
+
+	require.ErrorContains(t, c.handleReplicationEvent(change(InsertOp, nil, map[string]any{"id": 9, "tenant_id": "abc"})), "error applying row filter")
+}
+
+func TestColumnPolicies(t *testing.T) {
+	var res = Resource{
+		Namespace: "test",
+		Stream:    "foo",
+		ColumnPolicies: map[string]ColumnPolicy{
+			"ssn":   ColumnPolicyExclude,
+			"email": ColumnPolicyHash,
+			"phone": ColumnPolicyRedact,
+		},
+		HashSalt: "pepper",
+	}
+	require.NoError(t, res.Validate())
+	require.NoError(t, res.validateColumnPolicies([]string{"id"}))
+	require.ErrorContains(t, res.validateColumnPolicies([]string{"email"}), `column "email" is part of the collection key`)
+	require.Equal(t, []string{"missing"}, Resource{ColumnPolicies: map[string]ColumnPolicy{"id": ColumnPolicyHash, "missing": ColumnPolicyHash}}.unknownPolicyColumns([]string{"id", "name"}))
+
+	// The collection schema must permit the values which each policy produces.
+	var collection = func(ssn pf.Inference_Exists, email, phone []string) *pf.CollectionSpec {
+		return &pf.CollectionSpec{Projections: []pf.Projection{
+			{Ptr: "/ssn", Inference: pf.Inference{Types: []string{"string"}, Exists: ssn}},
+			{Ptr: "/email", Inference: pf.Inference{Types: email, Exists: pf.Inference_MAY}},
+			{Ptr: "/phone", Inference: pf.Inference{Types: phone, Exists: pf.Inference_MAY}},
+		}}
+	}
+	require.NoError(t, res.validatePolicyTypes(collection(pf.Inference_MAY, []string{"string"}, []string{"null", "string"})))
+	require.NoError(t, res.validatePolicyTypes(&pf.CollectionSpec{}))
+	require.ErrorContains(t, res.validatePolicyTypes(collection(pf.Inference_MUST, []string{"string"}, []string{"null", "string"})), `column "ssn" is required by the collection schema`)
+	require.ErrorContains(t, res.validatePolicyTypes(collection(pf.Inference_MAY, []string{"integer"}, []string{"null", "string"})), `column "email" has the policy "Hash" which produces strings`)
+	require.ErrorContains(t, res.validatePolicyTypes(collection(pf.Inference_MAY, []string{"string"}, []string{"string"})), `column "phone" has the policy "Redact" which produces nulls`)
+
+	var c, server = newTestCapture(nil, testBinding{
+		Resource: res,
+		State:    &TableState{Mode: TableModeActive, KeyColumns: []string{"id"}},
+	})
+	var before = map[string]any{"id": 1, "ssn": "123-45-6789", "email": "alice@example.com", "phone": "555-0100"}
+	var after = map[string]any{"id": 1, "ssn": "123-45-6789", "email": nil, "phone": "555-0101"}
+	require.NoError(t, c.handleReplicationEvent(&ChangeEvent{
+		Operation: UpdateOp,
+		Source:    &testSource{SourceCommon{Schema: "test", Table: "foo"}},
+		Before:    before,
+		After:     after,
+	}))
+
+	// The row images of the event itself are left intact.
+	require.Equal(t, "123-45-6789", before["ssn"])
+	require.Equal(t, "555-0101", after["phone"])
+
+	require.Len(t, server.sent, 1)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(server.sent[0].Captured.DocJson, &doc))
+	var hashed = sha256.Sum256([]byte(`pepper"alice@example.com"`))
+	require.Equal(t, map[string]any{"id": float64(1), "email": hex.EncodeToString(hashed[:]), "phone": nil}, doc["_meta"].(map[string]any)["before"])
+	delete(doc, "_meta")
+	require.Equal(t, map[string]any{"id": float64(1), "email": nil, "phone": nil}, doc)
+}
+
+// testPositionDatabase uses integer positions as positional watermarks.
+type testPositionDatabase struct{ Database }
+
+func (db *testPositionDatabase) WatermarksTable() string { return "test.watermarks" }
+
+func (db *testPositionDatabase) ReplicationHealth(ctx context.Context, cursor string) (*ReplicationHealth, error) {
+	return nil, nil
+}
+
+func (db *testPositionDatabase) PositionReached(cursor, position string) (bool, error) {
+	var c, err = strconv.Atoi(cursor)
+	if err != nil {
+		return false, err
+	}
+	p, err := strconv.Atoi(position)
+	if err != nil {
+		return false, err
+	}
+	return c >= p, nil
+}
+
+type testReplicationStream struct {
+	ReplicationStream
+	events chan DatabaseEvent
+}
+
+func (s *testReplicationStream) Events() <-chan DatabaseEvent { return s.events }
+
+func TestPositionalWatermarks(t *testing.T) {
+	var ctx = context.Background()
+	var c, _ = newTestCapture(&testPositionDatabase{})
+	c.State.Cursor = "5"
+	c.positional = true
+	var stream = &testReplicationStream{events: make(chan DatabaseEvent, 8)}
+
+	// A position which was already flushed is reached without any further events.
+	require.NoError(t, c.streamToWatermark(ctx, stream, "3"))
+
+	// Otherwise streaming stops on the first flush at or past the position.
+	stream.events <- &FlushEvent{Cursor: "6"}
+	stream.events <- &FlushEvent{Cursor: "8"}
+	stream.events <- &FlushEvent{Cursor: "9"}
+	require.NoError(t, c.streamToWatermark(ctx, stream, "7"))
+	require.Equal(t, "8", c.State.Cursor)
+
+	// The position may also be delivered while streaming is already underway.
+	var positions = make(chan string, 1)
+	positions <- "10"
+	stream.events <- &FlushEvent{Cursor: "11"}
+	require.NoError(t, c.streamToWatermarkWithOptions(ctx, stream, "", positions, true))
+	require.Equal(t, "11", c.State.Cursor)
+
+	close(stream.events)
+	require.ErrorIs(t, c.streamToWatermark(ctx, stream, "12"), errWatermarkNotReached)
+}
+
+func TestBackfillProgressFields(t *testing.T) {
+	require.Equal(t, logrus.Fields{
+		"backfilled": 250,
+		"rowsPerSec": 12.5,
+		"estimated":  1000,
+		"percent":    25.0,
+		"eta":        "1m0s",
+	}, backfillProgressFields(250, 1000, 12.5))
+
+	// Estimates are only statistics, and may turn out to be too low.
+	require.Equal(t, logrus.Fields{
+		"backfilled": 1200,
+		"rowsPerSec": 100.0,
+		"estimated":  1000,
+		"percent":    100.0,
+		"eta":        "0s",
+	}, backfillProgressFields(1200, 1000, 100))
+
+	require.Equal(t, logrus.Fields{
+		"backfilled": 1200,
+		"rowsPerSec": 0.3,
+	}, backfillProgressFields(1200, 0, 1.0/3))
+}
+
+// testTransactionSource is source metadata which identifies its transaction.
+type testTransactionSource struct {
+	SourceCommon
+	txid string
+}
+
+func (s *testTransactionSource) Common() SourceCommon  { return s.SourceCommon }
+func (s *testTransactionSource) TransactionID() string { return s.txid }
+
+func TestTransactionMetadata(t *testing.T) {
+	var ctx = context.Background()
+	var c, server = newTestCapture(&testPositionDatabase{},
+		testBinding{Resource: Resource{Namespace: "test", Stream: "foo"}, State: &TableState{Mode: TableModeActive, KeyColumns: []string{"id"}}},
+		testBinding{Resource: Resource{Namespace: "test", Stream: "bar"}, State: &TableState{Mode: TableModeActive, KeyColumns: []string{"id"}}},
+	)
+	c.State.Cursor = "0"
+	c.positional = true
+	c.transactions = &transactionTracker{}
+	c.transactionsBinding = &Binding{Index: 2, StreamID: "_flow.transactions"}
+	var change = func(table, txid string, id int) *ChangeEvent {
+		return &ChangeEvent{
+			Operation: InsertOp,
+			Source:    &testTransactionSource{SourceCommon{Millis: 1000, Schema: "test", Table: table}, txid},
+			After:     map[string]any{"id": id},
+		}
+	}
+
+	// The second transaction begins without an intervening flush, as happens when
+	// a single flush covers several transactions.
+	var stream = &testReplicationStream{events: make(chan DatabaseEvent, 8)}
+	stream.events <- change("foo", "tx1", 1)
+	stream.events <- change("bar", "tx1", 2)
+	stream.events <- change("foo", "tx1", 3)
+	stream.events <- change("foo", "tx2", 4)
+	stream.events <- &FlushEvent{Cursor: "1"}
+	require.NoError(t, c.streamToWatermark(ctx, stream, "1"))
+
+	var docs []string
+	for _, msg := range server.sent {
+		if msg.Captured != nil {
+			var doc = string(msg.Captured.DocJson)
+			if msg.Captured.Binding != 2 {
+				var parsed map[string]any
+				require.NoError(t, json.Unmarshal(msg.Captured.DocJson, &parsed))
+				var bs, err = json.Marshal(parsed["_meta"].(map[string]any)["transaction"])
+				require.NoError(t, err)
+				doc = string(bs)
+			}
+			docs = append(docs, fmt.Sprintf("%d %s", msg.Captured.Binding, doc))
+		}
+	}
+	require.Equal(t, []string{
+		`2 {"status":"BEGIN","id":"tx1","ts_ms":1000}`,
+		`0 {"data_collection_order":1,"id":"tx1","total_order":1}`,
+		`1 {"data_collection_order":1,"id":"tx1","total_order":2}`,
+		`0 {"data_collection_order":2,"id":"tx1","total_order":3}`,
+		`2 {"status":"END","id":"tx1","ts_ms":1000,"event_count":3,"data_collections":[{"data_collection":"test.bar","event_count":1},{"data_collection":"test.foo","event_count":2}]}`,
+		`2 {"status":"BEGIN","id":"tx2","ts_ms":1000}`,
+		`0 {"data_collection_order":1,"id":"tx2","total_order":1}`,
+		`2 {"status":"END","id":"tx2","ts_ms":1000,"event_count":1,"data_collections":[{"data_collection":"test.foo","event_count":1}]}`,
+	}, docs)
+
+	// Backfilled rows aren't part of any transaction.
+	var backfilled = change("foo", "", 5)
+	backfilled.Source.(*testTransactionSource).Snapshot = true
+	txn, err := c.transactionInfo(backfilled)
+	require.NoError(t, err)
+	require.Nil(t, txn)
+}
+
+func TestBackfillMetrics(t *testing.T) {
+	var state = &TableState{Mode: TableModePreciseBackfill, BackfilledCount: 250, EstimatedRows: 1000}
+	observeBackfill("test.metrics", state)
+	require.Equal(t, 250.0, testutil.ToFloat64(backfilledRows.WithLabelValues("test.metrics")))
+	require.Equal(t, 1000.0, testutil.ToFloat64(backfillEstimatedRows.WithLabelValues("test.metrics")))
+
+	// The metrics of a table are removed once its backfill completes.
+	state.Mode = TableModeActive
+	observeBackfill("test.metrics", state)
+	require.False(t, backfilledRows.DeleteLabelValues("test.metrics"))
+	require.False(t, backfillEstimatedRows.DeleteLabelValues("test.metrics"))
+
+	observeReplicationEvent(&ChangeEvent{
+		Operation: InsertOp,
+		Source:    &testSource{SourceCommon{Millis: time.Now().Add(-time.Minute).UnixMilli(), Schema: "test", Table: "metrics"}},
+	})
+	require.InDelta(t, 60.0, testutil.ToFloat64(replicationLag), 5.0)
+}
+
+type testHealthDatabase struct {
+	Database
+	health *ReplicationHealth
+	err    error
+	calls  int
+}
+
+func (db *testHealthDatabase) ReplicationHealth(ctx context.Context, cursor string) (*ReplicationHealth, error) {
+	db.calls++
+	return db.health, db.err
+}
+
+func TestReplicationHealthCheck(t *testing.T) {
+	var ctx = context.Background()
+	var db = &testHealthDatabase{health: &ReplicationHealth{Warnings: []string{"slot at risk"}}}
+	var c, _ = newTestCapture(db)
+	c.State.Cursor = "test-cursor"
+
+	// Warnings are only logged, and checks are rate-limited.
+	require.NoError(t, c.checkReplicationHealth(ctx))
+	require.NoError(t, c.checkReplicationHealth(ctx))
+	require.Equal(t, 1, db.calls)
+
+	// Failures to perform the check are only logged.
+	c.healthChecked = time.Time{}
+	db.health, db.err = nil, fmt.Errorf("permission denied")
+	require.NoError(t, c.checkReplicationHealth(ctx))
+
+	// A user error reporting lost changes fails the capture.
+	c.healthChecked = time.Time{}
+	db.err = cerrors.NewUserError(nil, "slot no longer exists")
+	require.ErrorContains(t, c.checkReplicationHealth(ctx), "slot no longer exists")
+	require.Equal(t, 3, db.calls)
+}
+
+func TestReplicationHealthCheckWhileStreaming(t *testing.T) {
+	var ctx = context.Background()
+	var db = &testHealthDatabase{Database: &testPositionDatabase{}}
+	var c, _ = newTestCapture(db)
+	c.State.Cursor = "0"
+	c.positional = true
+	var stream = &testReplicationStream{events: make(chan DatabaseEvent, 8)}
+
+	// Health is checked as the cursor advances while streaming to a distant watermark.
+	stream.events <- &FlushEvent{Cursor: "1"}
+	stream.events <- &FlushEvent{Cursor: "2"}
+	stream.events <- &FlushEvent{Cursor: "3"}
+	require.NoError(t, c.streamToWatermark(ctx, stream, "3"))
+	require.Equal(t, 1, db.calls)
+
+	// And a check reporting lost changes fails the capture before the watermark.
+	c.healthChecked = time.Time{}
+	db.err = cerrors.NewUserError(nil, "slot no longer exists")
+	stream.events <- &FlushEvent{Cursor: "4"}
+	require.ErrorContains(t, c.streamToWatermark(ctx, stream, "10"), "slot no longer exists")
+	require.Equal(t, "4", c.State.Cursor)
+}
+
+// testRangeDatabase serves backfill chunks of up to two rows from a table with
+// keys 0 through `rowCount-1`, which it splits into ranges at the boundaries.
+type testRangeDatabase struct {
+	Database
+	rowCount   int
+	boundaries [][]byte
+
+	mu    sync.Mutex
+	scans []string // The resume and end keys of every scan, as "resume-end"
+}
+
+func (db *testRangeDatabase) BackfillConcurrency() int { return 4 }
+
+func (db *testRangeDatabase) EstimatedRowCount(ctx context.Context, info *DiscoveryInfo) (int, error) {
+	return db.rowCount, nil
+}
+
+func (db *testRangeDatabase) BackfillKeyRanges(ctx context.Context, info *DiscoveryInfo, keyColumns []string) ([][]byte, error) {
+	return db.boundaries, nil
+}
+
+func (db *testRangeDatabase) ScanTableChunk(ctx context.Context, info *DiscoveryInfo, state *TableState, filter *RowFilter, callback func(event *ChangeEvent) error) error {
+	db.mu.Lock()
+	db.scans = append(db.scans, string(state.Scanned)+"-"+string(state.ScanEnd))
+	db.mu.Unlock()
+
+	var count int
+	for idx := 0; idx < db.rowCount && count < 2; idx++ {
+		var key = []byte(fmt.Sprintf("%04d", idx))
+		if compareTuples(key, state.Scanned) <= 0 || (state.ScanEnd != nil && compareTuples(key, state.ScanEnd) > 0) {
+			continue
+		}
+		if err := callback(&ChangeEvent{
+			Operation: InsertOp,
+			RowKey:    key,
+			Source:    &testSource{SourceCommon{Schema: info.Schema, Table: info.Name, Snapshot: true}},
+			After:     map[string]interface{}{"id": idx},
+		}); err != nil {
+			return err
+		}
+		count++
+	}
+	return nil
+}
+
+func TestKeyRangeBackfill(t *testing.T) {
+	var ctx = context.Background()
+	var db = &testRangeDatabase{rowCount: 6, boundaries: [][]byte{[]byte("0003"), []byte("0001"), []byte("0003")}}
+	var state = &TableState{Mode: TableModePreciseBackfill, KeyColumns: []string{"id"}}
+	var c, server = newTestCapture(db, testBinding{Resource: Resource{Namespace: "test", Stream: "a"}, State: state})
+	var replicate = func(key string) int {
+		var sent = len(server.sent)
+		require.NoError(t, c.handleReplicationEvent(&ChangeEvent{
+			Operation: UpdateOp,
+			RowKey:    []byte(key),
+			Source:    &testSource{SourceCommon{Schema: "test", Table: "a"}},
+			After:     map[string]interface{}{"id": key},
+		}))
+		return len(server.sent) - sent
+	}
+
+	// The boundaries are sorted and deduplicated, and every range is scanned at once.
+	require.NoError(t, c.backfillStreams(ctx))
+	require.Equal(t, []*BackfillRange{
+		{Scanned: []byte("0001"), End: []byte("0001")},
+		{Scanned: []byte("0003"), End: []byte("0003")},
+		{Scanned: []byte("0005")},
+	}, state.Ranges)
+	require.ElementsMatch(t, []string{"-0001", "0001-0003", "0003-"}, db.scans)
+	require.Len(t, server.sent, 6)
+	require.Equal(t, 6, state.BackfilledCount)
+
+	// Replicated changes are filtered against the progress of their own range.
+	state.Ranges[1].Scanned = []byte("0002")
+	require.Equal(t, 1, replicate("0002"))
+	require.Equal(t, 0, replicate("0003"))
+	require.Equal(t, 1, replicate("0004"))
+	state.Ranges[1].Scanned = []byte("0003")
+
+	// Each range completes once a scan of it is empty, and the backfill with them.
+	db.scans = nil
+	require.NoError(t, c.backfillStreams(ctx))
+	require.Len(t, db.scans, 3)
+	require.Equal(t, TableModeActive, state.Mode)
+	require.Nil(t, state.Ranges)
+	require.Equal(t, 1, replicate("0003"))
+
+	// A table is backfilled sequentially when the database suggests no boundaries.
+	state.Mode = TableModePreciseBackfill
+	state.BackfilledCount = 0
+	db.boundaries = nil
+	require.NoError(t, c.backfillStreams(ctx))
+	require.Nil(t, state.Ranges)
+	require.Equal(t, []byte("0001"), state.Scanned)
+}
@@ -14,8 +14,10 @@ import (
 )
 
 // DiscoverCatalog queries the database and generates discovered bindings
-// describing the available tables and their columns.
-func DiscoverCatalog(ctx context.Context, db Database) ([]*pc.Response_Discovered_Binding, error) {
+// describing the available tables and their columns. The optional resources,
+// keyed by stream ID, are checked for column policies which refer to columns
+// that don't exist.
+func DiscoverCatalog(ctx context.Context, db Database, resources map[string]Resource) ([]*pc.Response_Discovered_Binding, error) {
 	tables, err := db.DiscoverTables(ctx)
 	if err != nil {
 		return nil, err
@@ -34,6 +36,8 @@ func DiscoverCatalog(ctx context.Context, db Database) ([]*pc.Response_Discovere
 		AllowAdditionalProperties: true,
 	}).Reflect(db.EmptySourceMetadata())
 	sourceSchema.Version = ""
+	var debezium = db.DebeziumInfo() != nil
+	var transactionMetadata = db.TransactionMetadata()
 
 	var catalog []*pc.Response_Discovered_Binding
 	for _, table := range tables {
@@ -64,6 +68,10 @@ func DiscoverCatalog(ctx context.Context, db Database) ([]*pc.Response_Discovere
 		// The anchor by which we'll reference the table schema.
 		var anchor = strings.Title(table.Schema) + strings.Title(table.Name)
 
+		if unknown := resources[JoinStreamID(table.Schema, table.Name)].unknownPolicyColumns(table.ColumnNames); len(unknown) > 0 {
+			return nil, fmt.Errorf("column policies of table %q refer to nonexistent columns %q", JoinStreamID(table.Schema, table.Name), unknown)
+		}
+
 		// Build `properties` schemas for each table column.
 		var properties = make(map[string]*jsonschema.Schema)
 		for _, column := range table.Columns {
@@ -83,6 +91,47 @@ func DiscoverCatalog(ctx context.Context, db Database) ([]*pc.Response_Discovere
 			}
 		}
 
+		// Properties of the `_meta` object. When emitting Debezium envelopes the before
+		// and after states are always present, and null when inapplicable.
+		var metaProperties = map[string]*jsonschema.Schema{
+			"op": {
+				Enum:        []interface{}{"c", "d", "t", "u"},
+				Description: "Change operation type: 'c' Create/Insert, 'u' Update, 'd' Delete, 't' Truncate.",
+			},
+			"source": sourceSchema,
+			"before": {
+				Ref:         "#" + anchor,
+				Description: "Record state immediately before this change was applied.",
+				Extras: map[string]interface{}{
+					"reduce": map[string]interface{}{
+						"strategy": "firstWriteWins",
+					},
+				},
+			},
+		}
+		if debezium {
+			metaProperties["op"] = &jsonschema.Schema{
+				Enum:        []interface{}{"c", "d", "r", "t", "u"},
+				Description: "Change operation type: 'c' Create/Insert, 'r' Read (backfill), 'u' Update, 'd' Delete, 't' Truncate.",
+			}
+			metaProperties["before"] = &jsonschema.Schema{
+				AnyOf:       []*jsonschema.Schema{{Ref: "#" + anchor}, {Type: "null"}},
+				Description: metaProperties["before"].Description,
+				Extras:      metaProperties["before"].Extras,
+			}
+			metaProperties["after"] = &jsonschema.Schema{
+				AnyOf:       []*jsonschema.Schema{{Ref: "#" + anchor}, {Type: "null"}},
+				Description: "Record state immediately after this change was applied.",
+			}
+			metaProperties["ts_ms"] = &jsonschema.Schema{
+				Type:        "integer",
+				Description: "Unix timestamp (in millis) at which the connector processed this change.",
+			}
+		}
+		if transactionMetadata {
+			metaProperties["transaction"] = transactionInfoSchema()
+		}
+
 		// Schema.Properties is a weird OrderedMap thing, which doesn't allow for inline
 		// literal construction. Instead, use the Schema.Extras mechanism with "properties"
 		// to generate the properties keyword with an inline map.
@@ -104,22 +153,7 @@ func DiscoverCatalog(ctx context.Context, db Database) ([]*pc.Response_Discovere
 							"_meta": {
 								Type: "object",
 								Extras: map[string]interface{}{
-									"properties": map[string]*jsonschema.Schema{
-										"op": {
-											Enum:        []interface{}{"c", "d", "u"},
-											Description: "Change operation type: 'c' Create/Insert, 'u' Update, 'd' Delete.",
-										},
-										"source": sourceSchema,
-										"before": {
-											Ref:         "#" + anchor,
-											Description: "Record state immediately before this change was applied.",
-											Extras: map[string]interface{}{
-												"reduce": map[string]interface{}{
-													"strategy": "firstWriteWins",
-												},
-											},
-										},
-									},
+									"properties": metaProperties,
 									"reduce": map[string]interface{}{
 										"strategy": "merge",
 									},
@@ -206,7 +240,17 @@ func DiscoverCatalog(ctx context.Context, db Database) ([]*pc.Response_Discovere
 		})
 
 	}
-	return catalog, err
+
+	// The transaction metadata binding is suggested alongside the tables whenever
+	// transaction metadata is enabled.
+	if transactionMetadata {
+		var binding, err = discoverTransactionsBinding()
+		if err != nil {
+			return nil, err
+		}
+		catalog = append(catalog, binding)
+	}
+	return catalog, nil
 }
 
 // Per the flow JSON schema: Collection names are paths of Unicode letters, numbers, '-', '_', or
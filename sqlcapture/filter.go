@@ -0,0 +1,729 @@
+package sqlcapture
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// A RowFilter is a boolean expression over the columns of a table which limits
+// the rows captured from it. It's pushed down into backfill queries as SQL and
+// evaluated directly against the row images of replicated changes, so it's kept
+// to a small subset of SQL whose semantics are the same in both places:
+//
+//   - Comparisons of a column with a literal value: =, <>, !=, <, <=, >, >=
+//   - Membership tests: column [NOT] IN (value, ...)
+//   - Null tests: column IS [NOT] NULL
+//   - Combinations of the above using AND, OR, NOT and parentheses
+//
+// Literal values may be numbers, 'single-quoted' strings, TRUE or FALSE. Column
+// names may be "double-quoted" and are always matched exactly. Comparisons with
+// NULL column values follow the three-valued logic of SQL.
+//
+// Strings are compared byte-wise rather than according to the collation of the
+// column, which is often case- or accent-insensitive. The SQL rendering of string
+// comparisons therefore uses the BinaryString of the dialect, so that backfills
+// select the same rows as the filtering of replicated changes.
+type RowFilter struct {
+	expr    filterExpr
+	columns []string // The distinct columns referenced by the filter
+}
+
+// FilterDialect describes how a row filter is rendered as SQL for a particular database.
+type FilterDialect struct {
+	QuoteColumn  func(name string) string   // Quotes a column name
+	QuoteString  func(str string) string    // Quotes a string literal, or nil for standard SQL quoting
+	BinaryString func(column string) string // Converts a quoted column into a string which compares byte-wise with string literals, or nil if no conversion is needed
+	True, False  string                     // The literals TRUE and FALSE
+}
+
+// ParseRowFilter parses a row filter expression.
+func ParseRowFilter(filter string) (*RowFilter, error) {
+	var p = &filterParser{input: filter}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	var expr, err = p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at offset %d", p.tok, p.tok.pos)
+	}
+	var columns []string
+	for _, column := range p.columns {
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	return &RowFilter{expr: expr, columns: columns}, nil
+}
+
+// Columns returns the names of the columns referenced by the filter.
+func (f *RowFilter) Columns() []string {
+	return f.columns
+}
+
+// HasColumns returns true if the row contains every column the filter references.
+// A row image which lacks some of them (for instance the "before" image of a change
+// from a table whose replica identity is just its primary key) can't be evaluated.
+func (f *RowFilter) HasColumns(row map[string]any) bool {
+	for _, column := range f.columns {
+		if _, ok := row[column]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches evaluates the filter against a row. As in a SQL WHERE clause, the row
+// matches only if the filter evaluates to true rather than to false or NULL.
+func (f *RowFilter) Matches(row map[string]any) (bool, error) {
+	var result, err = f.expr.eval(row)
+	if err != nil {
+		return false, err
+	}
+	return result == triTrue, nil
+}
+
+// SQL renders the filter as a SQL condition in the provided dialect.
+func (f *RowFilter) SQL(dialect *FilterDialect) string {
+	return f.expr.sql(dialect)
+}
+
+// BackfillCondition returns the SQL condition limiting the rows scanned by a table
+// backfill, which combines the row filter of the binding (if any) with any condition
+// specific to the current backfill. It's empty if the whole table should be scanned.
+//
+// The backfill-specific condition originates from a signal and is parsed as a row
+// filter so that it's rendered in the dialect of the database rather than being
+// pasted into the query verbatim.
+func BackfillCondition(state *TableState, filter *RowFilter, dialect *FilterDialect) (string, error) {
+	var conditions []string
+	if filter != nil {
+		conditions = append(conditions, filter.SQL(dialect))
+	}
+	if state.BackfillWhere != "" {
+		var where, err = ParseRowFilter(state.BackfillWhere)
+		if err != nil {
+			return "", fmt.Errorf("invalid backfill condition %q: %w", state.BackfillWhere, err)
+		}
+		conditions = append(conditions, where.SQL(dialect))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// tristate is the result of evaluating a SQL boolean expression.
+type tristate int
+
+const (
+	triFalse tristate = iota
+	triTrue
+	triUnknown
+)
+
+func triAnd(x, y tristate) tristate {
+	if x == triFalse || y == triFalse {
+		return triFalse
+	} else if x == triUnknown || y == triUnknown {
+		return triUnknown
+	}
+	return triTrue
+}
+
+func triOr(x, y tristate) tristate {
+	if x == triTrue || y == triTrue {
+		return triTrue
+	} else if x == triUnknown || y == triUnknown {
+		return triUnknown
+	}
+	return triFalse
+}
+
+func triNot(x tristate) tristate {
+	switch x {
+	case triTrue:
+		return triFalse
+	case triFalse:
+		return triTrue
+	}
+	return triUnknown
+}
+
+func triBool(x bool) tristate {
+	if x {
+		return triTrue
+	}
+	return triFalse
+}
+
+type filterExpr interface {
+	eval(row map[string]any) (tristate, error)
+	sql(dialect *FilterDialect) string
+}
+
+type filterAnd struct{ left, right filterExpr }
+type filterOr struct{ left, right filterExpr }
+type filterNot struct{ inner filterExpr }
+
+// filterCompare compares a column with a literal value.
+type filterCompare struct {
+	column string
+	op     string
+	value  *filterLiteral
+}
+
+// filterIn tests whether a column is [not] equal to any of a list of literal values.
+type filterIn struct {
+	column string
+	values []*filterLiteral
+	negate bool
+}
+
+// filterIsNull tests whether a column is [not] null.
+type filterIsNull struct {
+	column string
+	negate bool
+}
+
+func (e *filterAnd) eval(row map[string]any) (tristate, error) {
+	var left, err = e.left.eval(row)
+	if err != nil {
+		return triUnknown, err
+	}
+	right, err := e.right.eval(row)
+	if err != nil {
+		return triUnknown, err
+	}
+	return triAnd(left, right), nil
+}
+
+func (e *filterOr) eval(row map[string]any) (tristate, error) {
+	var left, err = e.left.eval(row)
+	if err != nil {
+		return triUnknown, err
+	}
+	right, err := e.right.eval(row)
+	if err != nil {
+		return triUnknown, err
+	}
+	return triOr(left, right), nil
+}
+
+func (e *filterNot) eval(row map[string]any) (tristate, error) {
+	var inner, err = e.inner.eval(row)
+	if err != nil {
+		return triUnknown, err
+	}
+	return triNot(inner), nil
+}
+
+func (e *filterCompare) eval(row map[string]any) (tristate, error) {
+	var value = row[e.column]
+	if value == nil {
+		return triUnknown, nil
+	}
+	var cmp, err = e.value.compare(value)
+	if err != nil {
+		return triUnknown, fmt.Errorf("column %q: %w", e.column, err)
+	}
+	switch e.op {
+	case "=":
+		return triBool(cmp == 0), nil
+	case "<>":
+		return triBool(cmp != 0), nil
+	case "<":
+		return triBool(cmp < 0), nil
+	case "<=":
+		return triBool(cmp <= 0), nil
+	case ">":
+		return triBool(cmp > 0), nil
+	case ">=":
+		return triBool(cmp >= 0), nil
+	}
+	return triUnknown, fmt.Errorf("unknown comparison operator %q", e.op)
+}
+
+func (e *filterIn) eval(row map[string]any) (tristate, error) {
+	var value = row[e.column]
+	if value == nil {
+		return triUnknown, nil
+	}
+	var result = triFalse
+	for _, lit := range e.values {
+		var cmp, err = lit.compare(value)
+		if err != nil {
+			return triUnknown, fmt.Errorf("column %q: %w", e.column, err)
+		}
+		if cmp == 0 {
+			result = triTrue
+			break
+		}
+	}
+	if e.negate {
+		return triNot(result), nil
+	}
+	return result, nil
+}
+
+func (e *filterIsNull) eval(row map[string]any) (tristate, error) {
+	return triBool((row[e.column] == nil) != e.negate), nil
+}
+
+func (e *filterAnd) sql(d *FilterDialect) string {
+	return "(" + e.left.sql(d) + " AND " + e.right.sql(d) + ")"
+}
+
+func (e *filterOr) sql(d *FilterDialect) string {
+	return "(" + e.left.sql(d) + " OR " + e.right.sql(d) + ")"
+}
+
+func (e *filterNot) sql(d *FilterDialect) string {
+	return "NOT (" + e.inner.sql(d) + ")"
+}
+
+func (e *filterCompare) sql(d *FilterDialect) string {
+	return d.comparedColumn(e.column, e.value) + " " + e.op + " " + e.value.sql(d)
+}
+
+func (e *filterIn) sql(d *FilterDialect) string {
+	var values []string
+	for _, lit := range e.values {
+		values = append(values, lit.sql(d))
+	}
+	var op = " IN ("
+	if e.negate {
+		op = " NOT IN ("
+	}
+	return d.comparedColumn(e.column, e.values...) + op + strings.Join(values, ", ") + ")"
+}
+
+// comparedColumn renders a column which is compared with the provided literals,
+// as a binary string if any of them are strings.
+func (d *FilterDialect) comparedColumn(column string, values ...*filterLiteral) string {
+	var quoted = d.QuoteColumn(column)
+	if d.BinaryString != nil && slices.ContainsFunc(values, func(lit *filterLiteral) bool { return lit.kind == literalString }) {
+		return d.BinaryString(quoted)
+	}
+	return quoted
+}
+
+func (e *filterIsNull) sql(d *FilterDialect) string {
+	if e.negate {
+		return d.QuoteColumn(e.column) + " IS NOT NULL"
+	}
+	return d.QuoteColumn(e.column) + " IS NULL"
+}
+
+type literalKind int
+
+const (
+	literalString literalKind = iota
+	literalNumber
+	literalBool
+)
+
+// filterLiteral is a literal value in a row filter.
+type filterLiteral struct {
+	kind   literalKind
+	text   string   // The string value, or the number as written
+	number *big.Rat // The exact value of a number
+	bool   bool
+}
+
+func (lit *filterLiteral) sql(d *FilterDialect) string {
+	switch lit.kind {
+	case literalNumber:
+		return lit.text
+	case literalBool:
+		if lit.bool {
+			return d.True
+		}
+		return d.False
+	}
+	if d.QuoteString != nil {
+		return d.QuoteString(lit.text)
+	}
+	return "'" + strings.ReplaceAll(lit.text, "'", "''") + "'"
+}
+
+// compare compares a non-nil column value with the literal, returning a negative
+// number, zero, or a positive number when the value is less than, equal to, or
+// greater than the literal respectively.
+func (lit *filterLiteral) compare(value any) (int, error) {
+	switch lit.kind {
+	case literalString:
+		switch value := value.(type) {
+		case string:
+			return strings.Compare(value, lit.text), nil
+		case []byte:
+			return strings.Compare(string(value), lit.text), nil
+		}
+		return 0, fmt.Errorf("cannot compare %T value with string %q", value, lit.text)
+	case literalBool:
+		var x bool
+		switch value := value.(type) {
+		case bool:
+			x = value
+		default:
+			// Databases without a boolean type represent them as the integers 0 and 1.
+			var n, ok = numericValue(value)
+			if !ok || (n.Sign() != 0 && n.Cmp(big.NewRat(1, 1)) != 0) {
+				return 0, fmt.Errorf("cannot compare %T value with boolean", value)
+			}
+			x = n.Sign() != 0
+		}
+		if x == lit.bool {
+			return 0, nil
+		} else if lit.bool {
+			return -1, nil
+		}
+		return 1, nil
+	}
+	var n, ok = numericValue(value)
+	if !ok {
+		return 0, fmt.Errorf("cannot compare %T value %v with number %s", value, value, lit.text)
+	}
+	return n.Cmp(lit.number), nil
+}
+
+// numericValue converts a column value to an exact number if possible. Numbers
+// represented as strings (such as decimals) are parsed.
+func numericValue(value any) (*big.Rat, bool) {
+	switch value := value.(type) {
+	case int:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int8:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int16:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int64:
+		return new(big.Rat).SetInt64(value), true
+	case uint:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint8:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint16:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint32:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint64:
+		return new(big.Rat).SetUint64(value), true
+	case float32:
+		var n = new(big.Rat).SetFloat64(float64(value))
+		return n, n != nil
+	case float64:
+		var n = new(big.Rat).SetFloat64(value)
+		return n, n != nil
+	case bool:
+		if value {
+			return big.NewRat(1, 1), true
+		}
+		return new(big.Rat), true
+	case string:
+		return new(big.Rat).SetString(value)
+	case []byte:
+		return new(big.Rat).SetString(string(value))
+	}
+	return nil, false
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokQuotedIdent
+	tokString
+	tokNumber
+	tokOperator
+	tokLeftParen
+	tokRightParen
+	tokComma
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokString:
+		return fmt.Sprintf("string '%s'", t.text)
+	case tokQuotedIdent:
+		return fmt.Sprintf("column %q", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// isKeyword returns true if the token is the specified (case-insensitive) keyword.
+func (t filterToken) isKeyword(keyword string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, keyword)
+}
+
+var filterKeywords = []string{"AND", "OR", "NOT", "IN", "IS", "NULL", "TRUE", "FALSE"}
+
+type filterParser struct {
+	input   string
+	offset  int
+	tok     filterToken
+	columns []string
+}
+
+// next reads the next token of the input into p.tok.
+func (p *filterParser) next() error {
+	for p.offset < len(p.input) && unicode.IsSpace(rune(p.input[p.offset])) {
+		p.offset++
+	}
+	var start = p.offset
+	if start >= len(p.input) {
+		p.tok = filterToken{kind: tokEOF, pos: start}
+		return nil
+	}
+
+	var c = p.input[start]
+	switch {
+	case c == '(':
+		p.offset++
+		p.tok = filterToken{kind: tokLeftParen, text: "(", pos: start}
+	case c == ')':
+		p.offset++
+		p.tok = filterToken{kind: tokRightParen, text: ")", pos: start}
+	case c == ',':
+		p.offset++
+		p.tok = filterToken{kind: tokComma, text: ",", pos: start}
+	case c == '\'' || c == '"':
+		// Quoted strings and identifiers, in which the quote character is escaped by doubling it.
+		var text = new(strings.Builder)
+		for p.offset++; ; p.offset++ {
+			if p.offset >= len(p.input) {
+				return fmt.Errorf("unterminated quote at offset %d", start)
+			}
+			if p.input[p.offset] == c {
+				if p.offset+1 < len(p.input) && p.input[p.offset+1] == c {
+					p.offset++
+				} else {
+					break
+				}
+			}
+			text.WriteByte(p.input[p.offset])
+		}
+		p.offset++
+		var kind = tokString
+		if c == '"' {
+			kind = tokQuotedIdent
+		}
+		p.tok = filterToken{kind: kind, text: text.String(), pos: start}
+	case c == '=' || c == '<' || c == '>' || c == '!':
+		var op = string(c)
+		if p.offset+1 < len(p.input) {
+			if two := p.input[p.offset : p.offset+2]; two == "<=" || two == ">=" || two == "<>" || two == "!=" {
+				op = two
+			}
+		}
+		if op == "!" {
+			return fmt.Errorf("unexpected '!' at offset %d", start)
+		}
+		p.offset += len(op)
+		p.tok = filterToken{kind: tokOperator, text: op, pos: start}
+	case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		p.offset++
+		for p.offset < len(p.input) {
+			var d = p.input[p.offset]
+			if (d >= '0' && d <= '9') || d == '.' || d == 'e' || d == 'E' || ((d == '-' || d == '+') && (p.input[p.offset-1] == 'e' || p.input[p.offset-1] == 'E')) {
+				p.offset++
+			} else {
+				break
+			}
+		}
+		p.tok = filterToken{kind: tokNumber, text: p.input[start:p.offset], pos: start}
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.offset < len(p.input) {
+			var d = rune(p.input[p.offset])
+			if d == '_' || d == '$' || unicode.IsLetter(d) || unicode.IsDigit(d) {
+				p.offset++
+			} else {
+				break
+			}
+		}
+		p.tok = filterToken{kind: tokIdent, text: p.input[start:p.offset], pos: start}
+	default:
+		return fmt.Errorf("unexpected character %q at offset %d", c, start)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	var expr, err = p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.isKeyword("OR") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		expr = &filterOr{expr, right}
+	}
+	return expr, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	var expr, err = p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.isKeyword("AND") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		expr = &filterAnd{expr, right}
+	}
+	return expr, nil
+}
+
+func (p *filterParser) parseNot() (filterExpr, error) {
+	if p.tok.isKeyword("NOT") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		var inner, err = p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{inner}, nil
+	}
+	if p.tok.kind == tokLeftParen {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		var expr, err = p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRightParen {
+			return nil, fmt.Errorf("expected ')' but found %s at offset %d", p.tok, p.tok.pos)
+		}
+		return expr, p.next()
+	}
+	return p.parseCondition()
+}
+
+// parseCondition parses a comparison, membership test, or null test of a column.
+func (p *filterParser) parseCondition() (filterExpr, error) {
+	var column = p.tok
+	if column.kind == tokIdent {
+		for _, keyword := range filterKeywords {
+			if column.isKeyword(keyword) {
+				return nil, fmt.Errorf("expected a column name but found %s at offset %d", column, column.pos)
+			}
+		}
+	} else if column.kind != tokQuotedIdent {
+		return nil, fmt.Errorf("expected a column name but found %s at offset %d", column, column.pos)
+	}
+	p.columns = append(p.columns, column.text)
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.tok.kind == tokOperator:
+		var op = p.tok.text
+		if op == "!=" {
+			op = "<>"
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		var value, err = p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &filterCompare{column: column.text, op: op, value: value}, nil
+	case p.tok.isKeyword("IS"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		var negate = p.tok.isKeyword("NOT")
+		if negate {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if !p.tok.isKeyword("NULL") {
+			return nil, fmt.Errorf("expected NULL but found %s at offset %d", p.tok, p.tok.pos)
+		}
+		return &filterIsNull{column: column.text, negate: negate}, p.next()
+	case p.tok.isKeyword("IN") || p.tok.isKeyword("NOT"):
+		var negate = p.tok.isKeyword("NOT")
+		if negate {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if !p.tok.isKeyword("IN") {
+				return nil, fmt.Errorf("expected IN but found %s at offset %d", p.tok, p.tok.pos)
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokLeftParen {
+			return nil, fmt.Errorf("expected '(' but found %s at offset %d", p.tok, p.tok.pos)
+		}
+		var values []*filterLiteral
+		for {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			var value, err = p.parseLiteral()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if p.tok.kind == tokRightParen {
+				break
+			} else if p.tok.kind != tokComma {
+				return nil, fmt.Errorf("expected ',' or ')' but found %s at offset %d", p.tok, p.tok.pos)
+			}
+		}
+		return &filterIn{column: column.text, values: values, negate: negate}, p.next()
+	}
+	return nil, fmt.Errorf("expected a comparison of column %q but found %s at offset %d", column.text, p.tok, p.tok.pos)
+}
+
+func (p *filterParser) parseLiteral() (*filterLiteral, error) {
+	var tok = p.tok
+	var lit *filterLiteral
+	switch {
+	case tok.kind == tokString:
+		lit = &filterLiteral{kind: literalString, text: tok.text}
+	case tok.kind == tokNumber:
+		var n, ok = new(big.Rat).SetString(tok.text)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q at offset %d", tok.text, tok.pos)
+		}
+		lit = &filterLiteral{kind: literalNumber, text: tok.text, number: n}
+	case tok.isKeyword("TRUE"), tok.isKeyword("FALSE"):
+		lit = &filterLiteral{kind: literalBool, text: strings.ToUpper(tok.text), bool: tok.isKeyword("TRUE")}
+	case tok.isKeyword("NULL"):
+		return nil, fmt.Errorf("comparisons with NULL are never true, use IS NULL or IS NOT NULL instead (at offset %d)", tok.pos)
+	default:
+		return nil, fmt.Errorf("expected a literal value but found %s at offset %d", tok, tok.pos)
+	}
+	return lit, p.next()
+}
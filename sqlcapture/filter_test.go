@@ -0,0 +1,134 @@
+package sqlcapture
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFilterDialect = &FilterDialect{
+	QuoteColumn: func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` },
+	True:        "TRUE",
+	False:       "FALSE",
+}
+
+func TestParseRowFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		sql    string
+		err    string
+	}{
+		{
+			filter: "tenant_id IN (1, 2, 3)",
+			sql:    `"tenant_id" IN (1, 2, 3)`,
+		},
+		{
+			filter: `region = 'us-east' and NOT ("Deleted" = true or archived is not null)`,
+			sql:    `("region" = 'us-east' AND NOT (("Deleted" = TRUE OR "archived" IS NOT NULL)))`,
+		},
+		{
+			filter: "a = 1 OR b != -2.5 AND c NOT IN ('x', 'it''s')",
+			sql:    `("a" = 1 OR ("b" <> -2.5 AND "c" NOT IN ('x', 'it''s')))`,
+		},
+		{
+			filter: "amount >= 1e3",
+			sql:    `"amount" >= 1e3`,
+		},
+		{filter: "", err: "expected a column name but found end of filter"},
+		{filter: "a = NULL", err: "use IS NULL or IS NOT NULL instead"},
+		{filter: "a = 1; DROP TABLE foo", err: "unexpected character ';'"},
+		{filter: "a = b", err: "expected a literal value"},
+		{filter: "(a = 1", err: "expected ')'"},
+		{filter: "a = 'unterminated", err: "unterminated quote"},
+		{filter: "a IN ()", err: "expected a literal value"},
+		{filter: "and = 1", err: "expected a column name"},
+		{filter: "a = 1.2.3", err: "invalid number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			var filter, err = ParseRowFilter(tt.filter)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.sql, filter.SQL(testFilterDialect))
+		})
+	}
+}
+
+func TestRowFilterMatches(t *testing.T) {
+	tests := []struct {
+		filter  string
+		row     map[string]any
+		matches bool
+		err     string
+	}{
+		{filter: "tenant_id IN (1, 2)", row: map[string]any{"tenant_id": int64(2)}, matches: true},
+		{filter: "tenant_id IN (1, 2)", row: map[string]any{"tenant_id": int32(3)}, matches: false},
+		{filter: "tenant_id NOT IN (1, 2)", row: map[string]any{"tenant_id": nil}, matches: false},
+		{filter: "NOT (tenant_id = 1)", row: map[string]any{"tenant_id": nil}, matches: false},
+		{filter: "tenant_id IS NULL", row: map[string]any{"tenant_id": nil}, matches: true},
+		{filter: "tenant_id = 1 OR tenant_id IS NULL", row: map[string]any{"tenant_id": nil}, matches: true},
+		{filter: "price > 10.5", row: map[string]any{"price": "10.75"}, matches: true},
+		{filter: "price > 10.5", row: map[string]any{"price": float64(10.5)}, matches: false},
+		{filter: "id <= 18446744073709551615", row: map[string]any{"id": uint64(18446744073709551615)}, matches: true},
+		{filter: "region = 'us-east'", row: map[string]any{"region": []byte("us-east")}, matches: true},
+		{filter: "region < 'b'", row: map[string]any{"region": "a"}, matches: true},
+		{filter: "active = TRUE", row: map[string]any{"active": true}, matches: true},
+		{filter: "active = FALSE", row: map[string]any{"active": int64(0)}, matches: true},
+		{filter: "region = 1", row: map[string]any{"region": "us-east"}, err: `column "region": cannot compare`},
+		{filter: "region = 'x'", row: map[string]any{"region": int64(1)}, err: `column "region": cannot compare`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			var filter, err = ParseRowFilter(tt.filter)
+			require.NoError(t, err)
+			matches, err := filter.Matches(tt.row)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.matches, matches)
+		})
+	}
+}
+
+func TestRowFilterBinaryStrings(t *testing.T) {
+	var dialect = *testFilterDialect
+	dialect.BinaryString = func(column string) string { return column + ` COLLATE "C"` }
+
+	var filter, err = ParseRowFilter(`region = 'us-east' AND tenant_id = 1 AND name IN ('a', 'B') AND code NOT IN (1, 2)`)
+	require.NoError(t, err)
+	require.Equal(t, `((("region" COLLATE "C" = 'us-east' AND "tenant_id" = 1) AND "name" COLLATE "C" IN ('a', 'B')) AND "code" NOT IN (1, 2))`, filter.SQL(&dialect))
+
+	// Replicated changes are likewise compared byte-wise.
+	matches, err := filter.Matches(map[string]any{"region": "US-EAST", "tenant_id": 1, "name": "a", "code": 3})
+	require.NoError(t, err)
+	require.False(t, matches)
+}
+
+func TestBackfillCondition(t *testing.T) {
+	var filter, err = ParseRowFilter("a = 1 OR b = 2")
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		state  *TableState
+		filter *RowFilter
+		expect string
+	}{
+		{&TableState{}, nil, ""},
+		{&TableState{}, filter, `("a" = 1 OR "b" = 2)`},
+		{&TableState{BackfillWhere: "id > 1000"}, filter, `("a" = 1 OR "b" = 2) AND "id" > 1000`},
+		{&TableState{BackfillWhere: "name = 'x''y'"}, nil, `"name" = 'x''y'`},
+	} {
+		var where, err = BackfillCondition(tc.state, tc.filter, testFilterDialect)
+		require.NoError(t, err)
+		require.Equal(t, tc.expect, where)
+	}
+
+	// Conditions which aren't valid row filters are never pasted into the query.
+	_, err = BackfillCondition(&TableState{BackfillWhere: "1=1; DROP TABLE users"}, nil, testFilterDialect)
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package sqlcapture
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"time"
+
+	cerrors "github.com/estuary/connectors/go/connector-errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ReplicationHealth describes how close the replication cursor of a capture is to
+// falling out of the database's retention of replication data.
+type ReplicationHealth struct {
+	Details  map[string]any // Database-specific measurements, which are logged with every check
+	Warnings []string       // Problems which put changes at risk of being removed before they're captured
+}
+
+// checkReplicationHealth runs the replication health check of the database, at most
+// once per replicationHealthInterval. The check is purely diagnostic, so failures to
+// perform it are only logged. It returns an error only when the check reports (as a
+// user error) that the cursor has fallen out of retention and the capture should fail.
+func (c *Capture) checkReplicationHealth(ctx context.Context) error {
+	if time.Since(c.healthChecked) < replicationHealthInterval {
+		return nil
+	}
+	c.healthChecked = time.Now()
+
+	var health, err = c.Database.ReplicationHealth(ctx, c.State.Cursor)
+	var userErr *cerrors.UserError
+	if errors.As(err, &userErr) {
+		return err
+	} else if err != nil {
+		logrus.WithField("err", err).Warn("unable to check replication health")
+		return nil
+	} else if health == nil {
+		return nil
+	}
+
+	var fields = logrus.Fields{"cursor": c.State.Cursor}
+	maps.Copy(fields, health.Details)
+	logrus.WithFields(fields).Info("replication health")
+	for _, warning := range health.Warnings {
+		logrus.WithFields(fields).Warn(warning)
+	}
+	return nil
+}
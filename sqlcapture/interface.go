@@ -19,6 +19,8 @@ const (
 	UpdateOp ChangeOp = "u"
 	// DeleteOp is a DELETE operation.
 	DeleteOp ChangeOp = "d"
+	// TruncateOp is a TRUNCATE operation, which removes all rows of a table.
+	TruncateOp ChangeOp = "t"
 )
 
 // SourceCommon is common source metadata for data capture events.
@@ -64,6 +66,33 @@ type SourceMetadata interface {
 	Common() SourceCommon
 }
 
+// DebeziumSourceMetadata is implemented by source metadata which can describe
+// itself using the connector-specific properties of a Debezium source block.
+type DebeziumSourceMetadata interface {
+	SourceMetadata
+	// DebeziumSource returns the connector-specific Debezium source properties,
+	// which must not duplicate any properties of the source metadata itself.
+	DebeziumSource() map[string]any
+}
+
+// TransactionSourceMetadata is implemented by source metadata which can identify
+// the source transaction of a replicated change event.
+type TransactionSourceMetadata interface {
+	SourceMetadata
+	// TransactionID returns an identifier of the source transaction, which must be
+	// the same for all changes of a transaction and differ between transactions.
+	TransactionID() string
+}
+
+// DebeziumInfo describes a capture for the purpose of emitting Debezium change
+// event envelopes.
+type DebeziumInfo struct {
+	Connector string // The Debezium connector name, such as "postgresql".
+	Version   string // The version of the connector.
+	Name      string // The logical name of the capture. Filled in from the capture name if unset.
+	Database  string // The logical database name, if not provided by the source metadata.
+}
+
 // ChangeEvent represents an Insert/Update/Delete operation on a specific
 // row in the database.
 type ChangeEvent struct {
@@ -87,19 +116,58 @@ type MetadataEvent struct {
 	Metadata json.RawMessage
 }
 
-// A DatabaseEvent can be a ChangeEvent, FlushEvent, or MetadataEvent.
+// TruncateEvent informs the generic sqlcapture logic that all rows of
+// a table were removed by a TRUNCATE operation.
+type TruncateEvent struct {
+	Source SourceMetadata
+}
+
+// TableDropEvent informs the generic sqlcapture logic that a table
+// was dropped and should no longer be captured.
+type TableDropEvent struct {
+	StreamID string
+}
+
+// TableRenameEvent informs the generic sqlcapture logic that a table was
+// renamed, and that its binding should continue capturing it under the
+// new name.
+type TableRenameEvent struct {
+	StreamID  string
+	NewSchema string
+	NewTable  string
+}
+
+// TableReplaceEvent informs the generic sqlcapture logic that a different
+// table now exists under the name of a captured table (for instance because
+// an online schema migration tool swapped a new copy of the table into place)
+// and the table should be backfilled again.
+type TableReplaceEvent struct {
+	StreamID  string
+	Discovery *DiscoveryInfo
+}
+
+// A DatabaseEvent can be a ChangeEvent, FlushEvent, MetadataEvent, TruncateEvent,
+// TableDropEvent, TableRenameEvent, or TableReplaceEvent.
 type DatabaseEvent interface {
 	isDatabaseEvent()
 	String() string
 }
 
-func (*ChangeEvent) isDatabaseEvent()   {}
-func (*FlushEvent) isDatabaseEvent()    {}
-func (*MetadataEvent) isDatabaseEvent() {}
+func (*ChangeEvent) isDatabaseEvent()       {}
+func (*FlushEvent) isDatabaseEvent()        {}
+func (*MetadataEvent) isDatabaseEvent()     {}
+func (*TruncateEvent) isDatabaseEvent()     {}
+func (*TableDropEvent) isDatabaseEvent()    {}
+func (*TableRenameEvent) isDatabaseEvent()  {}
+func (*TableReplaceEvent) isDatabaseEvent() {}
 
-func (*ChangeEvent) String() string   { return "ChangeEvent" }
-func (*FlushEvent) String() string    { return "FlushEvent" }
-func (*MetadataEvent) String() string { return "MetadataEvent" }
+func (*ChangeEvent) String() string       { return "ChangeEvent" }
+func (*FlushEvent) String() string        { return "FlushEvent" }
+func (*MetadataEvent) String() string     { return "MetadataEvent" }
+func (*TruncateEvent) String() string     { return "TruncateEvent" }
+func (*TableDropEvent) String() string    { return "TableDropEvent" }
+func (*TableRenameEvent) String() string  { return "TableRenameEvent" }
+func (*TableReplaceEvent) String() string { return "TableReplaceEvent" }
 
 // KeyFields returns suitable fields for extracting the event primary key.
 func (e *ChangeEvent) KeyFields() map[string]interface{} {
@@ -122,8 +190,25 @@ type Database interface {
 	WriteWatermark(ctx context.Context, watermark string) error
 	// WatermarksTable returns the name of the table to which WriteWatermarks writes UUIDs.
 	WatermarksTable() string
+	// PositionalWatermarks returns true if the capture should use positions of the
+	// replication log as watermarks, instead of writing into the watermarks table.
+	PositionalWatermarks() bool
+	// CurrentPosition returns the current position of the replication log, in the
+	// same form as the cursors of FlushEvents. It's only used by positional watermarks.
+	CurrentPosition(ctx context.Context) (string, error)
+	// PositionReached returns true if the FlushEvent cursor is at or past the
+	// replication log position. It's only used by positional watermarks.
+	PositionReached(cursor, position string) (bool, error)
+	// SignalsTable returns the name of the table from which signals are read, or
+	// the empty string if signals are disabled.
+	SignalsTable() string
 	// ScanTableChunk fetches a chunk of rows from the specified table, resuming from the `resumeAfter` row key if non-nil.
-	ScanTableChunk(ctx context.Context, info *DiscoveryInfo, state *TableState, callback func(event *ChangeEvent) error) error
+	// Only rows matching the row filter of the binding (if non-nil) should be fetched, see BackfillCondition. When the
+	// ScanEnd row key of the state is non-nil, only rows with keys no greater than it should be fetched.
+	ScanTableChunk(ctx context.Context, info *DiscoveryInfo, state *TableState, filter *RowFilter, callback func(event *ChangeEvent) error) error
+	// EstimatedRowCount returns an estimate of the number of rows in a table from the
+	// statistics of the database, or zero if no estimate is available.
+	EstimatedRowCount(ctx context.Context, info *DiscoveryInfo) (int, error)
 	// DiscoverTables queries the database for information about tables available for capture.
 	DiscoverTables(ctx context.Context) (map[string]*DiscoveryInfo, error)
 	// TranslateDBToJSONType returns JSON schema information about the provided database column type.
@@ -132,6 +217,16 @@ type Database interface {
 	EmptySourceMetadata() SourceMetadata
 	// ShouldBackfill returns true if a given table's contents should be backfilled.
 	ShouldBackfill(streamID string) bool
+	// BackfillConcurrency returns the maximum number of tables which may have a
+	// backfill chunk scanned concurrently. ScanTableChunk must be safe to call
+	// concurrently for different tables when this is greater than one.
+	BackfillConcurrency() int
+	// BackfillKeyRanges returns row keys which split the key space of a table into
+	// ranges of roughly equal size, so that the precise backfill of the table may be
+	// split into key ranges which are scanned concurrently. The keys are serialized
+	// like the RowKey of change events. It may return no keys if the table shouldn't
+	// be split, and ScanTableChunk must honor the ScanEnd bound if it returns any.
+	BackfillKeyRanges(ctx context.Context, info *DiscoveryInfo, keyColumns []string) ([][]byte, error)
 
 	// The collection key which should be suggested if discovery fails to find suitable
 	// primary key. This should be some property or combination of properties in the
@@ -158,6 +253,20 @@ type Database interface {
 	// function provides a hook for database-specific diagnostic information to
 	// be logged.
 	ReplicationDiagnostics(ctx context.Context) error
+	// ReplicationHealth is called periodically to check how close the provided cursor
+	// is to falling out of the database's retention of replication data. It should return
+	// a user error if the cursor has fallen out of retention and the capture is configured
+	// to fail in that case, and otherwise report the problem as a warning.
+	ReplicationHealth(ctx context.Context, cursor string) (*ReplicationHealth, error)
+
+	// DebeziumInfo returns a description of the capture for use in Debezium change
+	// event envelopes, or nil if the capture doesn't emit Debezium envelopes.
+	DebeziumInfo() *DebeziumInfo
+
+	// TransactionMetadata returns true if replicated change events should carry the
+	// ID and sequence number of their source transaction. The source metadata of the
+	// change events must then implement TransactionSourceMetadata.
+	TransactionMetadata() bool
 }
 
 // ReplicationStream represents the process of receiving change events
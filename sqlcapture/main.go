@@ -24,6 +24,15 @@ type Resource struct {
 	Namespace string `json:"namespace" jsonschema:"title=Schema,description=The schema (namespace) in which the table resides.,readOnly=true"`
 	Stream    string `json:"stream" jsonschema:"title=Table Name,description=The name of the table to be captured.,readOnly=true"`
 
+	OnTruncate TruncateMode `json:"on_truncate,omitempty" jsonschema:"title=Truncate Handling,description=How a TRUNCATE of the source table should be handled. By default it is logged and otherwise ignored. Marker documents require a collection keyed only on '/_meta/' properties and are not applied by materializations.,default=,enum=,enum=Backfill,enum=Marker"`
+
+	Filter string `json:"filter,omitempty" jsonschema:"title=Row Filter,description=An optional SQL condition limiting which rows of the table are captured. Comparisons of columns with literal values may be combined using AND/OR/NOT. String comparisons are case-sensitive and ignore column collations. A change which moves a row out of the filter is captured as a deletion."`
+
+	ColumnPolicies map[string]ColumnPolicy `json:"column_policies,omitempty" jsonschema:"title=Column Policies,description=Optional policies for withholding the values of specific columns from the captured documents. Columns may be excluded entirely or have their values replaced by a salted SHA-256 hash or by null. Key columns cannot have a policy and the collection schema must permit the resulting values."`
+	HashSalt       string                  `json:"hash_salt,omitempty" jsonschema:"title=Hash Salt,description=The salt prepended to column values hashed by the column policies of this table."`
+
+	Transactions bool `json:"transactions,omitempty" jsonschema:"title=Transaction Metadata,description=Set on the binding which captures one BEGIN and one END document per source transaction instead of the contents of a table."`
+
 	// PrimaryKey allows the user to override the "scan key" columns which will be used
 	// to perform backfill queries and merge replicated changes. If left unset we default
 	// to the collection's key, which is basically always what the user wants, so we omit
@@ -60,17 +69,56 @@ const (
 	BackfillModeWithoutKey = BackfillMode("Without Primary Key")
 )
 
+// TruncateMode represents different ways we might want to handle a TRUNCATE of a captured table.
+type TruncateMode string
+
+const (
+	// TruncateModeIgnore logs the TRUNCATE and otherwise ignores it, so that
+	// the collection will continue to contain the removed rows.
+	TruncateModeIgnore = TruncateMode("")
+
+	// TruncateModeBackfill transitions the table back into a backfill, so that
+	// the collection will be refreshed from the current (truncated) contents.
+	TruncateModeBackfill = TruncateMode("Backfill")
+
+	// TruncateModeMarker emits a document whose `_meta/op` is "t" to signal to
+	// downstream consumers that all preceding rows of the table were removed.
+	//
+	// The marker carries no row values, so it's only supported for collections
+	// keyed on `/_meta/` properties (such as the source position), and it's up to
+	// readers of the collection to act on it. Materializations don't clear their
+	// targets when they see one: bindings of primary-keyed collections which need
+	// to reflect a TRUNCATE should use TruncateModeBackfill instead.
+	TruncateModeMarker = TruncateMode("Marker")
+)
+
 // Validate checks to make sure a resource appears usable.
 func (r Resource) Validate() error {
 	if !slices.Contains([]BackfillMode{BackfillModeAutomatic, BackfillModeNormal, BackfillModePrecise, BackfillModeOnlyChanges, BackfillModeWithoutKey}, r.Mode) {
 		return fmt.Errorf("invalid backfill mode %q", r.Mode)
 	}
+	if !slices.Contains([]TruncateMode{TruncateModeIgnore, TruncateModeBackfill, TruncateModeMarker}, r.OnTruncate) {
+		return fmt.Errorf("invalid truncate handling %q", r.OnTruncate)
+	}
 	if r.Namespace == "" {
 		return fmt.Errorf("table namespace unspecified")
 	}
 	if r.Stream == "" {
 		return fmt.Errorf("table name unspecified")
 	}
+	if r.Filter != "" {
+		if _, err := ParseRowFilter(r.Filter); err != nil {
+			return fmt.Errorf("invalid row filter %q: %w", r.Filter, err)
+		}
+	}
+	for column, policy := range r.ColumnPolicies {
+		if !slices.Contains([]ColumnPolicy{ColumnPolicyExclude, ColumnPolicyHash, ColumnPolicyRedact}, policy) {
+			return fmt.Errorf("invalid policy %q for column %q", policy, column)
+		}
+	}
+	if r.Transactions && (r.Mode != BackfillModeAutomatic || r.OnTruncate != TruncateModeIgnore || r.Filter != "" || len(r.ColumnPolicies) > 0) {
+		return fmt.Errorf("the transaction metadata binding doesn't capture a table and cannot have table options")
+	}
 	return nil
 }
 
@@ -83,7 +131,8 @@ type Binding struct {
 	StreamID      string
 	StateKey      boilerplate.StateKey
 	Resource      Resource
-	CollectionKey []string // JSON pointers
+	CollectionKey []string   // JSON pointers
+	Filter        *RowFilter // The parsed row filter of the resource, if any
 }
 
 // Driver is an implementation of the pc.DriverServer interface which performs
@@ -163,6 +212,9 @@ func (d *Driver) Apply(ctx context.Context, req *pc.Request_Apply) (*pc.Response
 				return nil, fmt.Errorf("error parsing resource config: %w", err)
 			}
 			res.SetDefaults()
+			if res.Transactions {
+				continue
+			}
 
 			var streamID = JoinStreamID(res.Namespace, res.Stream)
 
@@ -182,7 +234,19 @@ func (d *Driver) Validate(ctx context.Context, req *pc.Request_Validate) (*pc.Re
 	}
 	defer db.Close(ctx)
 
-	if _, err := DiscoverCatalog(ctx, db); err != nil {
+	var resources = make(map[string]Resource)
+	for _, binding := range req.Bindings {
+		var res Resource
+		if err := pf.UnmarshalStrict(binding.ResourceConfigJson, &res); err != nil {
+			return nil, fmt.Errorf("error parsing resource config: %w", err)
+		}
+		res.SetDefaults()
+		if !res.Transactions {
+			resources[JoinStreamID(res.Namespace, res.Stream)] = res
+		}
+	}
+
+	if _, err := DiscoverCatalog(ctx, db, resources); err != nil {
 		return nil, err
 	}
 
@@ -195,11 +259,47 @@ func (d *Driver) Validate(ctx context.Context, req *pc.Request_Validate) (*pc.Re
 		}
 		res.SetDefaults()
 
+		// The transaction metadata binding doesn't correspond to any table.
+		if res.Transactions {
+			if !db.TransactionMetadata() {
+				errs = append(errs, fmt.Errorf("the transaction metadata binding requires the capture to have transaction metadata enabled"))
+				continue
+			}
+			out = append(out, &pc.Response_Validated_Binding{
+				ResourcePath: []string{res.Namespace, res.Stream},
+			})
+			continue
+		}
+
+		var keyColumns []string
+		for _, ptr := range binding.Collection.Key {
+			keyColumns = append(keyColumns, collectionKeyToPrimaryKey(ptr))
+		}
+		if err := res.validateColumnPolicies(keyColumns); err != nil {
+			errs = append(errs, fmt.Errorf("table %q: %w", JoinStreamID(res.Namespace, res.Stream), err))
+			continue
+		}
+		if err := res.validatePolicyTypes(&binding.Collection); err != nil {
+			errs = append(errs, fmt.Errorf("table %q: %w", JoinStreamID(res.Namespace, res.Stream), err))
+			continue
+		}
+
 		if err := db.SetupTablePrerequisites(ctx, res.Namespace, res.Stream); err != nil {
 			errs = append(errs, err)
 			continue
 		}
 
+		// Truncate marker documents carry no row values, so they can only be captured
+		// into collections whose key is derived from the source metadata.
+		if res.OnTruncate == TruncateModeMarker {
+			for _, ptr := range binding.Collection.Key {
+				if !strings.HasPrefix(ptr, "/_meta/") {
+					errs = append(errs, fmt.Errorf("table %q: truncate markers cannot be captured into a collection keyed on %q, only keys under '/_meta/' are supported (use truncate handling 'Backfill' instead)", JoinStreamID(res.Namespace, res.Stream), ptr))
+					break
+				}
+			}
+		}
+
 		out = append(out, &pc.Response_Validated_Binding{
 			ResourcePath: []string{res.Namespace, res.Stream},
 		})
@@ -219,13 +319,17 @@ func (d *Driver) Discover(ctx context.Context, req *pc.Request_Discover) (*pc.Re
 	}
 	defer db.Close(ctx)
 
-	discoveredBindings, err := DiscoverCatalog(ctx, db)
+	// Discover requests don't carry the resource configs of any existing bindings,
+	// so the schemas discovered here cannot reflect their column policies. Validation
+	// instead checks that the collection schema permits the values which a policy
+	// produces, and the schema must be edited by the user when it doesn't.
+	discoveredBindings, err := DiscoverCatalog(ctx, db, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// Filter well-known flow created tables out of the discovered catalog before output. These
-	// include the watermarks table that this capture would create as-configured as well as
+	// include the watermarks and signals tables of this capture as-configured as well as
 	// materialization metadata tables. They are basically never useful to capture so we shouldn't
 	// suggest them.
 
@@ -233,6 +337,7 @@ func (d *Driver) Discover(ctx context.Context, req *pc.Request_Discover) (*pc.Re
 	// are expected to remain stable and may exist in any schema, depending on how the
 	// materialization is configured.
 	var watermarkStreamID = db.WatermarksTable()
+	var signalsStreamID = strings.ToLower(db.SignalsTable())
 	var filteredBindings = []*pc.Response_Discovered_Binding{} // Empty discovery must result in `[]` rather than `null`
 	for _, binding := range discoveredBindings {
 		var res Resource
@@ -241,7 +346,7 @@ func (d *Driver) Discover(ctx context.Context, req *pc.Request_Discover) (*pc.Re
 		}
 		res.SetDefaults()
 		var streamID = JoinStreamID(res.Namespace, res.Stream)
-		if streamID != watermarkStreamID && res.Stream != "flow_materializations_v2" && res.Stream != "flow_checkpoints_v1" {
+		if streamID != watermarkStreamID && streamID != signalsStreamID && res.Stream != "flow_materializations_v2" && res.Stream != "flow_checkpoints_v1" {
 			filteredBindings = append(filteredBindings, binding)
 		} else {
 			log.WithFields(log.Fields{
@@ -279,14 +384,26 @@ func (d *Driver) Pull(open *pc.Request_Open, stream *boilerplate.PullOutput) err
 
 	var errs = db.SetupPrerequisites(ctx)
 
-	// Build a mapping from stream IDs to capture binding information
+	// Build a mapping from stream IDs to capture binding information. The transaction
+	// metadata binding, if there is one, doesn't correspond to any table.
 	var bindings = make(map[string]*Binding)
+	var transactionsBinding *Binding
 	for idx, binding := range open.Capture.Bindings {
 		var res Resource
 		if err := pf.UnmarshalStrict(binding.ResourceConfigJson, &res); err != nil {
 			return fmt.Errorf("error parsing resource config: %w", err)
 		}
 		res.SetDefaults()
+		if res.Transactions {
+			transactionsBinding = &Binding{
+				Index:         uint32(idx),
+				StreamID:      JoinStreamID(res.Namespace, res.Stream),
+				StateKey:      boilerplate.StateKey(binding.StateKey),
+				Resource:      res,
+				CollectionKey: binding.Collection.Key,
+			}
+			continue
+		}
 		if err := db.SetupTablePrerequisites(ctx, res.Namespace, res.Stream); err != nil {
 			errs = append(errs, err)
 			continue
@@ -302,12 +419,21 @@ func (d *Driver) Pull(open *pc.Request_Open, stream *boilerplate.PullOutput) err
 			}
 		}
 
+		var filter *RowFilter
+		if res.Filter != "" {
+			// The filter was already verified to parse when the resource was validated.
+			if filter, err = ParseRowFilter(res.Filter); err != nil {
+				return fmt.Errorf("invalid row filter for table %q: %w", streamID, err)
+			}
+		}
+
 		bindings[streamID] = &Binding{
 			Index:         uint32(idx),
 			StreamID:      streamID,
 			StateKey:      boilerplate.StateKey(binding.StateKey),
 			Resource:      res,
 			CollectionKey: binding.Collection.Key,
+			Filter:        filter,
 		}
 	}
 
@@ -321,6 +447,14 @@ func (d *Driver) Pull(open *pc.Request_Open, stream *boilerplate.PullOutput) err
 		State:    &state,
 		Output:   &boilerplate.PullOutput{Connector_CaptureServer: stream},
 		Database: db,
+		debezium: db.DebeziumInfo(),
+	}
+	if db.TransactionMetadata() {
+		c.transactions = &transactionTracker{}
+		c.transactionsBinding = transactionsBinding
+	}
+	if c.debezium != nil && c.debezium.Name == "" {
+		c.debezium.Name = string(open.Capture.Name)
 	}
 
 	// Notify Flow that we're ready and would like to receive acknowledgements.
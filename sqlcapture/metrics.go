@@ -0,0 +1,52 @@
+package sqlcapture
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics of the replication and backfill progress of a capture, which are served
+// along with the document metrics of source-boilerplate when metrics are enabled.
+var (
+	replicationEvents = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sqlcapture_replication_events_total",
+		Help: "The number of change events received from the replication stream.",
+	})
+	replicationLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sqlcapture_replication_lag_seconds",
+		Help: "The time between the most recent replicated change being committed by the database and being processed by the capture.",
+	})
+	backfilledRows = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sqlcapture_backfill_rows",
+		Help: "The number of rows backfilled so far, per table currently being backfilled.",
+	}, []string{"stream"})
+	backfillEstimatedRows = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sqlcapture_backfill_estimated_rows",
+		Help: "The estimated number of rows, per table currently being backfilled.",
+	}, []string{"stream"})
+)
+
+// observeReplicationEvent updates the replication metrics with a change event
+// received from the replication stream.
+func observeReplicationEvent(event *ChangeEvent) {
+	replicationEvents.Inc()
+	if millis := event.Source.Common().Millis; millis > 0 {
+		replicationLag.Set(time.Since(time.UnixMilli(millis)).Seconds())
+	}
+}
+
+// observeBackfill updates the backfill metrics of a table, or removes them once
+// the backfill is complete.
+func observeBackfill(streamID string, state *TableState) {
+	if state.Mode == TableModeActive {
+		backfilledRows.DeleteLabelValues(streamID)
+		backfillEstimatedRows.DeleteLabelValues(streamID)
+		return
+	}
+	backfilledRows.WithLabelValues(streamID).Set(float64(state.BackfilledCount))
+	if state.EstimatedRows > 0 {
+		backfillEstimatedRows.WithLabelValues(streamID).Set(float64(state.EstimatedRows))
+	}
+}
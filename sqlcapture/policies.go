@@ -0,0 +1,129 @@
+package sqlcapture
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	pf "github.com/estuary/flow/go/protocols/flow"
+	"github.com/invopop/jsonschema"
+)
+
+// ColumnPolicy represents different ways in which the values of a specific
+// column may be withheld from the captured documents.
+type ColumnPolicy string
+
+const (
+	// ColumnPolicyExclude omits the column from captured documents entirely.
+	ColumnPolicyExclude = ColumnPolicy("Exclude")
+
+	// ColumnPolicyHash replaces non-null values of the column with the hex-encoded
+	// SHA-256 hash of the configured salt followed by the JSON encoding of the value.
+	// Equal values hash identically, so the column can still be joined on.
+	ColumnPolicyHash = ColumnPolicy("Hash")
+
+	// ColumnPolicyRedact replaces all values of the column with null.
+	ColumnPolicyRedact = ColumnPolicy("Redact")
+)
+
+// JSONSchema describes the permitted column policies in the resource spec schema.
+func (ColumnPolicy) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "string",
+		Enum: []interface{}{ColumnPolicyExclude, ColumnPolicyHash, ColumnPolicyRedact},
+	}
+}
+
+// validateColumnPolicies checks that none of the column policies of a resource
+// apply to the provided key columns, since the resulting documents could then
+// no longer be keyed correctly.
+func (r Resource) validateColumnPolicies(keyColumns []string) error {
+	for _, column := range keyColumns {
+		if policy, ok := r.ColumnPolicies[column]; ok {
+			return fmt.Errorf("column %q is part of the collection key and cannot have the policy %q", column, policy)
+		}
+	}
+	return nil
+}
+
+// applyColumnPolicies returns a copy of the row with the column policies of
+// the resource applied. The row itself is left unmodified, since the same row
+// may be both the before and after state of a change event.
+func (r *Resource) applyColumnPolicies(row map[string]any) (map[string]any, error) {
+	if row == nil || len(r.ColumnPolicies) == 0 {
+		return row, nil
+	}
+	var masked = make(map[string]any, len(row))
+	for column, value := range row {
+		switch r.ColumnPolicies[column] {
+		case ColumnPolicyExclude:
+			continue
+		case ColumnPolicyRedact:
+			value = nil
+		case ColumnPolicyHash:
+			if value != nil {
+				var bs, err = json.Marshal(value)
+				if err != nil {
+					return nil, fmt.Errorf("error hashing column %q: %w", column, err)
+				}
+				var hash = sha256.Sum256(append([]byte(r.HashSalt), bs...))
+				value = hex.EncodeToString(hash[:])
+			}
+		}
+		masked[column] = value
+	}
+	return masked, nil
+}
+
+// validatePolicyTypes checks that the values which the column policies of a resource
+// produce are permitted by the schema of its collection. Discovered schemas describe
+// the columns as they are in the database and aren't adjusted for any policies, so
+// for instance hashing an integer column requires the collection schema to be edited
+// to permit strings.
+func (r Resource) validatePolicyTypes(collection *pf.CollectionSpec) error {
+	var columns []string
+	for column := range r.ColumnPolicies {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+
+	for _, column := range columns {
+		var policy = r.ColumnPolicies[column]
+		var ptr = primaryKeyToCollectionKey(column)
+		var idx = slices.IndexFunc(collection.Projections, func(p pf.Projection) bool { return p.Ptr == ptr })
+		if idx < 0 {
+			continue // The collection schema doesn't constrain the column.
+		}
+		var inference = collection.Projections[idx].Inference
+		switch policy {
+		case ColumnPolicyExclude:
+			if inference.Exists == pf.Inference_MUST {
+				return fmt.Errorf("column %q is required by the collection schema and cannot have the policy %q", column, policy)
+			}
+		case ColumnPolicyHash:
+			if !slices.Contains(inference.Types, "string") {
+				return fmt.Errorf("column %q has the policy %q which produces strings, but the collection schema only permits %q", column, policy, inference.Types)
+			}
+		case ColumnPolicyRedact:
+			if !slices.Contains(inference.Types, "null") {
+				return fmt.Errorf("column %q has the policy %q which produces nulls, but the collection schema only permits %q", column, policy, inference.Types)
+			}
+		}
+	}
+	return nil
+}
+
+// unknownPolicyColumns returns the names of any policy columns which do not
+// exist in the provided list of table columns.
+func (r Resource) unknownPolicyColumns(columns []string) []string {
+	var unknown []string
+	for column := range r.ColumnPolicies {
+		if !slices.Contains(columns, column) {
+			unknown = append(unknown, column)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
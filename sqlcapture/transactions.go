@@ -0,0 +1,178 @@
+package sqlcapture
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	pc "github.com/estuary/flow/go/protocols/capture"
+	"github.com/invopop/jsonschema"
+	"github.com/sirupsen/logrus"
+)
+
+// TransactionInfo is the transaction metadata of a replicated change event. Its
+// properties match the transaction block of a Debezium change event envelope.
+type TransactionInfo struct {
+	ID                  string `json:"id" jsonschema:"description=Identifier of the source transaction which produced this change."`
+	TotalOrder          int    `json:"total_order" jsonschema:"description=Position of this change among all changes of the source transaction starting from one."`
+	DataCollectionOrder int    `json:"data_collection_order" jsonschema:"description=Position of this change among the changes of the source transaction to the same table starting from one."`
+}
+
+// The statuses of documents captured into the transaction metadata binding.
+const (
+	TransactionStatusBegin = "BEGIN"
+	TransactionStatusEnd   = "END"
+)
+
+// Names under which the transaction metadata binding is suggested by discovery.
+// The binding is identified by the `transactions` property of its resource config,
+// so these never collide with a captured table of the same name.
+const (
+	transactionsNamespace = "_flow"
+	transactionsStream    = "transactions"
+)
+
+// transactionDocument is a document of the transaction metadata binding, which
+// marks the beginning or end of a source transaction.
+type transactionDocument struct {
+	Status          string                  `json:"status" jsonschema:"description=Whether this document marks the beginning or the end of the transaction.,enum=BEGIN,enum=END"`
+	ID              string                  `json:"id" jsonschema:"description=Identifier of the source transaction. Change events carry the same identifier in '_meta/transaction/id'."`
+	Millis          int64                   `json:"ts_ms,omitempty" jsonschema:"description=Unix timestamp (in millis) at which the transaction was committed by the database."`
+	EventCount      int                     `json:"event_count,omitempty" jsonschema:"description=The total number of change events of the transaction. Only set at the end of the transaction."`
+	DataCollections []transactionCollection `json:"data_collections,omitempty" jsonschema:"description=The number of change events of the transaction for each table. Only set at the end of the transaction."`
+}
+
+// transactionCollection is the number of change events of a transaction for one table.
+type transactionCollection struct {
+	DataCollection string `json:"data_collection" jsonschema:"description=The '<schema>.<table>' name of the table."`
+	EventCount     int    `json:"event_count" jsonschema:"description=The number of change events of the transaction for the table."`
+}
+
+// transactionTracker follows the source transaction of the replicated change events
+// being emitted, so that each change can be numbered within its transaction.
+type transactionTracker struct {
+	id     string         // ID of the current transaction, or empty if between transactions
+	millis int64          // Commit timestamp of the current transaction
+	total  int            // Number of changes of the current transaction so far
+	counts map[string]int // Number of changes of the current transaction so far, per table
+}
+
+// transactionInfo returns the transaction metadata of a change event which is about
+// to be emitted. A change from a different transaction than the preceding changes
+// ends the current transaction and begins a new one. The result is nil for backfilled
+// rows and when transaction metadata isn't enabled.
+func (c *Capture) transactionInfo(event *ChangeEvent) (*TransactionInfo, error) {
+	var txn = c.transactions
+	var sourceCommon = event.Source.Common()
+	if txn == nil || sourceCommon.Snapshot {
+		return nil, nil
+	}
+	var source, ok = event.Source.(TransactionSourceMetadata)
+	if !ok || source.TransactionID() == "" {
+		return nil, fmt.Errorf("change event for %q doesn't identify its source transaction", sourceCommon.StreamID())
+	}
+
+	var id = source.TransactionID()
+	if txn.id != "" && txn.id != id {
+		if err := c.endTransaction(); err != nil {
+			return nil, err
+		}
+	}
+	if txn.id == "" {
+		txn.id, txn.millis, txn.total, txn.counts = id, sourceCommon.Millis, 0, make(map[string]int)
+		if err := c.emitTransactionDocument(&transactionDocument{
+			Status: TransactionStatusBegin,
+			ID:     id,
+			Millis: sourceCommon.Millis,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	var collection = sourceCommon.Schema + "." + sourceCommon.Table
+	txn.total++
+	txn.counts[collection]++
+	return &TransactionInfo{
+		ID:                  id,
+		TotalOrder:          txn.total,
+		DataCollectionOrder: txn.counts[collection],
+	}, nil
+}
+
+// endTransaction ends the current transaction, if there is one, by emitting its
+// END document with the number of change events per table.
+func (c *Capture) endTransaction() error {
+	var txn = c.transactions
+	if txn == nil || txn.id == "" {
+		return nil
+	}
+	var doc = &transactionDocument{
+		Status:     TransactionStatusEnd,
+		ID:         txn.id,
+		Millis:     txn.millis,
+		EventCount: txn.total,
+	}
+	for collection, count := range txn.counts {
+		doc.DataCollections = append(doc.DataCollections, transactionCollection{collection, count})
+	}
+	slices.SortFunc(doc.DataCollections, func(a, b transactionCollection) int {
+		return strings.Compare(a.DataCollection, b.DataCollection)
+	})
+	*txn = transactionTracker{}
+	return c.emitTransactionDocument(doc)
+}
+
+// emitTransactionDocument outputs a BEGIN or END document to the transaction
+// metadata binding, if the capture has one.
+func (c *Capture) emitTransactionDocument(doc *transactionDocument) error {
+	if c.transactionsBinding == nil {
+		return nil
+	}
+	var bs, err = json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("error serializing transaction metadata document: %w", err)
+	}
+	logrus.WithFields(logrus.Fields{"status": doc.Status, "id": doc.ID}).Trace("transaction metadata")
+	return c.Output.Documents(int(c.transactionsBinding.Index), bs)
+}
+
+// transactionInfoSchema returns the schema of the `_meta/transaction` property
+// of change events when transaction metadata is enabled.
+func transactionInfoSchema() *jsonschema.Schema {
+	var schema = (&jsonschema.Reflector{
+		ExpandedStruct: true,
+		DoNotReference: true,
+	}).Reflect(&TransactionInfo{})
+	schema.Version = ""
+	schema.Description = "Source transaction of a replicated change. Unset for backfilled rows."
+	return schema
+}
+
+// discoverTransactionsBinding returns the suggested transaction metadata binding,
+// which receives one BEGIN and one END document per source transaction.
+func discoverTransactionsBinding() (*pc.Response_Discovered_Binding, error) {
+	var schema = (&jsonschema.Reflector{
+		ExpandedStruct: true,
+		DoNotReference: true,
+	}).Reflect(&transactionDocument{})
+	schema.Version = ""
+	var rawSchema, err = schema.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling transaction metadata schema JSON: %w", err)
+	}
+	resourceSpecJSON, err := json.Marshal(Resource{
+		Namespace:    transactionsNamespace,
+		Stream:       transactionsStream,
+		Transactions: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing resource spec: %w", err)
+	}
+	return &pc.Response_Discovered_Binding{
+		RecommendedName:    recommendedCatalogName(transactionsNamespace, transactionsStream),
+		ResourceConfigJson: resourceSpecJSON,
+		DocumentSchemaJson: rawSchema,
+		Key:                []string{"/id", "/status"},
+	}, nil
+}